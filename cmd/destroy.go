@@ -24,6 +24,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/engine"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 )
 
 func newDestroyCmd() *cobra.Command {
@@ -35,13 +36,18 @@ func newDestroyCmd() *cobra.Command {
 	// Flags for engine.UpdateOptions.
 	var analyzers []string
 	var diffDisplay bool
+	var eventLogPath string
+	var jsonDisplay bool
 	var parallel int
 	var refresh bool
 	var showConfig bool
 	var showReplacementSteps bool
 	var showSames bool
+	var showSecrets bool
 	var skipPreview bool
 	var suppressOutputs bool
+	var targetDependents bool
+	var targets []string
 	var yes bool
 
 	var cmd = &cobra.Command{
@@ -63,7 +69,7 @@ func newDestroyCmd() *cobra.Command {
 				yes = true // auto-approve changes, since we cannot prompt.
 			}
 
-			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
+			opts, err := updateFlagsToOptions(interactive, skipPreview, yes, jsonDisplay)
 			if err != nil {
 				return err
 			}
@@ -73,12 +79,23 @@ func newDestroyCmd() *cobra.Command {
 				ShowConfig:           showConfig,
 				ShowReplacementSteps: showReplacementSteps,
 				ShowSameResources:    showSames,
+				ShowSecrets:          showSecrets,
 				SuppressOutputs:      suppressOutputs,
 				IsInteractive:        interactive,
 				DiffDisplay:          diffDisplay,
+				JSONDisplay:          jsonDisplay,
 				Debug:                debug,
 			}
 
+			if eventLogPath != "" {
+				eventLog, err := display.OpenEventLog(eventLogPath)
+				if err != nil {
+					return err
+				}
+				defer contract.IgnoreClose(eventLog)
+				opts.Display.EventLog = eventLog
+			}
+
 			s, err := requireStack(stack, false, opts.Display, true /*setCurrent*/)
 			if err != nil {
 				return err
@@ -94,10 +111,12 @@ func newDestroyCmd() *cobra.Command {
 			}
 
 			opts.Engine = engine.UpdateOptions{
-				Analyzers: analyzers,
-				Parallel:  parallel,
-				Debug:     debug,
-				Refresh:   refresh,
+				Analyzers:        analyzers,
+				Parallel:         parallel,
+				Debug:            debug,
+				Refresh:          refresh,
+				UpdateTargets:    targetsToURNs(targets),
+				TargetDependents: targetDependents,
 			}
 
 			_, err = s.Destroy(commandContext(), backend.UpdateOperation{
@@ -134,9 +153,23 @@ func newDestroyCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().StringVar(
+		&eventLogPath, "event-log", "",
+		"Append every engine event to the given file as a line of JSON while the operation runs")
+	cmd.PersistentFlags().BoolVar(
+		&jsonDisplay, "json", false,
+		"Write a JSON description of the operation to stdout instead of displaying its progress. "+
+			"Requires --yes and --skip-preview")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().StringArrayVarP(
+		&targets, "target", "t", []string{},
+		"Specify a single resource URN to destroy. Other resources will not be destroyed. "+
+			"Multiple resources can be specified using --target urn1 --target urn2")
+	cmd.PersistentFlags().BoolVar(
+		&targetDependents, "target-dependents", false,
+		"Allows updating of dependent targets discovered but not specified in --target list")
 	cmd.PersistentFlags().BoolVarP(
 		&refresh, "refresh", "r", false,
 		"Refresh the state of the stack's resources before this update")
@@ -149,6 +182,9 @@ func newDestroyCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&showSames, "show-sames", false,
 		"Show resources that don't need to be updated because they haven't changed, alongside those that do")
+	cmd.PersistentFlags().BoolVar(
+		&showSecrets, "show-secrets", false,
+		"Show secret values in the display instead of masking them")
 	cmd.PersistentFlags().BoolVar(
 		&skipPreview, "skip-preview", false,
 		"Do not perform a preview before performing the destroy")
@@ -51,7 +51,11 @@ For example, if you are using AWS, you can confirm using the AWS Console.
 Once you have confirmed the status of the interrupted operations, you can repair your stack
 using 'pulumi stack export' to export your stack to a file. For each operation that succeeded,
 remove that operation from the "pending_operations" section of the file. Once this is complete,
-use 'pulumi stack import' to import the repaired stack.`)
+use 'pulumi stack import' to import the repaired stack.
+
+Alternatively, 'pulumi up --resume' reads each resource whose update or delete was interrupted
+from its provider and records its actual state before proceeding with the update. Interrupted
+creates cannot be read, so they must first be resolved with 'pulumi stack recover'.`)
 	contract.IgnoreError(writer.Flush())
 
 	cmdutil.Diag().Errorf(diag.RawMessage("" /*urn*/, buf.String()))
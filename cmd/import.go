@@ -0,0 +1,259 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// importFile is the format of the file accepted by `pulumi import --file`.
+type importFile struct {
+	Resources []importSpec `json:"resources"`
+}
+
+// importSpec describes a single resource to import.
+type importSpec struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	ID   string `json:"id"`
+}
+
+// parseImportSpec validates a single resource to import and converts it into its engine representation.
+func parseImportSpec(spec importSpec) (deploy.Import, error) {
+	t, err := tokens.ParseTypeToken(spec.Type)
+	if err != nil {
+		return deploy.Import{}, errors.Wrapf(err, "invalid type for resource '%v'", spec.Name)
+	}
+	if !tokens.IsQName(spec.Name) {
+		return deploy.Import{}, errors.Errorf("invalid name '%v' for resource of type '%v'", spec.Name, spec.Type)
+	}
+	if spec.ID == "" {
+		return deploy.Import{}, errors.Errorf("missing ID for resource '%v'", spec.Name)
+	}
+	return deploy.Import{Type: t, Name: tokens.QName(spec.Name), ID: resource.ID(spec.ID)}, nil
+}
+
+// readImportFile reads the list of resources to import from the given file.
+func readImportFile(path string) ([]deploy.Import, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read import file '%v'", path)
+	}
+	var file importFile
+	if err = json.Unmarshal(b, &file); err != nil {
+		return nil, errors.Wrapf(err, "could not parse import file '%v'", path)
+	}
+	if len(file.Resources) == 0 {
+		return nil, errors.Errorf("import file '%v' does not list any resources", path)
+	}
+
+	var imports []deploy.Import
+	for _, spec := range file.Resources {
+		imp, err := parseImportSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		imports = append(imports, imp)
+	}
+	return imports, nil
+}
+
+func newImportCmd() *cobra.Command {
+	var debug bool
+	var file string
+	var message string
+	var stack string
+
+	// Flags for engine.UpdateOptions.
+	var diffDisplay bool
+	var eventLogPath string
+	var parallel int
+	var showConfig bool
+	var showSecrets bool
+	var skipPreview bool
+	var suppressOutputs bool
+	var yes bool
+
+	var cmd = &cobra.Command{
+		Use:   "import [type] [name] [id]",
+		Short: "Import existing resources into a stack",
+		Long: "Import existing resources into a stack.\n" +
+			"\n" +
+			"This command reads the current state of one or more existing cloud resources from their\n" +
+			"providers and adopts them into the stack, after which they are managed by Pulumi exactly\n" +
+			"as if they had been created by it. No changes are made to the resources themselves, and no\n" +
+			"other resources in the stack are affected. To continue managing imported resources, add\n" +
+			"them to your program with inputs that match their current state.\n" +
+			"\n" +
+			"A single resource is imported by passing its type, name, and ID:\n" +
+			"\n" +
+			"    pulumi import aws:ec2/vpc:Vpc my-vpc vpc-0123456789abcdef0\n" +
+			"\n" +
+			"Many resources may be imported at once by passing a JSON file with the `--file` flag:\n" +
+			"\n" +
+			"    {\n" +
+			"        \"resources\": [\n" +
+			"            { \"type\": \"aws:ec2/vpc:Vpc\", \"name\": \"my-vpc\", \"id\": \"vpc-0123456789abcdef0\" }\n" +
+			"        ]\n" +
+			"    }",
+		Args: cmdutil.MaximumNArgs(3),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			var imports []deploy.Import
+			switch {
+			case file != "" && len(args) != 0:
+				return errors.New("a resource may not be specified on the command line when using --file")
+			case file != "":
+				fileImports, err := readImportFile(file)
+				if err != nil {
+					return err
+				}
+				imports = fileImports
+			case len(args) == 3:
+				imp, err := parseImportSpec(importSpec{Type: args[0], Name: args[1], ID: args[2]})
+				if err != nil {
+					return err
+				}
+				imports = []deploy.Import{imp}
+			default:
+				return errors.New("either a resource type, name, and ID or an import file must be specified")
+			}
+
+			interactive := cmdutil.Interactive()
+			if !interactive {
+				yes = true // auto-approve changes, since we cannot prompt.
+			}
+
+			opts, err := updateFlagsToOptions(interactive, skipPreview, yes, false /*jsonDisplay*/)
+			if err != nil {
+				return err
+			}
+
+			opts.Display = display.Options{
+				Color:           cmdutil.GetGlobalColorization(),
+				ShowConfig:      showConfig,
+				ShowSecrets:     showSecrets,
+				SuppressOutputs: suppressOutputs,
+				IsInteractive:   interactive,
+				DiffDisplay:     diffDisplay,
+				Debug:           debug,
+			}
+
+			if eventLogPath != "" {
+				eventLog, err := display.OpenEventLog(eventLogPath)
+				if err != nil {
+					return err
+				}
+				defer contract.IgnoreClose(eventLog)
+				opts.Display.EventLog = eventLog
+			}
+
+			s, err := requireStack(stack, true, opts.Display, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+
+			proj, root, err := readProject()
+			if err != nil {
+				return err
+			}
+
+			m, err := getUpdateMetadata(message, root)
+			if err != nil {
+				return errors.Wrap(err, "gathering environment metadata")
+			}
+
+			opts.Engine = engine.UpdateOptions{
+				Parallel: parallel,
+				Debug:    debug,
+			}
+
+			_, err = s.Import(commandContext(), backend.UpdateOperation{
+				Proj:    proj,
+				Root:    root,
+				M:       m,
+				Opts:    opts,
+				Scopes:  cancellationScopes,
+				Imports: imports,
+			})
+			switch {
+			case err == context.Canceled:
+				return errors.New("import cancelled")
+			case err != nil:
+				return PrintEngineError(err)
+			default:
+				return nil
+			}
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&debug, "debug", "d", false,
+		"Print detailed debugging output during resource operations")
+	cmd.PersistentFlags().StringVarP(
+		&file, "file", "f", "",
+		"The path to a JSON file that lists the resources to import")
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().StringVar(
+		&stackConfigFile, "config-file", "",
+		"Use the configuration values in the specified file rather than detecting the file name")
+
+	cmd.PersistentFlags().StringVarP(
+		&message, "message", "m", "",
+		"Optional message to associate with the import operation")
+
+	// Flags for engine.UpdateOptions.
+	cmd.PersistentFlags().BoolVar(
+		&diffDisplay, "diff", false,
+		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().StringVar(
+		&eventLogPath, "event-log", "",
+		"Append every engine event to the given file as a line of JSON while the operation runs")
+	cmd.PersistentFlags().IntVarP(
+		&parallel, "parallel", "p", defaultParallel,
+		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().BoolVar(
+		&showConfig, "show-config", false,
+		"Show configuration keys and variables")
+	cmd.PersistentFlags().BoolVar(
+		&showSecrets, "show-secrets", false,
+		"Show secret values in the display instead of masking them")
+	cmd.PersistentFlags().BoolVar(
+		&skipPreview, "skip-preview", false,
+		"Do not perform a preview before performing the import")
+	cmd.PersistentFlags().BoolVar(
+		&suppressOutputs, "suppress-outputs", false,
+		"Suppress display of stack outputs (in case they contain sensitive values)")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Automatically approve and perform the import after previewing it")
+
+	return cmd
+}
@@ -59,6 +59,14 @@ func newLoginCmd() *cobra.Command {
 			"will store your state information on your computer underneath ~/.pulumi. It is then up to you to\n" +
 			"manage this state, including backing it up, using it in a team environment, and so on.\n" +
 			"\n" +
+			"State checkpoints may instead be stored in an S3 bucket by passing s3://<bucket>/<prefix>. The\n" +
+			"region and credentials are taken from the standard AWS environment variables and configuration,\n" +
+			"and may be overridden with region= and endpoint= query parameters. For instance,\n" +
+			"\n" +
+			"    $ pulumi login 's3://my-state?endpoint=http://localhost:9000'\n" +
+			"\n" +
+			"will store your state information in the my-state bucket of an S3-compatible server on port 9000.\n" +
+			"\n" +
 			"As a shortcut, you may pass --local to use your home directory (this is an alias for file://~):\n" +
 			"\n" +
 			"    $ pulumi login --local\n",
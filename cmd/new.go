@@ -72,7 +72,7 @@ func newNewCmd() *cobra.Command {
 			}
 
 			// Prepare options.
-			opts, err := updateFlagsToOptions(interactive, false /*skipPreview*/, yes)
+			opts, err := updateFlagsToOptions(interactive, false /*skipPreview*/, yes, false /*jsonDisplay*/)
 			if err != nil {
 				return err
 			}
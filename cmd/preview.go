@@ -21,23 +21,33 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 )
 
 func newPreviewCmd() *cobra.Command {
 	var debug bool
 	var expectNop bool
 	var message string
+	var planFile string
 	var stack string
 
 	// Flags for engine.UpdateOptions.
 	var analyzers []string
 	var diffDisplay bool
+	var eventLogPath string
+	var jsonDisplay bool
 	var parallel int
+	var policyPacks []string
+	var replaces []string
 	var showConfig bool
 	var showReplacementSteps bool
 	var showSames bool
+	var showSecrets bool
 	var suppressOutputs bool
+	var targetDependents bool
+	var targets []string
 
 	var cmd = &cobra.Command{
 		Use:        "preview",
@@ -54,27 +64,44 @@ func newPreviewCmd() *cobra.Command {
 			"actually take place.\n" +
 			"\n" +
 			"The program to run is loaded from the project in the current directory. Use the `-C` or\n" +
-			"`--cwd` flag to use a different directory.",
+			"`--cwd` flag to use a different directory.\n" +
+			"\n" +
+			"Use the `--save-plan` flag to save the steps that the preview generates to a file. Passing\n" +
+			"that file to `pulumi up --plan` ensures that the update performs exactly those steps.",
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := backend.UpdateOptions{
 				Engine: engine.UpdateOptions{
-					Analyzers: analyzers,
-					Parallel:  parallel,
-					Debug:     debug,
+					Analyzers:        analyzers,
+					Parallel:         parallel,
+					Debug:            debug,
+					UpdateTargets:    targetsToURNs(targets),
+					TargetDependents: targetDependents,
+					ReplaceTargets:   targetsToURNs(replaces),
 				},
 				Display: display.Options{
 					Color:                cmdutil.GetGlobalColorization(),
 					ShowConfig:           showConfig,
 					ShowReplacementSteps: showReplacementSteps,
 					ShowSameResources:    showSames,
+					ShowSecrets:          showSecrets,
 					SuppressOutputs:      suppressOutputs,
 					IsInteractive:        cmdutil.Interactive(),
 					DiffDisplay:          diffDisplay,
+					JSONDisplay:          jsonDisplay,
 					Debug:                debug,
 				},
 			}
 
+			if eventLogPath != "" {
+				eventLog, err := display.OpenEventLog(eventLogPath)
+				if err != nil {
+					return err
+				}
+				defer contract.IgnoreClose(eventLog)
+				opts.Display.EventLog = eventLog
+			}
+
 			s, err := requireStack(stack, true, opts.Display, true /*setCurrent*/)
 			if err != nil {
 				return err
@@ -90,6 +117,14 @@ func newPreviewCmd() *cobra.Command {
 				return errors.Wrap(err, "gathering environment metadata")
 			}
 
+			if opts.Engine.PolicyPacks, err = getPolicyPacks(s, root, policyPacks); err != nil {
+				return err
+			}
+
+			if planFile != "" {
+				opts.Engine.RecordPlan = deploy.NewUpdatePlan()
+			}
+
 			changes, err := s.Preview(commandContext(), backend.UpdateOperation{
 				Proj:   proj,
 				Root:   root,
@@ -102,6 +137,8 @@ func newPreviewCmd() *cobra.Command {
 				return PrintEngineError(err)
 			case expectNop && changes != nil && changes.HasChanges():
 				return errors.New("error: no changes were expected but changes were proposed")
+			case planFile != "":
+				return writePlan(planFile, opts.Engine.RecordPlan, s)
 			default:
 				return nil
 			}
@@ -124,6 +161,9 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVarP(
 		&message, "message", "m", "",
 		"Optional message to associate with the preview operation")
+	cmd.PersistentFlags().StringVar(
+		&planFile, "save-plan", "",
+		"Save the steps generated by this preview to the given file, for use with `pulumi up --plan`")
 
 	// Flags for engine.UpdateOptions.
 	cmd.PersistentFlags().StringSliceVar(
@@ -132,9 +172,29 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().StringVar(
+		&eventLogPath, "event-log", "",
+		"Append every engine event to the given file as a line of JSON while the operation runs")
+	cmd.PersistentFlags().BoolVar(
+		&jsonDisplay, "json", false,
+		"Write a JSON description of the preview to stdout instead of displaying its progress")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().StringArrayVar(
+		&policyPacks, "policy-pack", []string{},
+		"Run the analyzers of the policy pack in the given directory as part of this update. "+
+			"Multiple policy packs can be specified using --policy-pack dir1 --policy-pack dir2")
+	cmd.PersistentFlags().StringArrayVar(
+		&replaces, "replace", []string{},
+		"Specify resources to replace. Multiple resources can be specified using --replace urn1 --replace urn2")
+	cmd.PersistentFlags().StringArrayVarP(
+		&targets, "target", "t", []string{},
+		"Specify a single resource URN to update. Other resources will not be updated. "+
+			"Multiple resources can be specified using --target urn1 --target urn2")
+	cmd.PersistentFlags().BoolVar(
+		&targetDependents, "target-dependents", false,
+		"Allows updating of dependent targets discovered but not specified in --target list")
 	cmd.PersistentFlags().BoolVar(
 		&showConfig, "show-config", false,
 		"Show configuration keys and variables")
@@ -144,6 +204,9 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&showSames, "show-sames", false,
 		"Show resources that needn't be updated because they haven't changed, alongside those that do")
+	cmd.PersistentFlags().BoolVar(
+		&showSecrets, "show-secrets", false,
+		"Show secret values in the display instead of masking them")
 	cmd.PersistentFlags().BoolVar(
 		&suppressOutputs, "suppress-outputs", false,
 		"Suppress display of stack outputs (in case they contain sensitive values)")
@@ -164,6 +164,7 @@ func NewPulumiCmd() *cobra.Command {
 	cmd.AddCommand(newWhoAmICmd())
 	//     - Advanced Commands:
 	cmd.AddCommand(newCancelCmd())
+	cmd.AddCommand(newImportCmd())
 	cmd.AddCommand(newRefreshCmd())
 	cmd.AddCommand(newStateCmd())
 	//     - Other Commands:
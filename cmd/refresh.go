@@ -24,6 +24,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/engine"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 )
 
 func newRefreshCmd() *cobra.Command {
@@ -35,12 +36,17 @@ func newRefreshCmd() *cobra.Command {
 	// Flags for engine.UpdateOptions.
 	var analyzers []string
 	var diffDisplay bool
+	var eventLogPath string
+	var jsonDisplay bool
 	var parallel int
 	var showConfig bool
 	var showReplacementSteps bool
 	var showSames bool
+	var showSecrets bool
 	var skipPreview bool
 	var suppressOutputs bool
+	var targetDependents bool
+	var targets []string
 	var yes bool
 
 	var cmd = &cobra.Command{
@@ -62,7 +68,7 @@ func newRefreshCmd() *cobra.Command {
 				yes = true // auto-approve changes, since we cannot prompt.
 			}
 
-			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
+			opts, err := updateFlagsToOptions(interactive, skipPreview, yes, jsonDisplay)
 			if err != nil {
 				return err
 			}
@@ -72,12 +78,23 @@ func newRefreshCmd() *cobra.Command {
 				ShowConfig:           showConfig,
 				ShowReplacementSteps: showReplacementSteps,
 				ShowSameResources:    showSames,
+				ShowSecrets:          showSecrets,
 				SuppressOutputs:      suppressOutputs,
 				IsInteractive:        interactive,
 				DiffDisplay:          diffDisplay,
+				JSONDisplay:          jsonDisplay,
 				Debug:                debug,
 			}
 
+			if eventLogPath != "" {
+				eventLog, err := display.OpenEventLog(eventLogPath)
+				if err != nil {
+					return err
+				}
+				defer contract.IgnoreClose(eventLog)
+				opts.Display.EventLog = eventLog
+			}
+
 			s, err := requireStack(stack, true, opts.Display, true /*setCurrent*/)
 			if err != nil {
 				return err
@@ -94,9 +111,11 @@ func newRefreshCmd() *cobra.Command {
 			}
 
 			opts.Engine = engine.UpdateOptions{
-				Analyzers: analyzers,
-				Parallel:  parallel,
-				Debug:     debug,
+				Analyzers:        analyzers,
+				Parallel:         parallel,
+				Debug:            debug,
+				UpdateTargets:    targetsToURNs(targets),
+				TargetDependents: targetDependents,
 			}
 
 			changes, err := s.Refresh(commandContext(), backend.UpdateOperation{
@@ -143,15 +162,32 @@ func newRefreshCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().StringVar(
+		&eventLogPath, "event-log", "",
+		"Append every engine event to the given file as a line of JSON while the operation runs")
+	cmd.PersistentFlags().BoolVar(
+		&jsonDisplay, "json", false,
+		"Write a JSON description of the operation to stdout instead of displaying its progress. "+
+			"Requires --yes and --skip-preview")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().StringArrayVarP(
+		&targets, "target", "t", []string{},
+		"Specify a single resource URN to refresh. "+
+			"Multiple resources can be specified using --target urn1 --target urn2")
+	cmd.PersistentFlags().BoolVar(
+		&targetDependents, "target-dependents", false,
+		"Allows updating of dependent targets discovered but not specified in --target list")
 	cmd.PersistentFlags().BoolVar(
 		&showReplacementSteps, "show-replacement-steps", false,
 		"Show detailed resource replacement creates and deletes instead of a single step")
 	cmd.PersistentFlags().BoolVar(
 		&showSames, "show-sames", false,
 		"Show resources that needn't be updated because they haven't changed, alongside those that do")
+	cmd.PersistentFlags().BoolVar(
+		&showSecrets, "show-secrets", false,
+		"Show secret values in the display instead of masking them")
 	cmd.PersistentFlags().BoolVar(
 		&skipPreview, "skip-preview", false,
 		"Do not perform a preview before performing the refresh")
@@ -31,6 +31,7 @@ import (
 func newStackCmd() *cobra.Command {
 	var showIDs bool
 	var showURNs bool
+	var showSecrets bool
 	var stackName string
 
 	cmd := &cobra.Command{
@@ -136,7 +137,7 @@ func newStackCmd() *cobra.Command {
 				})
 
 				// Print out the output properties for the stack, if present.
-				if res, outputs := stack.GetRootStackResource(snap); res != nil {
+				if res, outputs := stack.GetRootStackResource(snap, showSecrets); res != nil {
 					fmt.Printf("\n")
 					printStackOutputs(outputs)
 				}
@@ -164,16 +165,22 @@ func newStackCmd() *cobra.Command {
 		&showIDs, "show-ids", "i", false, "Display each resource's provider-assigned unique ID")
 	cmd.PersistentFlags().BoolVarP(
 		&showURNs, "show-urns", "u", false, "Display each resource's Pulumi-assigned globally unique URN")
+	cmd.PersistentFlags().BoolVar(
+		&showSecrets, "show-secrets", false, "Display the values of secret stack outputs instead of masking them")
 
 	cmd.AddCommand(newStackExportCmd())
 	cmd.AddCommand(newStackGraphCmd())
 	cmd.AddCommand(newStackImportCmd())
 	cmd.AddCommand(newStackInitCmd())
+	cmd.AddCommand(newStackLockCmd())
 	cmd.AddCommand(newStackLsCmd())
 	cmd.AddCommand(newStackOutputCmd())
+	cmd.AddCommand(newStackRecoverCmd())
+	cmd.AddCommand(newStackRenameCmd())
 	cmd.AddCommand(newStackRmCmd())
 	cmd.AddCommand(newStackSelectCmd())
 	cmd.AddCommand(newStackTagCmd())
+	cmd.AddCommand(newStackUnlockCmd())
 
 	return cmd
 }
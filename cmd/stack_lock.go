@@ -0,0 +1,142 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newStackLockCmd() *cobra.Command {
+	var stack string
+
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Inspect stack locks",
+		Long: "Inspect stack locks\n" +
+			"\n" +
+			"When using the local backend, a stack is locked for the duration of each operation that\n" +
+			"changes its state, such as `pulumi up`, so that two operations cannot change the stack at\n" +
+			"the same time. The `status` command shows which operation holds a stack's lock.\n",
+		Args: cmdutil.NoArgs,
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+
+	cmd.AddCommand(newStackLockStatusCmd(&stack))
+
+	return cmd
+}
+
+func newStackLockStatusCmd(stack *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the holder of a stack's lock",
+		Args:  cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			s, be, err := requireLockableStack(*stack)
+			if err != nil {
+				return err
+			}
+
+			lock, err := be.GetStackLock(commandContext(), s.Ref())
+			if err != nil {
+				return err
+			}
+			if lock == nil {
+				fmt.Printf("The stack '%s' is not locked.\n", s.Ref())
+			} else {
+				fmt.Printf("The stack '%s' is locked by %s.\n", s.Ref(), lock)
+			}
+			return nil
+		}),
+	}
+}
+
+func newStackUnlockCmd() *cobra.Command {
+	var stack string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "unlock",
+		Short: "Break a stack's lock",
+		Long: "Break a stack's lock\n" +
+			"\n" +
+			"If an operation that holds a stack's lock is interrupted before it can release the lock, such\n" +
+			"as when its process is killed, the stack remains locked. This command breaks the lock so that\n" +
+			"the stack can be changed again. Breaking the lock of an operation that is still running can\n" +
+			"corrupt the stack's state, so `--force` must be passed to confirm that no operation is running.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			s, be, err := requireLockableStack(stack)
+			if err != nil {
+				return err
+			}
+
+			ctx := commandContext()
+			lock, err := be.GetStackLock(ctx, s.Ref())
+			if err != nil {
+				return err
+			}
+			if lock == nil {
+				fmt.Printf("The stack '%s' is not locked.\n", s.Ref())
+				return nil
+			}
+			if !force {
+				return errors.Errorf("the stack '%s' is locked by %s; if no operation is running, "+
+					"pass --force to break the lock", s.Ref(), lock)
+			}
+
+			if err = be.BreakStackLock(ctx, s.Ref()); err != nil {
+				return err
+			}
+			fmt.Printf("Broke the lock on stack '%s', which was held by %s.\n", s.Ref(), lock)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().BoolVarP(
+		&force, "force", "f", false, "Break the lock even though it may be held by a running operation")
+
+	return cmd
+}
+
+// requireLockableStack returns the stack with the given name, or the current stack, along with its backend. Only the
+// local backend uses stack locks; the Pulumi service coordinates concurrent operations itself.
+func requireLockableStack(stackName string) (backend.Stack, filestate.Backend, error) {
+	opts := display.Options{
+		Color: cmdutil.GetGlobalColorization(),
+	}
+	s, err := requireStack(stackName, false, opts, true /*setCurrent*/)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	be, ok := s.Backend().(filestate.Backend)
+	if !ok {
+		return nil, nil, errors.Errorf("stack locks are only used by the local backend, not %s", s.Backend().URL())
+	}
+	return s, be, nil
+}
@@ -15,13 +15,16 @@
 package cmd
 
 import (
+	"context"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/backend/httpstate"
@@ -34,11 +37,21 @@ import (
 func newStackLsCmd() *cobra.Command {
 	var allStacks bool
 	var jsonOut bool
+	var tagFilters []string
 	cmd := &cobra.Command{
 		Use:   "ls",
 		Short: "List all known stacks",
-		Args:  cmdutil.NoArgs,
+		Long: "List all known stacks\n" +
+			"\n" +
+			"Stacks may be filtered by their tags with `--tag name=value`. If `--tag` is passed more\n" +
+			"than once, only stacks that have all of the given tags are listed.",
+		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			filters, err := parseStackTagFilters(tagFilters)
+			if err != nil {
+				return err
+			}
+
 			var packageFilter *tokens.PackageName
 			if !allStacks {
 				// Ensure we are in a project; if not, we will fail.
@@ -69,11 +82,17 @@ func newStackLsCmd() *cobra.Command {
 				current = s.Ref().String()
 			}
 
-			// List all of the stacks available.
+			// List all of the stacks available, keeping those that have the requested tags.
 			stackSummaries, err := b.ListStacks(commandContext(), packageFilter)
 			if err != nil {
 				return err
 			}
+			if len(filters) > 0 {
+				if stackSummaries, err = filterStackSummariesByTags(
+					commandContext(), b, stackSummaries, filters); err != nil {
+					return err
+				}
+			}
 			// Sort by stack name.
 			sort.Slice(stackSummaries, func(i, j int) bool {
 				return stackSummaries[i].Name().String() < stackSummaries[j].Name().String()
@@ -90,10 +109,52 @@ func newStackLsCmd() *cobra.Command {
 		&jsonOut, "json", "j", false, "Emit output as JSON")
 	cmd.PersistentFlags().BoolVarP(
 		&allStacks, "all", "a", false, "List all stacks instead of just stacks for the current project")
+	cmd.PersistentFlags().StringArrayVar(
+		&tagFilters, "tag", []string{}, "Only list stacks that have the given tag, in the form `name=value`")
 
 	return cmd
 }
 
+// parseStackTagFilters parses the values of the --tag flag into a set of tags that listed stacks must have.
+func parseStackTagFilters(filters []string) (map[apitype.StackTagName]string, error) {
+	tags := make(map[apitype.StackTagName]string)
+	for _, filter := range filters {
+		eq := strings.Index(filter, "=")
+		if eq <= 0 {
+			return nil, errors.Errorf("invalid tag filter '%s'; expected 'name=value'", filter)
+		}
+		tags[filter[:eq]] = filter[eq+1:]
+	}
+	return tags, nil
+}
+
+// filterStackSummariesByTags returns the stack summaries whose stacks have all of the given tags.
+func filterStackSummariesByTags(ctx context.Context, b backend.Backend, stackSummaries []backend.StackSummary,
+	filters map[apitype.StackTagName]string) ([]backend.StackSummary, error) {
+
+	var filtered []backend.StackSummary
+	for _, summary := range stackSummaries {
+		tags, err := b.GetStackTags(ctx, summary.Name())
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting tags for stack '%s'", summary.Name())
+		}
+		if stackHasTags(tags, filters) {
+			filtered = append(filtered, summary)
+		}
+	}
+	return filtered, nil
+}
+
+// stackHasTags returns true if the given stack tags include each of the given filters.
+func stackHasTags(tags, filters map[apitype.StackTagName]string) bool {
+	for name, value := range filters {
+		if actual, has := tags[name]; !has || actual != value {
+			return false
+		}
+	}
+	return true
+}
+
 // stackSummaryJSON is the shape of the --json output of this command. When --json is passed, we print an array
 // of stackSummaryJSON objects.  While we can add fields to this structure in the future, we should not change
 // existing fields.
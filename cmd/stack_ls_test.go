@@ -0,0 +1,49 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+)
+
+func TestParseStackTagFilters(t *testing.T) {
+	filters, err := parseStackTagFilters([]string{"pulumi:project=web", "owner=", "url=http://a?b=c"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[apitype.StackTagName]string{
+		"pulumi:project": "web",
+		"owner":          "",
+		"url":            "http://a?b=c",
+	}, filters)
+
+	for _, filter := range []string{"owner", "=value"} {
+		_, err = parseStackTagFilters([]string{filter})
+		assert.Error(t, err, filter)
+	}
+}
+
+func TestStackHasTags(t *testing.T) {
+	tags := map[apitype.StackTagName]string{"pulumi:project": "web", "owner": "ops"}
+
+	assert.True(t, stackHasTags(tags, nil))
+	assert.True(t, stackHasTags(tags, map[apitype.StackTagName]string{"owner": "ops"}))
+	assert.True(t, stackHasTags(tags, map[apitype.StackTagName]string{"owner": "ops", "pulumi:project": "web"}))
+	assert.False(t, stackHasTags(tags, map[apitype.StackTagName]string{"owner": "dev"}))
+	assert.False(t, stackHasTags(tags, map[apitype.StackTagName]string{"owner": "ops", "team": "ops"}))
+	assert.False(t, stackHasTags(nil, map[apitype.StackTagName]string{"owner": "ops"}))
+}
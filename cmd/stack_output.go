@@ -27,6 +27,7 @@ import (
 
 func newStackOutputCmd() *cobra.Command {
 	var jsonOut bool
+	var showSecrets bool
 	var stackName string
 
 	cmd := &cobra.Command{
@@ -36,7 +37,9 @@ func newStackOutputCmd() *cobra.Command {
 		Long: "Show a stack's output properties.\n" +
 			"\n" +
 			"By default, this command lists all output properties exported from a stack.\n" +
-			"If a specific property-name is supplied, just that property's value is shown.",
+			"If a specific property-name is supplied, just that property's value is shown.\n" +
+			"\n" +
+			"Secret outputs are masked unless --show-secrets is passed.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
@@ -52,7 +55,7 @@ func newStackOutputCmd() *cobra.Command {
 				return err
 			}
 
-			_, outputs := stack.GetRootStackResource(snap)
+			_, outputs := stack.GetRootStackResource(snap, showSecrets)
 			if outputs == nil {
 				outputs = make(map[string]interface{})
 			}
@@ -85,6 +88,8 @@ func newStackOutputCmd() *cobra.Command {
 
 	cmd.PersistentFlags().BoolVarP(
 		&jsonOut, "json", "j", false, "Emit output as JSON")
+	cmd.PersistentFlags().BoolVar(
+		&showSecrets, "show-secrets", false, "Display the values of secret outputs instead of masking them")
 	cmd.PersistentFlags().StringVarP(
 		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
 
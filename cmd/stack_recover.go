@@ -0,0 +1,268 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+	surveycore "gopkg.in/AlecAivazis/survey.v1/core"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/resource/edit"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// recoverStrategyDescriptions describes each recovery strategy for the interactive prompt.
+var recoverStrategyDescriptions = map[edit.RecoverStrategy]string{
+	edit.RecoverRefresh: "read the resource's current state from its provider",
+	edit.RecoverAccept:  "assume that the operation succeeded",
+	edit.RecoverDiscard: "assume that the operation never happened",
+}
+
+func newStackRecoverCmd() *cobra.Command {
+	var stackName string
+	var strategy string
+	cmd := &cobra.Command{
+		Use:   "recover",
+		Args:  cmdutil.NoArgs,
+		Short: "Resolve operations left pending by an interrupted update",
+		Long: "Resolve operations left pending by an interrupted update.\n" +
+			"\n" +
+			"If the Pulumi CLI is interrupted while waiting for changes to resources to complete, those\n" +
+			"resources are left with pending operations, and the stack cannot be updated until each\n" +
+			"operation is resolved. This command lists each pending operation and resolves it by either\n" +
+			"refreshing the resource from its provider, accepting the operation as having succeeded, or\n" +
+			"discarding the operation. The resolved state is then written back to the stack. Refreshing a\n" +
+			"resource or accepting its update reads the resource from its provider, which is configured from\n" +
+			"the current project and the stack's configuration.\n" +
+			"\n" +
+			"By default, the strategy for each operation is chosen interactively. Pass `--strategy` to apply\n" +
+			"the same strategy to every operation; this is required when not running interactively.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			interactive := cmdutil.Interactive()
+			if strategy != "" && recoverStrategyDescriptions[edit.RecoverStrategy(strategy)] == "" {
+				return errors.Errorf("unknown strategy '%s'; must be one of %s", strategy, recoverStrategyList())
+			} else if strategy == "" && !interactive {
+				return errors.New("--strategy must be specified when not running interactively")
+			}
+
+			s, err := requireStack(stackName, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			snap, err := s.Snapshot(commandContext())
+			if err != nil {
+				return err
+			}
+			if snap == nil || len(snap.PendingOperations) == 0 {
+				fmt.Printf("There are no pending operations to recover.\n")
+				return nil
+			}
+
+			fmt.Printf("The stack '%s' has %d pending operation(s):\n", s.Ref().Name(), len(snap.PendingOperations))
+			for _, op := range snap.PendingOperations {
+				fmt.Printf("  * %s, interrupted while %s\n", op.Resource.URN, op.Type)
+			}
+			fmt.Printf("\n")
+
+			reader := &providerReader{stack: s, snap: snap}
+			defer contract.IgnoreClose(reader)
+
+			// Iterate over a copy of the pending operations, as each is removed from the snapshot as it is recovered.
+			ops := append([]resource.Operation(nil), snap.PendingOperations...)
+			for _, op := range ops {
+				choice := edit.RecoverStrategy(strategy)
+				if choice == "" {
+					if choice, err = chooseRecoverStrategy(opts, op); err != nil {
+						return err
+					}
+				}
+
+				// A pending create does not record the ID of the resource it created, which is needed to either read
+				// or record the resource. Ask for the ID if we can; otherwise, the create can only be discarded.
+				if op.Type == resource.OperationTypeCreating && op.Resource.Custom && op.Resource.ID == "" &&
+					choice != edit.RecoverDiscard {
+
+					id := ""
+					if interactive {
+						prompt := fmt.Sprintf("Enter the ID of %s (or leave blank to discard its create)",
+							op.Resource.URN)
+						if id, err = cmdutil.ReadConsole(prompt); err != nil {
+							return err
+						}
+					} else {
+						cmdutil.Diag().Warningf(diag.RawMessage(op.Resource.URN,
+							"the ID of this resource is unknown, so its pending create will be discarded"))
+					}
+					if id == "" {
+						choice = edit.RecoverDiscard
+					} else {
+						op.Resource.ID = resource.ID(id)
+					}
+				}
+
+				if err = edit.RecoverOperation(snap, op, choice, reader.Read); err != nil {
+					return errors.Wrapf(err, "recovering the pending %s of '%s'", op.Type, op.Resource.URN)
+				}
+				fmt.Printf("Resolved the pending %s of %s (%s).\n", op.Type, op.Resource.URN, choice)
+			}
+
+			if err = snap.VerifyIntegrity(); err != nil {
+				return errors.Wrap(err, "the recovered state is invalid")
+			}
+			if err = saveSnapshot(s, snap); err != nil {
+				return errors.Wrap(err, "could not import deployment")
+			}
+			fmt.Printf("Recovery successful.\n")
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().StringVar(
+		&strategy, "strategy", "",
+		"The strategy to use for every pending operation, one of "+recoverStrategyList()+
+			". Defaults to prompting for each operation")
+
+	return cmd
+}
+
+// recoverStrategyList returns a human-readable list of the supported recovery strategies.
+func recoverStrategyList() string {
+	var names []string
+	for _, s := range edit.RecoverStrategies {
+		names = append(names, fmt.Sprintf("'%s'", s))
+	}
+	return strings.Join(names, ", ")
+}
+
+// chooseRecoverStrategy prompts the user to choose how to recover the given pending operation.
+func chooseRecoverStrategy(opts display.Options, op resource.Operation) (edit.RecoverStrategy, error) {
+	surveycore.DisableColor = true
+	surveycore.QuestionIcon = ""
+	surveycore.SelectFocusIcon = opts.Color.Colorize(colors.BrightGreen + ">" + colors.Reset)
+	prompt := fmt.Sprintf("How should the pending %s of %s be resolved?", op.Type, op.Resource.URN)
+	prompt = opts.Color.Colorize(colors.SpecPrompt + prompt + colors.Reset)
+
+	var options []string
+	optionMap := make(map[string]edit.RecoverStrategy)
+	for _, s := range edit.RecoverStrategies {
+		option := fmt.Sprintf("%s: %s", s, recoverStrategyDescriptions[s])
+		options = append(options, option)
+		optionMap[option] = s
+	}
+
+	var option string
+	if err := survey.AskOne(&survey.Select{
+		Message:  prompt,
+		Options:  options,
+		PageSize: len(options),
+	}, &option, nil); err != nil {
+		return "", errors.New("no strategy selected")
+	}
+	return optionMap[option], nil
+}
+
+// providerReader reads resources from the providers recorded in a stack's snapshot. The providers are loaded on first
+// use, with a plugin host that is configured from the current project and the stack's configuration, just as they
+// would be for an update.
+type providerReader struct {
+	stack    backend.Stack
+	snap     *deploy.Snapshot
+	ctx      *plugin.Context
+	registry *providers.Registry
+}
+
+// load creates the plugin context and the provider registry used to read resources.
+func (r *providerReader) load() error {
+	proj, root, err := readProject()
+	if err != nil {
+		return err
+	}
+	ps, err := loadProjectStack(r.stack)
+	if err != nil {
+		return err
+	}
+	target := &deploy.Target{
+		Name:      r.stack.Ref().Name(),
+		Config:    ps.Config,
+		Decrypter: backend.GetLazyStackCrypter(r.stack),
+		Snapshot:  r.snap,
+	}
+
+	projinfo := &engine.Projinfo{Proj: proj, Root: root}
+	_, _, ctx, err := engine.ProjectInfoContext(projinfo, nil, target, nil, cmdutil.Diag(), cmdutil.Diag(), nil)
+	if err != nil {
+		return err
+	}
+	registry, err := providers.NewRegistry(ctx.Host, r.snap.Resources, false, nil)
+	if err != nil {
+		contract.IgnoreClose(ctx)
+		return err
+	}
+	r.ctx, r.registry = ctx, registry
+	return nil
+}
+
+// Read reads the current outputs of the given resource from its provider.
+func (r *providerReader) Read(res *resource.State) (resource.PropertyMap, error) {
+	if r.registry == nil {
+		if err := r.load(); err != nil {
+			return nil, errors.Wrap(err, "loading providers")
+		}
+	}
+
+	if res.Provider == "" {
+		return nil, errors.Errorf("resource '%v' does not refer to a provider", res.URN)
+	}
+	ref, err := providers.ParseReference(res.Provider)
+	if err != nil {
+		return nil, err
+	}
+	prov, ok := r.registry.GetProvider(ref)
+	if !ok {
+		return nil, errors.Errorf("unknown provider '%v' for resource '%v'", ref, res.URN)
+	}
+
+	outputs, _, err := prov.Read(res.URN, res.ID, res.Outputs)
+	return outputs, err
+}
+
+// Close closes any providers that were loaded by the reader.
+func (r *providerReader) Close() error {
+	if r.ctx == nil {
+		return nil
+	}
+	return r.ctx.Close()
+}
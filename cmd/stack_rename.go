@@ -0,0 +1,92 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/backend/state"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newStackRenameCmd() *cobra.Command {
+	var stack string
+	var cmd = &cobra.Command{
+		Use:   "rename <new-stack-name>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Rename an existing stack",
+		Long: "Rename an existing stack\n" +
+			"\n" +
+			"This command renames a stack, along with the URNs of its resources and its configuration\n" +
+			"file, Pulumi.<stack-name>.yaml. The stack's update history is kept.\n" +
+			"\n" +
+			"Renaming a stack changes the value of `getStack()` inside a Pulumi program. If a resource's\n" +
+			"name is derived from the stack's name, the next update will replace the resource.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stack, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			newName := tokens.QName(args[0])
+
+			// Find the stack's configuration file, and make sure that renaming it will not overwrite another.
+			oldConfigPath, err := workspace.DetectProjectStackPath(s.Ref().Name())
+			if err != nil {
+				return err
+			}
+			newConfigPath, err := workspace.DetectProjectStackPath(newName)
+			if err != nil {
+				return err
+			}
+			if _, err = os.Stat(newConfigPath); err == nil {
+				return errors.Errorf("the configuration file %s already exists", newConfigPath)
+			}
+
+			newRef, err := s.Rename(commandContext(), newName)
+			if err != nil {
+				return err
+			}
+
+			// Move the stack's configuration, if it has any.
+			if err = os.Rename(oldConfigPath, newConfigPath); err != nil && !os.IsNotExist(err) {
+				return errors.Wrapf(err, "renamed stack '%s', but could not move its configuration", s.Ref())
+			}
+
+			if err = state.SetCurrentStack(newRef.String()); err != nil {
+				return err
+			}
+
+			fmt.Printf("Renamed stack '%s' to '%s'\n", s.Ref(), newRef)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+
+	return cmd
+}
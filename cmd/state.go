@@ -22,6 +22,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/resource"
@@ -163,7 +164,16 @@ func runTotalStateEdit(stackName string, operation func(opts display.Options, sn
 	}
 
 	// Once we've mutated the snapshot, import it back into the backend so that it can be persisted.
-	bytes, err := json.Marshal(stack.SerializeDeployment(snap))
+	return saveSnapshot(s, snap)
+}
+
+// saveSnapshot imports the given snapshot into the given stack's backend so that it is persisted.
+func saveSnapshot(s backend.Stack, snap *deploy.Snapshot) error {
+	sdep, err := stack.SerializeDeployment(snap, backend.GetLazyStackCrypter(s))
+	if err != nil {
+		return errors.Wrap(err, "serializing deployment")
+	}
+	bytes, err := json.Marshal(sdep)
 	if err != nil {
 		return err
 	}
@@ -20,6 +20,7 @@ import (
 	"io/ioutil"
 	"math"
 	"os"
+	"path/filepath"
 
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
@@ -46,19 +47,29 @@ func newUpCmd() *cobra.Command {
 	var debug bool
 	var expectNop bool
 	var message string
+	var planFile string
 	var stack string
 	var configArray []string
 
 	// Flags for engine.UpdateOptions.
 	var analyzers []string
+	var continueOnError bool
 	var diffDisplay bool
+	var eventLogPath string
+	var jsonDisplay bool
 	var parallel int
+	var policyPacks []string
 	var refresh bool
+	var replaces []string
+	var resume bool
 	var showConfig bool
 	var showReplacementSteps bool
 	var showSames bool
+	var showSecrets bool
 	var skipPreview bool
 	var suppressOutputs bool
+	var targetDependents bool
+	var targets []string
 	var yes bool
 
 	// up implementation used when the source of the Pulumi program is in the current working directory.
@@ -90,11 +101,30 @@ func newUpCmd() *cobra.Command {
 			return errors.Wrap(err, "gathering environment metadata")
 		}
 
+		packs, err := getPolicyPacks(s, root, policyPacks)
+		if err != nil {
+			return err
+		}
+
 		opts.Engine = engine.UpdateOptions{
-			Analyzers: analyzers,
-			Parallel:  parallel,
-			Debug:     debug,
-			Refresh:   refresh,
+			Analyzers:        analyzers,
+			PolicyPacks:      packs,
+			Parallel:         parallel,
+			Debug:            debug,
+			Refresh:          refresh,
+			UpdateTargets:    targetsToURNs(targets),
+			TargetDependents: targetDependents,
+			ReplaceTargets:   targetsToURNs(replaces),
+			ContinueOnError:  continueOnError,
+			Resume:           resume,
+		}
+
+		if planFile != "" {
+			plan, err := readPlan(planFile, s)
+			if err != nil {
+				return err
+			}
+			opts.Engine.UpdatePlan = plan
 		}
 
 		changes, err := s.Update(commandContext(), backend.UpdateOperation{
@@ -229,11 +259,22 @@ func newUpCmd() *cobra.Command {
 			return errors.Wrap(err, "gathering environment metadata")
 		}
 
+		packs, err := getPolicyPacks(s, root, policyPacks)
+		if err != nil {
+			return err
+		}
+
 		opts.Engine = engine.UpdateOptions{
-			Analyzers: analyzers,
-			Parallel:  parallel,
-			Debug:     debug,
-			Refresh:   refresh,
+			Analyzers:        analyzers,
+			PolicyPacks:      packs,
+			Parallel:         parallel,
+			Debug:            debug,
+			Refresh:          refresh,
+			UpdateTargets:    targetsToURNs(targets),
+			TargetDependents: targetDependents,
+			ReplaceTargets:   targetsToURNs(replaces),
+			ContinueOnError:  continueOnError,
+			Resume:           resume,
 		}
 
 		// TODO for the URL case:
@@ -283,7 +324,7 @@ func newUpCmd() *cobra.Command {
 				yes = true // auto-approve changes, since we cannot prompt.
 			}
 
-			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
+			opts, err := updateFlagsToOptions(interactive, skipPreview, yes, jsonDisplay)
 			if err != nil {
 				return err
 			}
@@ -293,13 +334,35 @@ func newUpCmd() *cobra.Command {
 				ShowConfig:           showConfig,
 				ShowReplacementSteps: showReplacementSteps,
 				ShowSameResources:    showSames,
+				ShowSecrets:          showSecrets,
 				SuppressOutputs:      suppressOutputs,
 				IsInteractive:        interactive,
 				DiffDisplay:          diffDisplay,
+				JSONDisplay:          jsonDisplay,
 				Debug:                debug,
 			}
 
+			if eventLogPath != "" {
+				eventLog, err := display.OpenEventLog(eventLogPath)
+				if err != nil {
+					return err
+				}
+				defer contract.IgnoreClose(eventLog)
+				opts.Display.EventLog = eventLog
+			}
+
+			// Policy packs passed on the command line are relative to the current directory, which changes if the
+			// program comes from a template.
+			for i, dir := range policyPacks {
+				if policyPacks[i], err = filepath.Abs(dir); err != nil {
+					return err
+				}
+			}
+
 			if len(args) > 0 {
+				if planFile != "" {
+					return errors.New("--plan may not be used when creating a new project from a template")
+				}
 				return upTemplateNameOrURL(args[0], opts)
 			}
 
@@ -326,17 +389,48 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVarP(
 		&message, "message", "m", "",
 		"Optional message to associate with the update operation")
+	cmd.PersistentFlags().StringVar(
+		&planFile, "plan", "",
+		"Fail the update if it would perform any step that is not part of the plan saved to the given file "+
+			"by `pulumi preview --save-plan`")
 
 	// Flags for engine.UpdateOptions.
 	cmd.PersistentFlags().StringSliceVar(
 		&analyzers, "analyzer", []string{},
 		"Run one or more analyzers as part of this update")
+	cmd.PersistentFlags().BoolVar(
+		&continueOnError, "continue-on-error", false,
+		"Continue updating resources that do not depend on a failed resource instead of stopping at the first failure")
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().StringVar(
+		&eventLogPath, "event-log", "",
+		"Append every engine event to the given file as a line of JSON while the operation runs")
+	cmd.PersistentFlags().BoolVar(
+		&jsonDisplay, "json", false,
+		"Write a JSON description of the operation to stdout instead of displaying its progress. "+
+			"Requires --yes and --skip-preview")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().StringArrayVar(
+		&policyPacks, "policy-pack", []string{},
+		"Run the analyzers of the policy pack in the given directory as part of this update. "+
+			"Multiple policy packs can be specified using --policy-pack dir1 --policy-pack dir2")
+	cmd.PersistentFlags().StringArrayVar(
+		&replaces, "replace", []string{},
+		"Specify resources to replace. Multiple resources can be specified using --replace urn1 --replace urn2")
+	cmd.PersistentFlags().BoolVar(
+		&resume, "resume", false,
+		"Reconcile operations left pending by an interrupted update with their providers before updating")
+	cmd.PersistentFlags().StringArrayVarP(
+		&targets, "target", "t", []string{},
+		"Specify a single resource URN to update. Other resources will not be updated. "+
+			"Multiple resources can be specified using --target urn1 --target urn2")
+	cmd.PersistentFlags().BoolVar(
+		&targetDependents, "target-dependents", false,
+		"Allows updating of dependent targets discovered but not specified in --target list")
 	cmd.PersistentFlags().BoolVarP(
 		&refresh, "refresh", "r", false,
 		"Refresh the state of the stack's resources before this update")
@@ -349,6 +443,9 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&showSames, "show-sames", false,
 		"Show resources that don't need be updated because they haven't changed, alongside those that do")
+	cmd.PersistentFlags().BoolVar(
+		&showSecrets, "show-secrets", false,
+		"Show secret values in the display instead of masking them")
 	cmd.PersistentFlags().BoolVar(
 		&skipPreview, "skip-preview", false,
 		"Do not perform a preview before performing the update")
@@ -455,7 +552,7 @@ func isPreconfiguredEmptyStack(
 	if len(snap.Resources) != 1 {
 		return false
 	}
-	stackResource, _ := stack.GetRootStackResource(snap)
+	stackResource, _ := stack.GetRootStackResource(snap, false)
 	if stackResource == nil {
 		return false
 	}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -35,6 +36,7 @@ import (
 	surveycore "gopkg.in/AlecAivazis/survey.v1/core"
 	git "gopkg.in/src-d/go-git.v4"
 
+	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/backend/filestate"
@@ -42,6 +44,9 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend/state"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
 	"github.com/pulumi/pulumi/pkg/util/cancel"
 	"github.com/pulumi/pulumi/pkg/util/ciutil"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
@@ -597,14 +602,92 @@ func printJSON(v interface{}) error {
 
 // updateFlagsToOptions ensures that the given update flags represent a valid combination.  If so, an UpdateOptions
 // is returned with a nil-error; otherwise, the non-nil error contains information about why the combination is invalid.
-func updateFlagsToOptions(interactive, skipPreview, yes bool) (backend.UpdateOptions, error) {
+func updateFlagsToOptions(interactive, skipPreview, yes, jsonDisplay bool) (backend.UpdateOptions, error) {
 	if !interactive && !yes {
 		return backend.UpdateOptions{},
 			errors.New("--yes must be passed in non-interactive mode")
 	}
+	if jsonDisplay && !yes {
+		return backend.UpdateOptions{},
+			errors.New("--yes must be passed with --json, since changes cannot be confirmed")
+	}
+	if jsonDisplay && !skipPreview {
+		return backend.UpdateOptions{},
+			errors.New("--skip-preview must be passed with --json, since only a single JSON document can be written")
+	}
 
 	return backend.UpdateOptions{
 		AutoApprove: yes,
 		SkipPreview: skipPreview,
 	}, nil
 }
+
+// targetsToURNs converts the list of URNs passed via `--target` flags into a list of resource URNs.
+func targetsToURNs(targets []string) []resource.URN {
+	var urns []resource.URN
+	for _, t := range targets {
+		urns = append(urns, resource.URN(t))
+	}
+	return urns
+}
+
+// getPolicyPacks returns the directories of the policy packs to run during a preview or update of the given stack:
+// those enabled in the stack's configuration file, which are relative to the project's root directory, followed by
+// those passed via `--policy-pack`.
+func getPolicyPacks(s backend.Stack, root string, policyPacks []string) ([]string, error) {
+	ps, err := loadProjectStack(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading stack configuration")
+	}
+
+	var dirs []string
+	for _, dir := range ps.PolicyPacks {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(root, dir)
+		}
+		dirs = append(dirs, dir)
+	}
+	for _, dir := range policyPacks {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, abs)
+	}
+	return dirs, nil
+}
+
+// readPlan reads an update plan saved by `pulumi preview --save-plan` from the given file. Any secret values in the
+// plan are decrypted using the given stack's secrets.
+func readPlan(path string, s backend.Stack) (*deploy.UpdatePlan, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read plan file '%v'", path)
+	}
+	var plan apitype.UpdatePlanV1
+	if err = json.Unmarshal(b, &plan); err != nil {
+		return nil, errors.Wrapf(err, "could not parse plan file '%v'", path)
+	}
+	result, err := stack.DeserializePlan(plan, backend.GetLazyStackCrypter(s))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not load plan file '%v'", path)
+	}
+	return result, nil
+}
+
+// writePlan saves an update plan to the given file. Any secret values in the plan are encrypted using the given stack's
+// secrets.
+func writePlan(path string, plan *deploy.UpdatePlan, s backend.Stack) error {
+	splan, err := stack.SerializePlan(plan, backend.GetLazyStackCrypter(s))
+	if err != nil {
+		return errors.Wrap(err, "serializing plan")
+	}
+	b, err := json.MarshalIndent(splan, "", "    ")
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(path, b, 0600); err != nil {
+		return errors.Wrapf(err, "could not write plan file '%v'", path)
+	}
+	return nil
+}
@@ -226,3 +226,25 @@ func TestReadingGitLabMetadata(t *testing.T) {
 		assertEnvValue(t, test, backend.VCSRepoKind, gitutil.GitLabHostName)
 	}
 }
+
+func TestUpdateFlagsToOptions(t *testing.T) {
+	// Non-interactive updates must be approved up front.
+	_, err := updateFlagsToOptions(false /*interactive*/, false /*skipPreview*/, false /*yes*/, false /*jsonDisplay*/)
+	assert.Error(t, err)
+
+	// JSON output requires both --yes and --skip-preview, rather than implying either.
+	_, err = updateFlagsToOptions(true /*interactive*/, true /*skipPreview*/, false /*yes*/, true /*jsonDisplay*/)
+	assert.Error(t, err)
+	_, err = updateFlagsToOptions(true /*interactive*/, false /*skipPreview*/, true /*yes*/, true /*jsonDisplay*/)
+	assert.Error(t, err)
+
+	opts, err := updateFlagsToOptions(true /*interactive*/, true /*skipPreview*/, true /*yes*/, true /*jsonDisplay*/)
+	assert.NoError(t, err)
+	assert.True(t, opts.AutoApprove)
+	assert.True(t, opts.SkipPreview)
+
+	opts, err = updateFlagsToOptions(true /*interactive*/, false /*skipPreview*/, false /*yes*/, false /*jsonDisplay*/)
+	assert.NoError(t, err)
+	assert.False(t, opts.AutoApprove)
+	assert.False(t, opts.SkipPreview)
+}
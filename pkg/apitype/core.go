@@ -81,6 +81,8 @@ type CheckpointV3 struct {
 	Config config.Map `json:"config,omitempty" yaml:"config,omitempty"`
 	// Latest is the latest/current deployment (if an update has occurred).
 	Latest *DeploymentV3 `json:"latest,omitempty" yaml:"latest,omitempty"`
+	// Tags contains the stack's tags.
+	Tags map[StackTagName]string `json:"tags,omitempty" yaml:"tags,omitempty"`
 }
 
 // DeploymentV1 represents a deployment that has actually occurred. It is similar to the engine's snapshot structure,
@@ -276,6 +278,44 @@ type ResourceV3 struct {
 	// PendingReplacement is used to track delete-before-replace resources that have been deleted but not yet
 	// recreated.
 	PendingReplacement bool `json:"pendingReplacement,omitempty" yaml:"pendingReplacement,omitempty"`
+	// CustomTimeouts is the set of timeouts, in seconds, that apply to this resource's create, update, and delete
+	// operations.
+	CustomTimeouts *CustomTimeoutsV1 `json:"customTimeouts,omitempty" yaml:"customTimeouts,omitempty"`
+	// RetainOnDelete is true if the resource should be removed from the stack, rather than deleted by its provider,
+	// when it is deleted or replaced.
+	RetainOnDelete bool `json:"retainOnDelete,omitempty" yaml:"retainOnDelete,omitempty"`
+	// RetryPolicy is the policy, with delays in seconds, that overrides how this resource's create, update, and delete
+	// operations are retried after transient failures.
+	RetryPolicy *RetryPolicyV1 `json:"retryPolicy,omitempty" yaml:"retryPolicy,omitempty"`
+	// ReplaceOnChanges is a list of property paths whose changes force the resource to be replaced rather than updated
+	// in place.
+	ReplaceOnChanges []string `json:"replaceOnChanges,omitempty" yaml:"replaceOnChanges,omitempty"`
+}
+
+// CustomTimeoutsV1 is the set of timeouts, in seconds, that apply to a resource's create, update, and delete
+// operations. A timeout of zero means that the corresponding operation is not subject to a timeout.
+type CustomTimeoutsV1 struct {
+	// Create is the timeout for create operations.
+	Create float64 `json:"create,omitempty" yaml:"create,omitempty"`
+	// Update is the timeout for update operations.
+	Update float64 `json:"update,omitempty" yaml:"update,omitempty"`
+	// Delete is the timeout for delete operations.
+	Delete float64 `json:"delete,omitempty" yaml:"delete,omitempty"`
+}
+
+// RetryPolicyV1 describes how a resource's create, update, and delete operations are retried when they fail with a
+// transient error. Delays are in seconds, and a zero value for any field means that the field is unset.
+type RetryPolicyV1 struct {
+	// MaxAttempts is the maximum number of attempts.
+	MaxAttempts int `json:"maxAttempts,omitempty" yaml:"maxAttempts,omitempty"`
+	// Delay is the delay before the first retry.
+	Delay float64 `json:"delay,omitempty" yaml:"delay,omitempty"`
+	// MaxDelay is the maximum delay between retries.
+	MaxDelay float64 `json:"maxDelay,omitempty" yaml:"maxDelay,omitempty"`
+	// Backoff is the multiplier for each delay.
+	Backoff float64 `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+	// Jitter is the fraction by which delays vary.
+	Jitter float64 `json:"jitter,omitempty" yaml:"jitter,omitempty"`
 }
 
 // ManifestV1 captures meta-information about this checkpoint file, such as versions of binaries, etc.
@@ -298,6 +338,61 @@ type PluginInfoV1 struct {
 	Version string               `json:"version" yaml:"version"`
 }
 
+// UpdatePlanV1 is a serialized update plan, which records the steps that an update is expected to perform.
+type UpdatePlanV1 struct {
+	// Version is the version of the plan format. It is always 1.
+	Version int `json:"version" yaml:"version"`
+	// Resources contains the plan for each resource, keyed by URN.
+	Resources map[resource.URN]ResourcePlanV1 `json:"resources,omitempty" yaml:"resources,omitempty"`
+}
+
+// ResourcePlanV1 records the steps that an update is expected to perform on a single resource.
+type ResourcePlanV1 struct {
+	// Ops contains the steps that are expected to be performed on the resource, in order.
+	Ops []string `json:"ops" yaml:"ops"`
+	// Inputs contains the resource's expected input properties.
+	Inputs map[string]interface{} `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+	// Outputs contains the resource's expected output properties.
+	Outputs map[string]interface{} `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+}
+
+// JournalEntryKind is the kind of change that a journal entry makes to the resources of a deployment.
+type JournalEntryKind string
+
+const (
+	// JournalEntryCreate inserts a resource into the deployment's resources at the entry's index.
+	JournalEntryCreate JournalEntryKind = "create"
+	// JournalEntryUpdate replaces the resource at the entry's index.
+	JournalEntryUpdate JournalEntryKind = "update"
+	// JournalEntryDelete removes the resource at the entry's index.
+	JournalEntryDelete JournalEntryKind = "delete"
+)
+
+// JournalEntryV1 is a single change to the list of resources in a deployment.
+type JournalEntryV1 struct {
+	// Kind is the kind of change.
+	Kind JournalEntryKind `json:"kind" yaml:"kind"`
+	// Index is the index in the deployment's resources at which the change is made.
+	Index int `json:"index" yaml:"index"`
+	// Resource is the created or updated resource. It is omitted for deletions.
+	Resource *ResourceV3 `json:"resource,omitempty" yaml:"resource,omitempty"`
+}
+
+// JournalRecordV1 records the changes that turn one deployment into the next. A checkpoint's journal is a sequence of
+// records that are applied in order to the checkpoint's latest deployment, so that an update need not rewrite the
+// entire checkpoint each time it changes a resource.
+type JournalRecordV1 struct {
+	// Base is the time in the manifest of the deployment to which the journal applies. Records whose base does not
+	// match the checkpoint's latest deployment are stale and must be ignored.
+	Base time.Time `json:"base" yaml:"base"`
+	// Manifest replaces the manifest of the deployment.
+	Manifest ManifestV1 `json:"manifest" yaml:"manifest"`
+	// Entries are the changes to the deployment's resources, which must be applied in order.
+	Entries []JournalEntryV1 `json:"entries,omitempty" yaml:"entries,omitempty"`
+	// PendingOperations replaces the pending operations of the deployment.
+	PendingOperations []OperationV2 `json:"pending_operations,omitempty" yaml:"pending_operations,omitempty"`
+}
+
 // SecretV1 captures the information that a particular value is secret and must be decrypted before use.
 //
 // NOTE: nothing produces these values yet. This type is merely a placeholder for future use.
@@ -341,6 +436,9 @@ const (
 	// VCSRepositoryKindTag is a tag that represents the kind of the cloud VCS that this stack
 	// may be associated with (inferred by the CLI based on the git remote info).
 	VCSRepositoryKindTag StackTagName = "vcs:kind"
+	// CISystemTag is a tag that represents the name of the CI system that last updated this stack
+	// (inferred by the CLI based on the environment).
+	CISystemTag StackTagName = "ci:system"
 )
 
 // Stack describes a Stack running on a Pulumi Cloud.
@@ -61,6 +61,11 @@ type SummaryEvent struct {
 	// ResourceChanges contains the count for resource change by type. The keys are deploy.StepOp,
 	// which is not exported in this package.
 	ResourceChanges map[string]int `json:"resourceChanges"`
+	// FailedResources contains the URNs of the resources whose operations failed.
+	FailedResources []string `json:"failedResources,omitempty"`
+	// SkippedResources contains the URNs of the resources whose operations were skipped because a resource on
+	// which they depend failed.
+	SkippedResources []string `json:"skippedResources,omitempty"`
 }
 
 // StepEventMetadata describes a "step" within the Pulumi engine, which is any concrete action
@@ -81,10 +86,24 @@ type StepEventMetadata struct {
 
 	// Keys causing a replacement (only applicable for "create" and "replace" Ops).
 	Keys []string `json:"keys,omitempty"`
+	// ForceReplace is set if the replacement was explicitly requested rather than caused by a change (only applicable
+	// for "create" and "replace" Ops).
+	ForceReplace bool `json:"forceReplace,omitempty"`
 	// Logical is set if the step is a logical operation in the program.
 	Logical bool `json:"logical"`
 	// Provider actually performing the step.
 	Provider string `json:"provider"`
+	// DetailedDiff is the structured property diff reported by the provider, if any. The keys are property paths.
+	DetailedDiff map[string]PropertyDiff `json:"detailedDiff,omitempty"`
+}
+
+// PropertyDiff describes the difference between a single property's old and new values.
+type PropertyDiff struct {
+	// Kind is the kind of difference: one of "add", "add-replace", "delete", "delete-replace", "update", or
+	// "update-replace".
+	Kind string `json:"kind"`
+	// InputDiff is true if this is a difference between old and new inputs rather than old state and new inputs.
+	InputDiff bool `json:"inputDiff"`
 }
 
 // StepEventStateMetadata is the more detailed state information for a resource as it relates to
@@ -103,6 +122,9 @@ type StepEventStateMetadata struct {
 	Parent string `json:"parent"`
 	// Protect is true to "protect" this resource (protected resources cannot be deleted).
 	Protect bool `json:"protect"`
+	// RetainOnDelete is true if the resource is removed from the stack, rather than deleted by its provider, when it
+	// is deleted.
+	RetainOnDelete bool `json:"retainOnDelete,omitempty"`
 	// Inputs contains the resource's input properties (as specified by the program). Secrets have
 	// filtered out, and large assets have been replaced by hashes as applicable.
 	Inputs map[string]interface{} `json:"inputs"`
@@ -0,0 +1,69 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apitype
+
+const (
+	// UpdateResultSchemaVersionCurrent is the current version of the `UpdateResult` schema. Fields may be added to
+	// the schema without changing its version; any other change requires a new version.
+	UpdateResultSchemaVersionCurrent = 1
+)
+
+// UpdateResultV1 is the machine-readable description of a preview, update, refresh, or destroy that is written by the
+// CLI when it is passed `--json`.
+type UpdateResultV1 struct {
+	// Version is the version of the result format. It is always 1.
+	Version int `json:"version"`
+	// Kind is the kind of operation that was performed.
+	Kind UpdateKind `json:"kind"`
+	// Preview is true if the operation was a preview, in which case no changes were applied.
+	Preview bool `json:"preview"`
+	// Config contains the keys and values of the stack's configuration. Secret values are blinded.
+	Config map[string]string `json:"config,omitempty"`
+	// Steps contains the steps that the operation performed (or would perform), in the order in which they began.
+	Steps []UpdateStepV1 `json:"steps"`
+	// Diagnostics contains the diagnostic messages that were reported during the operation, in order. Ephemeral
+	// status messages are omitted.
+	Diagnostics []DiagnosticEvent `json:"diagnostics,omitempty"`
+	// Outputs contains the stack's outputs.
+	Outputs map[string]interface{} `json:"outputs,omitempty"`
+	// Summary summarizes the changes that the operation made (or would make). It is absent if the operation failed
+	// before it could complete.
+	Summary *SummaryEvent `json:"summary,omitempty"`
+}
+
+// UpdateStepV1 describes a single step that an operation performed (or would perform) on a resource.
+type UpdateStepV1 struct {
+	// Op is the operation performed by the step, e.g. "create" or "update".
+	Op string `json:"op"`
+	// URN is the URN of the resource affected by the step.
+	URN string `json:"urn"`
+	// Type is the type of the resource affected by the step.
+	Type string `json:"type"`
+	// Provider is the reference of the provider that performed the step, if any.
+	Provider string `json:"provider,omitempty"`
+	// Old is the state of the resource before the step, if any.
+	Old *StepEventStateMetadata `json:"old,omitempty"`
+	// New is the state of the resource after the step, if any.
+	New *StepEventStateMetadata `json:"new,omitempty"`
+	// ReplaceKeys contains the properties whose changes caused the resource to be replaced, if any.
+	ReplaceKeys []string `json:"replaceKeys,omitempty"`
+	// ForceReplace is true if the resource is replaced because its replacement was explicitly requested.
+	ForceReplace bool `json:"forceReplace,omitempty"`
+	// Diffs contains the difference between the resource's old and new properties, keyed by property path. If the
+	// resource's provider did not report a detailed diff, only top-level properties are described.
+	Diffs map[string]PropertyDiff `json:"diffs,omitempty"`
+	// Failed is true if the step failed.
+	Failed bool `json:"failed,omitempty"`
+}
@@ -46,6 +46,12 @@ type CreateStackRequest struct {
 // CreateStackResponse is the response from a create Stack request.
 type CreateStackResponse struct{}
 
+// StackRenameRequest defines the request body for renaming a Stack.
+type StackRenameRequest struct {
+	// The rest of the StackIdentifier (owner, project, current name) is in the URL.
+	NewName string `json:"newName"`
+}
+
 // EncryptValueRequest defines the request body for encrypting a value.
 type EncryptValueRequest struct {
 	// The value to encrypt.
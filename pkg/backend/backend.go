@@ -94,6 +94,9 @@ type Backend interface {
 	RemoveStack(ctx context.Context, stackRef StackReference, force bool) (bool, error)
 	// ListStacks returns a list of stack summaries for all known stacks in the target backend.
 	ListStacks(ctx context.Context, projectFilter *tokens.PackageName) ([]StackSummary, error)
+	// RenameStack renames the given stack, rewriting the URNs of its resources to refer to the new name, and returns a
+	// reference to the renamed stack. The stack's update history is kept wherever the backend is able to.
+	RenameStack(ctx context.Context, stackRef StackReference, newName tokens.QName) (StackReference, error)
 
 	// GetStackCrypter returns an encrypter/decrypter for the given stack's secret config values.
 	GetStackCrypter(stackRef StackReference) (config.Crypter, error)
@@ -104,6 +107,8 @@ type Backend interface {
 	Update(ctx context.Context, stackRef StackReference, op UpdateOperation) (engine.ResourceChanges, error)
 	// Refresh refreshes the stack's state from the cloud provider.
 	Refresh(ctx context.Context, stackRef StackReference, op UpdateOperation) (engine.ResourceChanges, error)
+	// Import adopts the existing resources listed in the operation into the stack.
+	Import(ctx context.Context, stackRef StackReference, op UpdateOperation) (engine.ResourceChanges, error)
 	// Destroy destroys all of this stack's resources.
 	Destroy(ctx context.Context, stackRef StackReference, op UpdateOperation) (engine.ResourceChanges, error)
 
@@ -132,11 +137,12 @@ type Backend interface {
 
 // UpdateOperation is a complete stack update operation (preview, update, refresh, or destroy).
 type UpdateOperation struct {
-	Proj   *workspace.Project
-	Root   string
-	M      *UpdateMetadata
-	Opts   UpdateOptions
-	Scopes CancellationScopeSource
+	Proj    *workspace.Project
+	Root    string
+	M       *UpdateMetadata
+	Opts    UpdateOptions
+	Scopes  CancellationScopeSource
+	Imports []deploy.Import // the resources to adopt, for import operations.
 }
 
 // UpdateOptions is the full set of update options, including backend and engine options.
@@ -216,7 +222,7 @@ func (c *backendClient) GetStackOutputs(ctx context.Context, name string) (resou
 	if err != nil {
 		return nil, err
 	}
-	res, _ := stack.GetRootStackResource(snap)
+	res, _ := stack.GetRootStackResource(snap, false)
 	if res == nil {
 		return resource.PropertyMap{}, nil
 	}
@@ -43,13 +43,70 @@ func ShowEvents(
 	op string, action apitype.UpdateKind, stack tokens.QName, proj tokens.PackageName,
 	events <-chan engine.Event, done chan<- bool, opts Options, isPreview bool) {
 
-	if opts.DiffDisplay {
+	if opts.EventLog != nil {
+		events = logEvents(events, opts.EventLog)
+	}
+
+	// The JSON display reveals secrets itself, if requested, as it converts each event.
+	if opts.ShowSecrets && !opts.JSONDisplay {
+		events = revealSecrets(events)
+	}
+
+	if opts.JSONDisplay {
+		ShowJSONEvents(action, events, done, opts, isPreview)
+	} else if opts.DiffDisplay {
 		ShowDiffEvents(op, action, events, done, opts)
 	} else {
 		ShowProgressEvents(op, action, stack, proj, events, done, opts, isPreview)
 	}
 }
 
+// revealSecrets returns a channel that carries the events from the given channel with the underlying values of any
+// secrets in their resource states revealed. The returned channel is closed once the given channel is closed.
+func revealSecrets(events <-chan engine.Event) <-chan engine.Event {
+	revealed := make(chan engine.Event)
+	go func() {
+		defer close(revealed)
+		for e := range events {
+			switch p := e.Payload.(type) {
+			case engine.ResourcePreEventPayload:
+				p.Metadata = revealStepEventMetadataSecrets(p.Metadata)
+				e.Payload = p
+			case engine.ResourceOutputsEventPayload:
+				p.Metadata = revealStepEventMetadataSecrets(p.Metadata)
+				e.Payload = p
+			case engine.ResourceOperationFailedPayload:
+				p.Metadata = revealStepEventMetadataSecrets(p.Metadata)
+				e.Payload = p
+			}
+			revealed <- e
+		}
+	}()
+	return revealed
+}
+
+func revealStepEventMetadataSecrets(md engine.StepEventMetadata) engine.StepEventMetadata {
+	md.Old = revealStepEventStateMetadataSecrets(md.Old)
+	md.New = revealStepEventStateMetadataSecrets(md.New)
+	md.Res = revealStepEventStateMetadataSecrets(md.Res)
+	return md
+}
+
+func revealStepEventStateMetadataSecrets(md *engine.StepEventStateMetadata) *engine.StepEventStateMetadata {
+	if md == nil {
+		return nil
+	}
+
+	reveal := func(s *resource.Secret) resource.PropertyValue {
+		return s.Element
+	}
+
+	revealed := *md
+	revealed.Inputs = md.Inputs.MapSecrets(reveal)
+	revealed.Outputs = md.Outputs.MapSecrets(reveal)
+	return &revealed
+}
+
 type nopSpinner struct {
 }
 
@@ -212,6 +269,10 @@ func renderSummaryEvent(action apitype.UpdateKind, event engine.SummaryEventPayl
 		fprintfIgnoreError(out, "\n")
 	}
 
+	// If any resources failed or were skipped, list them.
+	renderResourceList(out, "Failed resources", event.FailedResources, opts)
+	renderResourceList(out, "Skipped resources", event.SkippedResources, opts)
+
 	// For actual deploys, we print some additional summary information
 	if !event.IsPreview {
 		// Round up to the nearest second.  It's not useful to spit out time with 9 digits of
@@ -226,6 +287,18 @@ func renderSummaryEvent(action apitype.UpdateKind, event engine.SummaryEventPayl
 	return out.String()
 }
 
+func renderResourceList(out *bytes.Buffer, title string, urns []resource.URN, opts Options) {
+	if len(urns) == 0 {
+		return
+	}
+
+	fprintIgnoreError(out, opts.Color.Colorize(
+		fmt.Sprintf("\n%s%s:%s\n", colors.SpecHeadline, title, colors.Reset)))
+	for _, urn := range urns {
+		fprintfIgnoreError(out, "    %v\n", urn)
+	}
+}
+
 func renderPreludeEvent(event engine.PreludeEventPayload, opts Options) string {
 	// Only if we have been instructed to show configuration values will we print anything during the prelude.
 	if !opts.ShowConfig {
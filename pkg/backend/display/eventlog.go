@@ -0,0 +1,88 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// EventLog appends engine events to a file as they occur. Each event is written in its apitype.EngineEvent form as a
+// single line of JSON. Events are numbered in the order in which they are recorded, starting from one each time the
+// log is opened, and are stamped with the time at which they were recorded.
+type EventLog struct {
+	lock     sync.Mutex // guards the file and the sequence number.
+	file     *os.File   // the file to which events are appended.
+	sequence int        // the sequence number of the last event that was recorded.
+}
+
+// OpenEventLog opens the event log at the given path, creating it if it does not exist. Events are appended to any
+// existing contents.
+func OpenEventLog(path string) (*EventLog, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening event log")
+	}
+	return &EventLog{file: file}, nil
+}
+
+// Record appends the given event to the log.
+func (l *EventLog) Record(e engine.Event) error {
+	apiEvent, err := ConvertEngineEvent(e, false /*showSecrets*/)
+	if err != nil {
+		return err
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.sequence++
+	apiEvent.Sequence = l.sequence
+	apiEvent.Timestamp = int(time.Now().Unix())
+
+	b, err := json.Marshal(apiEvent)
+	if err != nil {
+		return err
+	}
+	_, err = l.file.Write(append(b, '\n'))
+	return err
+}
+
+// Close closes the log's file.
+func (l *EventLog) Close() error {
+	return l.file.Close()
+}
+
+// logEvents returns a channel that carries the events from the given channel, recording each event in the given log
+// as it passes through. The returned channel is closed once the given channel is closed.
+func logEvents(events <-chan engine.Event, log *EventLog) <-chan engine.Event {
+	logged := make(chan engine.Event)
+	go func() {
+		defer close(logged)
+		for e := range events {
+			// A failure to record an event must not interrupt the operation, so errors are ignored.
+			contract.IgnoreError(log.Record(e))
+			logged <- e
+		}
+	}()
+	return logged
+}
@@ -0,0 +1,75 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/engine"
+)
+
+func TestEventLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eventlog")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.json")
+
+	// Each time the log is opened, its events are appended to the file and numbered from one.
+	for i := 0; i < 2; i++ {
+		log, err := OpenEventLog(path)
+		assert.NoError(t, err)
+
+		events := make(chan engine.Event)
+		logged := logEvents(events, log)
+		go func() {
+			events <- engine.Event{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{
+				Message: "message", Severity: diag.Warning}}
+			events <- engine.Event{Type: engine.CancelEvent}
+			close(events)
+		}()
+		for range logged {
+		}
+		assert.NoError(t, log.Close())
+	}
+
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if assert.Len(t, lines, 4) {
+		for i, line := range lines {
+			var event apitype.EngineEvent
+			assert.NoError(t, json.Unmarshal([]byte(line), &event))
+			assert.Equal(t, i%2+1, event.Sequence)
+			assert.NotZero(t, event.Timestamp)
+			if i%2 == 0 {
+				if assert.NotNil(t, event.DiagnosticEvent) {
+					assert.Equal(t, "message", event.DiagnosticEvent.Message)
+					assert.Equal(t, "warning", event.DiagnosticEvent.Severity)
+				}
+			} else {
+				assert.NotNil(t, event.CancelEvent)
+			}
+		}
+	}
+}
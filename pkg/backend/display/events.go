@@ -0,0 +1,220 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// ConvertEngineEvent converts a raw engine.Event into an apitype.EngineEvent used in the Pulumi
+// REST API. Returns an error if the engine event is unknown or not in an expected format.
+// EngineEvent.{ Sequence, Timestamp } are expected to be set by the caller. The values of secrets
+// are masked unless showSecrets is true.
+func ConvertEngineEvent(e engine.Event, showSecrets bool) (apitype.EngineEvent, error) {
+	var apiEvent apitype.EngineEvent
+
+	// Error to return if the payload doesn't match expected.
+	eventTypePayloadMismatch := errors.Errorf("unexpected payload for event type %v", e.Type)
+
+	switch e.Type {
+	case engine.CancelEvent:
+		apiEvent.CancelEvent = &apitype.CancelEvent{}
+
+	case engine.StdoutColorEvent:
+		p, ok := e.Payload.(engine.StdoutEventPayload)
+		if !ok {
+			return apiEvent, eventTypePayloadMismatch
+		}
+		apiEvent.StdoutEvent = &apitype.StdoutEngineEvent{
+			Message: p.Message,
+			Color:   string(p.Color),
+		}
+
+	case engine.DiagEvent:
+		p, ok := e.Payload.(engine.DiagEventPayload)
+		if !ok {
+			return apiEvent, eventTypePayloadMismatch
+		}
+		apiEvent.DiagnosticEvent = &apitype.DiagnosticEvent{
+			URN:       string(p.URN),
+			Prefix:    p.Prefix,
+			Message:   p.Message,
+			Color:     string(p.Color),
+			Severity:  string(p.Severity),
+			Ephemeral: p.Ephemeral,
+		}
+
+	case engine.PreludeEvent:
+		p, ok := e.Payload.(engine.PreludeEventPayload)
+		if !ok {
+			return apiEvent, eventTypePayloadMismatch
+		}
+		// Convert the config bag.
+		cfg := make(map[string]string)
+		for k, v := range p.Config {
+			cfg[k] = v
+		}
+		apiEvent.PreludeEvent = &apitype.PreludeEvent{
+			Config: cfg,
+		}
+
+	case engine.SummaryEvent:
+		p, ok := e.Payload.(engine.SummaryEventPayload)
+		if !ok {
+			return apiEvent, eventTypePayloadMismatch
+		}
+		// Convert the resource changes.
+		changes := make(map[string]int)
+		for op, count := range p.ResourceChanges {
+			changes[string(op)] = count
+		}
+		apiEvent.SummaryEvent = &apitype.SummaryEvent{
+			MaybeCorrupt:     p.MaybeCorrupt,
+			DurationSeconds:  int(p.Duration.Seconds()),
+			ResourceChanges:  changes,
+			FailedResources:  convertURNs(p.FailedResources),
+			SkippedResources: convertURNs(p.SkippedResources),
+		}
+
+	case engine.ResourcePreEvent:
+		p, ok := e.Payload.(engine.ResourcePreEventPayload)
+		if !ok {
+			return apiEvent, eventTypePayloadMismatch
+		}
+		apiEvent.ResourcePreEvent = &apitype.ResourcePreEvent{
+			Metadata: convertStepEventMetadata(p.Metadata, showSecrets),
+			Planning: p.Planning,
+		}
+
+	case engine.ResourceOutputsEvent:
+		p, ok := e.Payload.(engine.ResourceOutputsEventPayload)
+		if !ok {
+			return apiEvent, eventTypePayloadMismatch
+		}
+		apiEvent.ResOutputsEvent = &apitype.ResOutputsEvent{
+			Metadata: convertStepEventMetadata(p.Metadata, showSecrets),
+			Planning: p.Planning,
+		}
+
+	case engine.ResourceOperationFailed:
+		p, ok := e.Payload.(engine.ResourceOperationFailedPayload)
+		if !ok {
+			return apiEvent, eventTypePayloadMismatch
+		}
+		apiEvent.ResOpFailedEvent = &apitype.ResOpFailedEvent{
+			Metadata: convertStepEventMetadata(p.Metadata, showSecrets),
+			Status:   int(p.Status),
+			Steps:    p.Steps,
+		}
+
+	default:
+		return apiEvent, errors.Errorf("unknown event type %q", e.Type)
+	}
+
+	return apiEvent, nil
+}
+
+func convertStepEventMetadata(md engine.StepEventMetadata, showSecrets bool) apitype.StepEventMetadata {
+	keys := make([]string, len(md.Keys))
+	for i, v := range md.Keys {
+		keys[i] = string(v)
+	}
+
+	var detailedDiff map[string]apitype.PropertyDiff
+	if md.DetailedDiff != nil {
+		detailedDiff = make(map[string]apitype.PropertyDiff)
+		for path, pd := range md.DetailedDiff {
+			detailedDiff[path] = apitype.PropertyDiff{
+				Kind:      pd.Kind.String(),
+				InputDiff: pd.InputDiff,
+			}
+		}
+	}
+
+	return apitype.StepEventMetadata{
+		Op:   string(md.Op),
+		URN:  string(md.URN),
+		Type: string(md.Type),
+
+		Old: convertStepEventStateMetadata(md.Old, showSecrets),
+		New: convertStepEventStateMetadata(md.New, showSecrets),
+		Res: convertStepEventStateMetadata(md.Res, showSecrets),
+
+		Keys:         keys,
+		ForceReplace: md.ForceReplace,
+		Logical:      md.Logical,
+		Provider:     md.Provider,
+		DetailedDiff: detailedDiff,
+	}
+}
+
+func convertStepEventStateMetadata(md *engine.StepEventStateMetadata,
+	showSecrets bool) *apitype.StepEventStateMetadata {
+
+	if md == nil {
+		return nil
+	}
+
+	// Unless they were asked for, never write the underlying values of secrets. Because every secret is either masked
+	// or revealed, the properties' serialization never needs to encrypt anything.
+	mapSecret := func(*resource.Secret) resource.PropertyValue {
+		return resource.NewStringProperty("[secret]")
+	}
+	if showSecrets {
+		mapSecret = func(s *resource.Secret) resource.PropertyValue {
+			return s.Element
+		}
+	}
+
+	inputs, err := stack.SerializeProperties(md.Inputs.MapSecrets(mapSecret), config.NewPanicCrypter())
+	contract.IgnoreError(err)
+	outputs, err := stack.SerializeProperties(md.Outputs.MapSecrets(mapSecret), config.NewPanicCrypter())
+	contract.IgnoreError(err)
+
+	return &apitype.StepEventStateMetadata{
+		Type: string(md.Type),
+		URN:  string(md.URN),
+
+		Custom:         md.Custom,
+		Delete:         md.Delete,
+		ID:             string(md.ID),
+		Parent:         string(md.Parent),
+		Protect:        md.Protect,
+		RetainOnDelete: md.RetainOnDelete,
+		Inputs:         inputs,
+		Outputs:        outputs,
+		InitErrors:     md.InitErrors,
+	}
+}
+
+// convertURNs converts a list of resource URNs into their string representations.
+func convertURNs(urns []resource.URN) []string {
+	if len(urns) == 0 {
+		return nil
+	}
+
+	result := make([]string, len(urns))
+	for i, urn := range urns {
+		result[i] = string(urn)
+	}
+	return result
+}
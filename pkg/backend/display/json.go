@@ -0,0 +1,186 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// ShowJSONEvents reads events from the `events` channel until it is closed or a cancel event is received, and then
+// writes a JSON document that describes the operation, an apitype.UpdateResultV1, to stdout. Nothing is written while
+// the operation is in progress.
+func ShowJSONEvents(action apitype.UpdateKind, events <-chan engine.Event, done chan<- bool, opts Options,
+	isPreview bool) {
+
+	defer close(done)
+
+	result := newJSONResult(action, isPreview)
+	for e := range events {
+		if e.Type == engine.CancelEvent {
+			break
+		}
+		result.recordEvent(e, opts)
+	}
+
+	b, err := json.MarshalIndent(result.UpdateResultV1, "", "    ")
+	contract.IgnoreError(err)
+	fprintIgnoreError(os.Stdout, string(b)+"\n")
+}
+
+// jsonResult accumulates the description of an operation from the operation's engine events.
+type jsonResult struct {
+	apitype.UpdateResultV1
+
+	steps map[jsonStepKey]int // the index of the latest step for each resource and operation.
+}
+
+// jsonStepKey identifies the steps for a single resource and operation.
+type jsonStepKey struct {
+	urn resource.URN
+	op  deploy.StepOp
+}
+
+func newJSONResult(action apitype.UpdateKind, isPreview bool) *jsonResult {
+	return &jsonResult{
+		UpdateResultV1: apitype.UpdateResultV1{
+			Version: apitype.UpdateResultSchemaVersionCurrent,
+			Kind:    action,
+			Preview: isPreview,
+			Steps:   []apitype.UpdateStepV1{},
+		},
+		steps: make(map[jsonStepKey]int),
+	}
+}
+
+// recordEvent records the information that the given engine event carries in the result.
+func (r *jsonResult) recordEvent(e engine.Event, opts Options) {
+	// Debug messages are only recorded if debugging was requested, and ephemeral status messages are never recorded.
+	if e.Type == engine.DiagEvent {
+		p := e.Payload.(engine.DiagEventPayload)
+		if p.Ephemeral || (p.Severity == diag.Debug && !opts.Debug) {
+			return
+		}
+	}
+
+	apiEvent, err := ConvertEngineEvent(e, opts.ShowSecrets)
+	if err != nil {
+		return
+	}
+
+	switch {
+	case apiEvent.PreludeEvent != nil:
+		r.Config = apiEvent.PreludeEvent.Config
+	case apiEvent.SummaryEvent != nil:
+		r.Summary = apiEvent.SummaryEvent
+	case apiEvent.DiagnosticEvent != nil:
+		d := *apiEvent.DiagnosticEvent
+		d.Prefix, d.Message = colors.Never.Colorize(d.Prefix), colors.Never.Colorize(d.Message)
+		d.Color = string(colors.Never)
+		r.Diagnostics = append(r.Diagnostics, d)
+	case apiEvent.ResourcePreEvent != nil:
+		md := e.Payload.(engine.ResourcePreEventPayload).Metadata
+		r.steps[jsonStepKey{urn: md.URN, op: md.Op}] = len(r.Steps)
+		r.Steps = append(r.Steps, newJSONStep(md, apiEvent.ResourcePreEvent.Metadata))
+	case apiEvent.ResOutputsEvent != nil:
+		md := e.Payload.(engine.ResourceOutputsEventPayload).Metadata
+		step := newJSONStep(md, apiEvent.ResOutputsEvent.Metadata)
+		if i, has := r.steps[jsonStepKey{urn: md.URN, op: md.Op}]; has {
+			r.Steps[i] = step
+		} else {
+			r.steps[jsonStepKey{urn: md.URN, op: md.Op}] = len(r.Steps)
+			r.Steps = append(r.Steps, step)
+		}
+		if isRootStack(md) && step.New != nil && len(step.New.Outputs) > 0 && !opts.SuppressOutputs {
+			r.Outputs = step.New.Outputs
+		}
+	case apiEvent.ResOpFailedEvent != nil:
+		md := e.Payload.(engine.ResourceOperationFailedPayload).Metadata
+		if i, has := r.steps[jsonStepKey{urn: md.URN, op: md.Op}]; has {
+			r.Steps[i].Failed = true
+		}
+	}
+}
+
+// newJSONStep describes the step with the given metadata, which has also been converted to its API form.
+func newJSONStep(md engine.StepEventMetadata, apiMD apitype.StepEventMetadata) apitype.UpdateStepV1 {
+	diffs := apiMD.DetailedDiff
+	if diffs == nil {
+		diffs = getPropertyDiffs(md)
+	}
+
+	return apitype.UpdateStepV1{
+		Op:           apiMD.Op,
+		URN:          apiMD.URN,
+		Type:         apiMD.Type,
+		Provider:     apiMD.Provider,
+		Old:          apiMD.Old,
+		New:          apiMD.New,
+		ReplaceKeys:  apiMD.Keys,
+		ForceReplace: apiMD.ForceReplace,
+		Diffs:        diffs,
+	}
+}
+
+// getPropertyDiffs returns the differences between the top-level properties of the old and new states of the resource
+// affected by the given step. Inputs are compared for all steps but refreshes, for which outputs are compared.
+func getPropertyDiffs(step engine.StepEventMetadata) map[string]apitype.PropertyDiff {
+	if step.Old == nil || step.New == nil {
+		return nil
+	}
+
+	olds, news, inputDiff := step.Old.Inputs, step.New.Inputs, true
+	if step.Op == deploy.OpRefresh {
+		olds, news, inputDiff = step.Old.Outputs, step.New.Outputs, false
+	}
+	diff := olds.Diff(news)
+	if diff == nil {
+		return nil
+	}
+
+	replaces := make(map[resource.PropertyKey]bool)
+	for _, k := range step.Keys {
+		replaces[k] = true
+	}
+	diffs := make(map[string]apitype.PropertyDiff)
+	record := func(k resource.PropertyKey, kind, replaceKind plugin.DiffKind) {
+		if replaces[k] {
+			kind = replaceKind
+		}
+		diffs[string(k)] = apitype.PropertyDiff{Kind: kind.String(), InputDiff: inputDiff}
+	}
+	for k := range diff.Adds {
+		record(k, plugin.DiffAdd, plugin.DiffAddReplace)
+	}
+	for k := range diff.Deletes {
+		record(k, plugin.DiffDelete, plugin.DiffDeleteReplace)
+	}
+	for k := range diff.Updates {
+		record(k, plugin.DiffUpdate, plugin.DiffUpdateReplace)
+	}
+	if len(diffs) == 0 {
+		return nil
+	}
+	return diffs
+}
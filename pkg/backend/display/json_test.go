@@ -0,0 +1,120 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+func TestJSONResult(t *testing.T) {
+	stackURN := resource.URN("urn:pulumi:test::test::pulumi:pulumi:Stack::test-test")
+	resURN := resource.URN("urn:pulumi:test::test::pkgA:m:typA::resA")
+
+	stackStep := engine.StepEventMetadata{
+		Op:   deploy.OpSame,
+		URN:  stackURN,
+		Type: stackURN.Type(),
+		Old:  &engine.StepEventStateMetadata{URN: stackURN, Type: stackURN.Type()},
+		New: &engine.StepEventStateMetadata{URN: stackURN, Type: stackURN.Type(), Outputs: resource.PropertyMap{
+			"out": resource.NewStringProperty("value"),
+		}},
+	}
+	resStep := engine.StepEventMetadata{
+		Op:   deploy.OpUpdate,
+		URN:  resURN,
+		Type: resURN.Type(),
+		Old: &engine.StepEventStateMetadata{URN: resURN, Type: resURN.Type(), Inputs: resource.PropertyMap{
+			"changed": resource.NewStringProperty("old"),
+			"deleted": resource.NewStringProperty("old"),
+			"same":    resource.NewStringProperty("same"),
+		}},
+		New: &engine.StepEventStateMetadata{URN: resURN, Type: resURN.Type(), Inputs: resource.PropertyMap{
+			"changed": resource.NewStringProperty("new"),
+			"added":   resource.NewStringProperty("new"),
+			"same":    resource.NewStringProperty("same"),
+			"secret":  resource.MakeSecret(resource.NewStringProperty("hidden")),
+		}},
+	}
+
+	events := []engine.Event{
+		{Type: engine.PreludeEvent, Payload: engine.PreludeEventPayload{Config: map[string]string{"test:a": "b"}}},
+		{Type: engine.ResourcePreEvent, Payload: engine.ResourcePreEventPayload{Metadata: resStep}},
+		{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{
+			URN: resURN, Message: "status", Severity: diag.Info, Ephemeral: true}},
+		{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{
+			URN: resURN, Message: "<{%fg 1%}>failed<{%reset%}>", Severity: diag.Error}},
+		{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{Message: "debug", Severity: diag.Debug}},
+		{Type: engine.ResourceOperationFailed, Payload: engine.ResourceOperationFailedPayload{Metadata: resStep}},
+		{Type: engine.ResourceOutputsEvent, Payload: engine.ResourceOutputsEventPayload{Metadata: stackStep}},
+		{Type: engine.SummaryEvent, Payload: engine.SummaryEventPayload{
+			ResourceChanges: engine.ResourceChanges{deploy.OpUpdate: 1},
+			FailedResources: []resource.URN{resURN},
+		}},
+	}
+
+	result := newJSONResult(apitype.UpdateUpdate, false)
+	for _, e := range events {
+		result.recordEvent(e, Options{})
+	}
+
+	assert.Equal(t, apitype.UpdateResultSchemaVersionCurrent, result.Version)
+	assert.Equal(t, apitype.UpdateUpdate, result.Kind)
+	assert.Equal(t, map[string]string{"test:a": "b"}, result.Config)
+
+	// The update and the stack's same step are both recorded, in order, and the failed step is marked as such.
+	if assert.Len(t, result.Steps, 2) {
+		step := result.Steps[0]
+		assert.Equal(t, "update", step.Op)
+		assert.Equal(t, string(resURN), step.URN)
+		assert.True(t, step.Failed)
+		assert.Equal(t, map[string]apitype.PropertyDiff{
+			"changed": {Kind: "update", InputDiff: true},
+			"deleted": {Kind: "delete", InputDiff: true},
+			"added":   {Kind: "add", InputDiff: true},
+			"secret":  {Kind: "add", InputDiff: true},
+		}, step.Diffs)
+		assert.Equal(t, "[secret]", step.New.Inputs["secret"])
+
+		assert.Equal(t, "same", result.Steps[1].Op)
+		assert.False(t, result.Steps[1].Failed)
+	}
+
+	// Only the error is recorded, without its color markup.
+	if assert.Len(t, result.Diagnostics, 1) {
+		assert.Equal(t, "failed", result.Diagnostics[0].Message)
+		assert.Equal(t, "error", result.Diagnostics[0].Severity)
+	}
+
+	assert.Equal(t, map[string]interface{}{"out": "value"}, result.Outputs)
+	if assert.NotNil(t, result.Summary) {
+		assert.Equal(t, map[string]int{"update": 1}, result.Summary.ResourceChanges)
+		assert.Equal(t, []string{string(resURN)}, result.Summary.FailedResources)
+	}
+
+	// Secrets are only revealed if they were asked for.
+	result = newJSONResult(apitype.UpdateUpdate, false)
+	result.recordEvent(events[1], Options{ShowSecrets: true})
+	if assert.Len(t, result.Steps, 1) {
+		assert.Equal(t, "hidden", result.Steps[0].New.Inputs["secret"])
+	}
+}
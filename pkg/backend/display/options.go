@@ -22,9 +22,12 @@ type Options struct {
 	ShowConfig           bool                // true if we should show configuration information.
 	ShowReplacementSteps bool                // true to show the replacement steps in the plan.
 	ShowSameResources    bool                // true to show the resources that aren't updated in addition to updates.
+	ShowSecrets          bool                // true to show the underlying values of secrets instead of masking them.
 	SuppressOutputs      bool                // true to suppress output summarization, e.g. if contains sensitive info.
 	SummaryDiff          bool                // If the diff display should be summarized
 	IsInteractive        bool                // If we should display things interactively
 	DiffDisplay          bool                // true if we should display things as a rich diff
+	JSONDisplay          bool                // true if we should write a JSON description of the operation instead
+	EventLog             *EventLog           // if non-nil, the log to which every engine event is appended.
 	Debug                bool                // true to enable debug output.
 }
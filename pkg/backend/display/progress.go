@@ -1036,6 +1036,9 @@ func (display *ProgressDisplay) getStepDoneDescription(step engine.StepEventMeta
 			case deploy.OpUpdate:
 				return "updating failed"
 			case deploy.OpDelete, deploy.OpDeleteReplaced:
+				if isRetainedDelete(step) {
+					return "retaining failed"
+				}
 				return "deleting failed"
 			case deploy.OpReplace:
 				return "replacing failed"
@@ -1045,6 +1048,8 @@ func (display *ProgressDisplay) getStepDoneDescription(step engine.StepEventMeta
 				return "refreshing failed"
 			case deploy.OpReadDiscard, deploy.OpDiscardReplaced:
 				return "discarding failed"
+			case deploy.OpImport:
+				return "importing failed"
 			}
 		} else {
 			switch op {
@@ -1055,12 +1060,18 @@ func (display *ProgressDisplay) getStepDoneDescription(step engine.StepEventMeta
 			case deploy.OpUpdate:
 				return "updated"
 			case deploy.OpDelete:
+				if isRetainedDelete(step) {
+					return "retained"
+				}
 				return "deleted"
 			case deploy.OpReplace:
 				return "replaced"
 			case deploy.OpCreateReplacement:
 				return "created replacement"
 			case deploy.OpDeleteReplaced:
+				if isRetainedDelete(step) {
+					return "retained original"
+				}
 				return "deleted original"
 			case deploy.OpRead:
 				// nolint: goconst
@@ -1073,6 +1084,8 @@ func (display *ProgressDisplay) getStepDoneDescription(step engine.StepEventMeta
 				return "discarded"
 			case deploy.OpDiscardReplaced:
 				return "discarded original"
+			case deploy.OpImport:
+				return "imported"
 			}
 		}
 
@@ -1096,12 +1109,18 @@ func (display *ProgressDisplay) getPreviewText(step engine.StepEventMetadata) st
 	case deploy.OpUpdate:
 		return "update"
 	case deploy.OpDelete:
+		if isRetainedDelete(step) {
+			return "retain"
+		}
 		return "delete"
 	case deploy.OpReplace:
 		return "replace"
 	case deploy.OpCreateReplacement:
 		return "create replacement"
 	case deploy.OpDeleteReplaced:
+		if isRetainedDelete(step) {
+			return "retain original"
+		}
 		return "delete original"
 	case deploy.OpRead:
 		// nolint: goconst
@@ -1114,6 +1133,8 @@ func (display *ProgressDisplay) getPreviewText(step engine.StepEventMetadata) st
 		return "discard"
 	case deploy.OpDiscardReplaced:
 		return "discard origina;"
+	case deploy.OpImport:
+		return "import"
 	}
 
 	contract.Failf("Unrecognized resource step op: %v", step.Op)
@@ -1131,6 +1152,9 @@ func (display *ProgressDisplay) getPreviewDoneText(step engine.StepEventMetadata
 	case deploy.OpUpdate:
 		return "update"
 	case deploy.OpDelete:
+		if isRetainedDelete(step) {
+			return "retain"
+		}
 		return "delete"
 	case deploy.OpReplace, deploy.OpCreateReplacement, deploy.OpDeleteReplaced, deploy.OpReadReplacement,
 		deploy.OpDiscardReplaced:
@@ -1142,6 +1166,8 @@ func (display *ProgressDisplay) getPreviewDoneText(step engine.StepEventMetadata
 		return "refresh"
 	case deploy.OpReadDiscard:
 		return "discard"
+	case deploy.OpImport:
+		return "import"
 	}
 
 	contract.Failf("Unrecognized resource step op: %v", step.Op)
@@ -1173,6 +1199,12 @@ func (display *ProgressDisplay) getStepOp(step engine.StepEventMetadata) deploy.
 	return op
 }
 
+// isRetainedDelete returns true if the given step removes a resource from the stack without deleting it.
+func isRetainedDelete(step engine.StepEventMetadata) bool {
+	return (step.Op == deploy.OpDelete || step.Op == deploy.OpDeleteReplaced) && step.Old != nil &&
+		step.Old.RetainOnDelete
+}
+
 func (display *ProgressDisplay) getStepOpLabel(step engine.StepEventMetadata) string {
 	return display.getStepOp(step).Prefix() + colors.Reset
 }
@@ -1199,12 +1231,18 @@ func (display *ProgressDisplay) getStepInProgressDescription(step engine.StepEve
 		case deploy.OpUpdate:
 			return "updating"
 		case deploy.OpDelete:
+			if isRetainedDelete(step) {
+				return "retaining"
+			}
 			return "deleting"
 		case deploy.OpReplace:
 			return "replacing"
 		case deploy.OpCreateReplacement:
 			return "creating replacement"
 		case deploy.OpDeleteReplaced:
+			if isRetainedDelete(step) {
+				return "retaining original"
+			}
 			return "deleting original"
 		case deploy.OpRead:
 			return "reading"
@@ -1216,6 +1254,8 @@ func (display *ProgressDisplay) getStepInProgressDescription(step engine.StepEve
 			return "discarding"
 		case deploy.OpDiscardReplaced:
 			return "discarding original"
+		case deploy.OpImport:
+			return "importing"
 		}
 
 		contract.Failf("Unrecognized resource step op: %v", op)
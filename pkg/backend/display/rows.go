@@ -387,7 +387,13 @@ func (data *resourceRowData) getDiffInfo(step engine.StepEventMetadata) string {
 	changesBuf := &bytes.Buffer{}
 	if step.Old != nil && step.New != nil {
 		var diff *resource.ObjectDiff
-		if data.diffOutputs {
+		if step.DetailedDiff != nil {
+			// If the provider reported a detailed diff, display exactly the changes that it reported.
+			diff = engine.TranslateDetailedDiff(step)
+			if len(diff.Adds) == 0 && len(diff.Deletes) == 0 && len(diff.Updates) == 0 {
+				diff = nil
+			}
+		} else if data.diffOutputs {
 			if step.Old.Outputs != nil && step.New.Outputs != nil {
 				diff = step.Old.Outputs.Diff(step.New.Outputs)
 			}
@@ -429,6 +435,14 @@ func (data *resourceRowData) getDiffInfo(step engine.StepEventMetadata) string {
 			writePropertyKeys(changesBuf, diff.Deletes, deploy.OpDelete)
 			writePropertyKeys(changesBuf, updates, deploy.OpUpdate)
 		}
+
+		// An explicitly requested replacement need not be accompanied by any changes, so call it out.
+		if step.ForceReplace {
+			if diff != nil {
+				writeString(changesBuf, "; ")
+			}
+			writeString(changesBuf, "forced replacement")
+		}
 	}
 
 	fprintIgnoreError(changesBuf, colors.Reset)
@@ -18,11 +18,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"os/user"
-	"path/filepath"
+	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -50,13 +50,21 @@ const localBackendURLPrefix = "file://"
 // Backend extends the base backend interface with specific information about local backends.
 type Backend interface {
 	backend.Backend
-	local() // at the moment, no local specific info, so just use a marker function.
+
+	// GetStackLock returns the holder of the given stack's lock, or nil if the stack is not locked.
+	GetStackLock(ctx context.Context, stackRef backend.StackReference) (*StackLock, error)
+	// BreakStackLock removes the given stack's lock, regardless of which operation holds it.
+	BreakStackLock(ctx context.Context, stackRef backend.StackReference) error
 }
 
 type localBackend struct {
 	d               diag.Sink
 	url             string
 	stackConfigFile string
+	bucket          Bucket // the bucket in which the backend's state is kept.
+
+	crypterLock sync.Mutex
+	crypters    map[tokens.QName]config.Crypter // the checkpoint crypters for each stack, created on demand.
 }
 
 type localBackendReference struct {
@@ -71,19 +79,28 @@ func (r localBackendReference) Name() tokens.QName {
 	return r.name
 }
 
+// IsLocalBackendURL returns true if the given URL selects a backend whose state is kept in a bucket, either a directory
+// on the local filesystem (`file://`) or an S3 bucket (`s3://`), rather than in the Pulumi service.
 func IsLocalBackendURL(url string) bool {
-	return strings.HasPrefix(url, localBackendURLPrefix)
+	return strings.HasPrefix(url, localBackendURLPrefix) || strings.HasPrefix(url, s3BackendURLPrefix)
 }
 
 func New(d diag.Sink, url, stackConfigFile string) (Backend, error) {
-	if !IsLocalBackendURL(url) {
-		return nil, errors.Errorf("local URL %s has an illegal prefix; expected %s", url, localBackendURLPrefix)
+	bucket, err := openBucket(url)
+	if err != nil {
+		return nil, err
 	}
+	return newLocalBackend(d, url, stackConfigFile, bucket), nil
+}
+
+// newLocalBackend returns a backend that keeps its state in the given bucket.
+func newLocalBackend(d diag.Sink, url, stackConfigFile string, bucket Bucket) *localBackend {
 	return &localBackend{
 		d:               d,
 		url:             url,
 		stackConfigFile: stackConfigFile,
-	}, nil
+		bucket:          bucket,
+	}
 }
 
 func Login(d diag.Sink, url, stackConfigFile string) (Backend, error) {
@@ -94,8 +111,6 @@ func Login(d diag.Sink, url, stackConfigFile string) (Backend, error) {
 	return be, workspace.StoreAccessToken(url, "", true)
 }
 
-func (b *localBackend) local() {}
-
 func (b *localBackend) Name() string {
 	name, err := os.Hostname()
 	contract.IgnoreError(err)
@@ -109,25 +124,6 @@ func (b *localBackend) URL() string {
 	return b.url
 }
 
-func (b *localBackend) Dir() string {
-	path := b.url[len(localBackendURLPrefix):]
-	if path == "~" {
-		user, err := user.Current()
-		contract.AssertNoErrorf(err, "could not determine current user")
-		path = user.HomeDir
-	} else if path == "." {
-		pwd, err := os.Getwd()
-		contract.AssertNoErrorf(err, "could not determine current working directory")
-		path = pwd
-	}
-	return path
-}
-
-func (b *localBackend) StateDir() string {
-	dir := b.Dir()
-	return filepath.Join(dir, workspace.BookkeepingDir)
-}
-
 func (b *localBackend) ParseStackReference(stackRefName string) (backend.StackReference, error) {
 	return localBackendReference{name: tokens.QName(stackRefName)}, nil
 }
@@ -154,12 +150,12 @@ func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackRe
 		return nil, errors.Wrap(err, "validating stack properties")
 	}
 
-	file, err := b.saveStack(stackName, nil, nil)
+	key, err := b.saveStack(stackName, nil, tags, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	stack := newStack(stackRef, file, nil, nil, b)
+	stack := newStack(stackRef, b.bucket.URL(key), nil, nil, b)
 	fmt.Printf("Created stack '%s'\n", stack.Ref())
 
 	return stack, nil
@@ -167,14 +163,14 @@ func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackRe
 
 func (b *localBackend) GetStack(ctx context.Context, stackRef backend.StackReference) (backend.Stack, error) {
 	stackName := stackRef.Name()
-	config, snapshot, path, err := b.getStack(stackName)
+	config, snapshot, key, err := b.getStack(stackName)
 	switch {
 	case os.IsNotExist(errors.Cause(err)):
 		return nil, nil
 	case err != nil:
 		return nil, err
 	default:
-		return newStack(stackRef, path, config, snapshot, b), nil
+		return newStack(stackRef, b.bucket.URL(key), config, snapshot, b), nil
 	}
 }
 
@@ -201,6 +197,12 @@ func (b *localBackend) ListStacks(
 
 func (b *localBackend) RemoveStack(ctx context.Context, stackRef backend.StackReference, force bool) (bool, error) {
 	stackName := stackRef.Name()
+	lock, err := b.lockStack(stackName, "remove")
+	if err != nil {
+		return false, err
+	}
+	defer func() { contract.IgnoreError(b.unlockStack(stackName, lock)) }()
+
 	_, snapshot, _, err := b.getStack(stackName)
 	if err != nil {
 		return false, err
@@ -214,10 +216,56 @@ func (b *localBackend) RemoveStack(ctx context.Context, stackRef backend.StackRe
 	return false, b.removeStack(stackName)
 }
 
+func (b *localBackend) RenameStack(ctx context.Context, stackRef backend.StackReference,
+	newName tokens.QName) (backend.StackReference, error) {
+
+	if err := backend.ValidateStackProperties(string(newName), nil); err != nil {
+		return nil, errors.Wrap(err, "validating stack properties")
+	}
+
+	// Lock the new name as well as the old one, so that no other operation can create a stack with the new name while
+	// this one is being moved there.
+	stackName := stackRef.Name()
+	lock, err := b.lockStack(stackName, "rename")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { contract.IgnoreError(b.unlockStack(stackName, lock)) }()
+	newLock, err := b.lockStack(newName, "rename")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { contract.IgnoreError(b.unlockStack(newName, newLock)) }()
+
+	if err = b.renameStack(stackName, newName); err != nil {
+		return nil, err
+	}
+	return localBackendReference{name: newName}, nil
+}
+
 func (b *localBackend) GetStackCrypter(stackRef backend.StackReference) (config.Crypter, error) {
 	return symmetricCrypter(stackRef.Name(), b.stackConfigFile)
 }
 
+// checkpointCrypter returns the crypter used to encrypt and decrypt secret values in the given stack's checkpoint. The
+// crypter is created lazily, so that we only prompt for a passphrase if the checkpoint actually contains secrets.
+func (b *localBackend) checkpointCrypter(stackName tokens.QName) config.Crypter {
+	b.crypterLock.Lock()
+	defer b.crypterLock.Unlock()
+
+	if b.crypters == nil {
+		b.crypters = make(map[tokens.QName]config.Crypter)
+	}
+	crypter, has := b.crypters[stackName]
+	if !has {
+		crypter = config.NewLazyCrypter(func() (config.Crypter, error) {
+			return symmetricCrypter(stackName, b.stackConfigFile)
+		})
+		b.crypters[stackName] = crypter
+	}
+	return crypter
+}
+
 func (b *localBackend) GetLatestConfiguration(ctx context.Context,
 	stackRef backend.StackReference) (config.Map, error) {
 
@@ -266,6 +314,15 @@ func (b *localBackend) Refresh(ctx context.Context, stackRef backend.StackRefere
 	return backend.PreviewThenPromptThenExecute(ctx, apitype.RefreshUpdate, stack, op, b.apply)
 }
 
+func (b *localBackend) Import(ctx context.Context, stackRef backend.StackReference,
+	op backend.UpdateOperation) (engine.ResourceChanges, error) {
+	stack, err := b.GetStack(ctx, stackRef)
+	if err != nil {
+		return nil, err
+	}
+	return backend.PreviewThenPromptThenExecute(ctx, apitype.ResourceImportUpdate, stack, op, b.apply)
+}
+
 func (b *localBackend) Destroy(ctx context.Context, stackRef backend.StackReference,
 	op backend.UpdateOperation) (engine.ResourceChanges, error) {
 	stack, err := b.GetStack(ctx, stackRef)
@@ -281,10 +338,31 @@ func (b *localBackend) apply(ctx context.Context, kind apitype.UpdateKind, stack
 	stackRef := stack.Ref()
 	stackName := stackRef.Name()
 
-	// Print a banner so it's clear this is a local deployment.
+	// Lock the stack for the duration of any operation that changes its state, so that concurrent operations cannot
+	// interleave their changes to its checkpoint.
+	if !opts.DryRun {
+		lock, err := b.lockStack(stackName, string(kind))
+		if err != nil {
+			return nil, err
+		}
+		defer func() { contract.IgnoreError(b.unlockStack(stackName, lock)) }()
+
+		// Record the latest tags from the environment, as the Pulumi service does at the start of each update.
+		tags, err := backend.GetMergedStackTags(ctx, stack)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting stack tags")
+		}
+		if err = b.updateStackTags(stackName, tags); err != nil {
+			return nil, errors.Wrap(err, "updating stack tags")
+		}
+	}
+
+	// Print a banner so it's clear this is a local deployment, unless we are writing JSON.
 	actionLabel := backend.ActionLabel(kind, opts.DryRun)
-	fmt.Printf(op.Opts.Display.Color.Colorize(
-		colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stackRef)
+	if !op.Opts.Display.JSONDisplay {
+		fmt.Printf(op.Opts.Display.Color.Colorize(
+			colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stackRef)
+	}
 
 	// Start the update.
 	update, err := b.newUpdate(stackName, op.Proj, op.Root)
@@ -339,6 +417,8 @@ func (b *localBackend) apply(ctx context.Context, kind apitype.UpdateKind, stack
 		changes, updateErr = engine.Update(update, engineCtx, op.Opts.Engine, opts.DryRun)
 	case apitype.RefreshUpdate:
 		changes, updateErr = engine.Refresh(update, engineCtx, op.Opts.Engine, opts.DryRun)
+	case apitype.ResourceImportUpdate:
+		changes, updateErr = engine.Import(update, engineCtx, op.Opts.Engine, op.Imports, opts.DryRun)
 	case apitype.DestroyUpdate:
 		changes, updateErr = engine.Destroy(update, engineCtx, op.Opts.Engine, opts.DryRun)
 	default:
@@ -370,7 +450,7 @@ func (b *localBackend) apply(ctx context.Context, kind apitype.UpdateKind, stack
 		Result:      result,
 		EndTime:     end,
 		// IDEA: it would be nice to populate the *Deployment, so that addToHistory below doesn't need to
-		//     rudely assume it knows where the checkpoint file is on disk as it makes a copy of it.  This isn't
+		//     rudely assume it knows where the checkpoint is kept as it makes a copy of it.  This isn't
 		//     trivial to achieve today given the event driven nature of plan-walking, however.
 		ResourceChanges: changes,
 	}
@@ -397,11 +477,11 @@ func (b *localBackend) apply(ctx context.Context, kind apitype.UpdateKind, stack
 	}
 
 	// Make sure to print a link to the stack's checkpoint before exiting.
-	if opts.ShowLink {
+	if opts.ShowLink && !op.Opts.Display.JSONDisplay {
 		fmt.Printf(
 			op.Opts.Display.Color.Colorize(
 				colors.SpecHeadline+"Permalink: "+
-					colors.Underline+colors.BrightBlue+"file://%s"+colors.Reset+"\n"), stack.(*localStack).Path())
+					colors.Underline+colors.BrightBlue+"%s"+colors.Reset+"\n"), stack.(*localStack).Path())
 	}
 
 	return changes, nil
@@ -460,7 +540,12 @@ func (b *localBackend) ExportDeployment(ctx context.Context,
 		snap = deploy.NewSnapshot(deploy.Manifest{}, nil, nil)
 	}
 
-	data, err := json.Marshal(stack.SerializeDeployment(snap))
+	deployment, err := stack.SerializeDeployment(snap, b.checkpointCrypter(stackName))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(deployment)
 	if err != nil {
 		return nil, err
 	}
@@ -475,17 +560,23 @@ func (b *localBackend) ImportDeployment(ctx context.Context, stackRef backend.St
 	deployment *apitype.UntypedDeployment) error {
 
 	stackName := stackRef.Name()
-	config, _, _, err := b.getStack(stackName)
+	lock, err := b.lockStack(stackName, "import")
 	if err != nil {
 		return err
 	}
+	defer func() { contract.IgnoreError(b.unlockStack(stackName, lock)) }()
 
-	snap, err := stack.DeserializeUntypedDeployment(deployment)
+	chk, err := b.getCheckpoint(stackName)
 	if err != nil {
 		return err
 	}
 
-	_, err = b.saveStack(stackName, config, snap)
+	snap, err := stack.DeserializeUntypedDeployment(deployment, b.checkpointCrypter(stackName))
+	if err != nil {
+		return err
+	}
+
+	_, err = b.saveStack(stackName, chk.Config, chk.Tags, snap)
 	return err
 }
 
@@ -505,22 +596,14 @@ func (b *localBackend) getLocalStacks() ([]tokens.QName, error) {
 	var stacks []tokens.QName
 
 	// Read the stack directory.
-	path := b.stackPath("")
-
-	files, err := ioutil.ReadDir(path)
-	if err != nil && !os.IsNotExist(err) {
+	files, err := b.bucket.List(b.stackPath(""))
+	if err != nil {
 		return nil, errors.Errorf("could not read stacks: %v", err)
 	}
 
-	for _, file := range files {
-		// Ignore directories.
-		if file.IsDir() {
-			continue
-		}
-
+	for _, stackfn := range files {
 		// Skip files without valid extensions (e.g., *.bak files).
-		stackfn := file.Name()
-		ext := filepath.Ext(stackfn)
+		ext := path.Ext(stackfn)
 		if _, has := encoding.Marshalers[ext]; !has {
 			continue
 		}
@@ -543,14 +626,28 @@ func (b *localBackend) getLocalStacks() ([]tokens.QName, error) {
 func (b *localBackend) GetStackTags(ctx context.Context,
 	stackRef backend.StackReference) (map[apitype.StackTagName]string, error) {
 
-	// The local backend does not currently persist tags.
-	return nil, errors.New("stack tags not supported in --local mode")
+	chk, err := b.getCheckpoint(stackRef.Name())
+	if err != nil {
+		return nil, err
+	}
+	return chk.Tags, nil
 }
 
 // UpdateStackTags updates the stacks's tags, replacing all existing tags.
 func (b *localBackend) UpdateStackTags(ctx context.Context,
 	stackRef backend.StackReference, tags map[apitype.StackTagName]string) error {
 
-	// The local backend does not currently persist tags.
-	return errors.New("stack tags not supported in --local mode")
+	// Validate stack tags.
+	if err := backend.ValidateStackTags(tags); err != nil {
+		return err
+	}
+
+	stackName := stackRef.Name()
+	lock, err := b.lockStack(stackName, "tag")
+	if err != nil {
+		return err
+	}
+	defer func() { contract.IgnoreError(b.unlockStack(stackName, lock)) }()
+
+	return b.updateStackTags(stackName, tags)
 }
@@ -0,0 +1,289 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// Bucket is a simple store of blobs, in which each blob is identified by a slash-separated key. The local backend keeps
+// all of its state -- checkpoints, history and backups -- in a bucket.
+type Bucket interface {
+	// URL returns a URL that identifies the blob with the given key.
+	URL(key string) string
+	// ReadFile returns the contents of the blob with the given key. If there is no such blob, the returned error
+	// satisfies os.IsNotExist.
+	ReadFile(key string) ([]byte, error)
+	// WriteFile creates or replaces the blob with the given key.
+	WriteFile(key string, data []byte) error
+	// Create creates the blob with the given key, failing if it already exists. If it does, the returned error
+	// satisfies os.IsExist. Creation is atomic: of several concurrent calls for the same key, at most one succeeds.
+	Create(key string, data []byte) error
+	// Delete deletes the blob with the given key, if it exists.
+	Delete(key string) error
+	// Rename moves the blob with the given key to a new key, replacing any blob that already has that key.
+	Rename(from, to string) error
+	// List returns the names of the blobs directly beneath the given directory, sorted by name. A directory that
+	// contains no blobs is empty.
+	List(dir string) ([]string, error)
+}
+
+// openBucket opens the bucket identified by the given local backend URL.
+func openBucket(url string) (Bucket, error) {
+	switch {
+	case strings.HasPrefix(url, localBackendURLPrefix):
+		dir := url[len(localBackendURLPrefix):]
+		if dir == "~" {
+			user, err := user.Current()
+			if err != nil {
+				return nil, errors.Wrap(err, "could not determine current user")
+			}
+			dir = user.HomeDir
+		} else if dir == "." {
+			pwd, err := os.Getwd()
+			if err != nil {
+				return nil, errors.Wrap(err, "could not determine current working directory")
+			}
+			dir = pwd
+		}
+		return newFileBucket(dir), nil
+	case strings.HasPrefix(url, s3BackendURLPrefix):
+		return openS3Bucket(url)
+	default:
+		return nil, errors.Errorf("local URL %s has an illegal prefix; expected %s or %s",
+			url, localBackendURLPrefix, s3BackendURLPrefix)
+	}
+}
+
+// notExistError returns an error satisfying os.IsNotExist for the blob with the given URL.
+func notExistError(op, url string) error {
+	return &os.PathError{Op: op, Path: url, Err: os.ErrNotExist}
+}
+
+// existError returns an error satisfying os.IsExist for the blob with the given URL.
+func existError(op, url string) error {
+	return &os.PathError{Op: op, Path: url, Err: os.ErrExist}
+}
+
+// fileBucket is a bucket whose blobs are files beneath a directory on the local filesystem.
+type fileBucket struct {
+	root string // the directory that contains the bucket's files.
+}
+
+func newFileBucket(root string) Bucket {
+	return &fileBucket{root: root}
+}
+
+func (b *fileBucket) path(key string) string {
+	return filepath.Join(b.root, filepath.FromSlash(key))
+}
+
+func (b *fileBucket) URL(key string) string {
+	return localBackendURLPrefix + b.path(key)
+}
+
+func (b *fileBucket) ReadFile(key string) ([]byte, error) {
+	return ioutil.ReadFile(b.path(key))
+}
+
+func (b *fileBucket) WriteFile(key string, data []byte) error {
+	file := b.path(key)
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, 0600)
+}
+
+func (b *fileBucket) Create(key string, data []byte) error {
+	file := b.path(key)
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+
+	// Write the data to a temporary file and then link it into place. Linking fails if the file already exists, which
+	// makes the check for an existing file and the creation of the new one a single atomic operation, and the file
+	// never appears with only some of its data.
+	tmp, err := ioutil.TempFile(filepath.Dir(file), filepath.Base(file)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { contract.IgnoreError(os.Remove(tmp.Name())) }()
+	if _, err = tmp.Write(data); err != nil {
+		contract.IgnoreClose(tmp)
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Link(tmp.Name(), file)
+}
+
+func (b *fileBucket) Delete(key string) error {
+	if err := os.Remove(b.path(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (b *fileBucket) Rename(from, to string) error {
+	file := b.path(to)
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+	return os.Rename(b.path(from), file)
+}
+
+func (b *fileBucket) List(dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(b.path(dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var names []string
+	for _, file := range files {
+		if !file.IsDir() {
+			names = append(names, file.Name())
+		}
+	}
+	return names, nil
+}
+
+// memoryBucket is a bucket whose blobs are held in memory.
+type memoryBucket struct {
+	lock  sync.Mutex
+	blobs map[string][]byte
+}
+
+func newMemoryBucket() Bucket {
+	return &memoryBucket{blobs: make(map[string][]byte)}
+}
+
+func (b *memoryBucket) URL(key string) string {
+	return "mem://" + key
+}
+
+func (b *memoryBucket) ReadFile(key string) ([]byte, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	data, has := b.blobs[key]
+	if !has {
+		return nil, notExistError("read", b.URL(key))
+	}
+	return append([]byte(nil), data...), nil
+}
+
+func (b *memoryBucket) WriteFile(key string, data []byte) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.blobs[key] = append([]byte(nil), data...)
+	return nil
+}
+
+func (b *memoryBucket) Create(key string, data []byte) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, has := b.blobs[key]; has {
+		return existError("create", b.URL(key))
+	}
+	b.blobs[key] = append([]byte(nil), data...)
+	return nil
+}
+
+func (b *memoryBucket) Delete(key string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.blobs, key)
+	return nil
+}
+
+func (b *memoryBucket) Rename(from, to string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	data, has := b.blobs[from]
+	if !has {
+		return notExistError("rename", b.URL(from))
+	}
+	delete(b.blobs, from)
+	b.blobs[to] = data
+	return nil
+}
+
+func (b *memoryBucket) List(dir string) ([]string, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var names []string
+	for key := range b.blobs {
+		if path.Dir(key) == path.Clean(dir) {
+			names = append(names, path.Base(key))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// deleteAll deletes every blob directly beneath the given directory of the given bucket.
+func deleteAll(b Bucket, dir string) error {
+	contract.Require(dir != "", "dir")
+
+	names, err := b.List(dir)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err = b.Delete(path.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renameAll moves every blob directly beneath one directory of the given bucket to another directory, giving each blob
+// the name returned by the given function.
+func renameAll(b Bucket, from, to string, rename func(name string) string) error {
+	contract.Require(from != "", "from")
+	contract.Require(to != "", "to")
+	contract.Require(rename != nil, "rename")
+
+	names, err := b.List(from)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err = b.Rename(path.Join(from, name), path.Join(to, rename(name))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// s3BackendURLPrefix is the URL scheme that selects a local backend whose state is kept in an S3 bucket.
+const s3BackendURLPrefix = "s3://"
+
+// s3Bucket is a bucket whose blobs are objects in an S3 bucket, or in a bucket of any S3-compatible service.
+type s3Bucket struct {
+	svc       *s3.S3
+	bucket    string // the name of the S3 bucket.
+	prefix    string // the prefix of every object key, if any.
+	createErr error  // non-nil if objects cannot be created atomically, in which case Create always fails.
+}
+
+// openS3Bucket opens the S3 bucket identified by a URL of the form `s3://bucket/prefix`. The region may be given with a
+// `region` query parameter, and the endpoint of an S3-compatible service with an `endpoint` query parameter. Otherwise
+// the region and credentials are taken from the standard AWS environment variables and configuration files.
+func openS3Bucket(rawurl string) (Bucket, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing S3 URL %s", rawurl)
+	}
+	if u.Host == "" {
+		return nil, errors.Errorf("S3 URL %s does not name a bucket", rawurl)
+	}
+
+	cfg := aws.NewConfig()
+	query := u.Query()
+	if region := query.Get("region"); region != "" {
+		cfg.Region = aws.String(region)
+	}
+	if endpoint := query.Get("endpoint"); endpoint != "" {
+		// S3-compatible services are generally addressed by path rather than by virtual host.
+		cfg.Endpoint = aws.String(endpoint)
+		cfg.S3ForcePathStyle = aws.Bool(true)
+		cfg.DisableSSL = aws.Bool(strings.HasPrefix(endpoint, "http://"))
+		if cfg.Region == nil {
+			cfg.Region = aws.String("us-east-1")
+		}
+	}
+
+	return newS3Bucket(u.Host, strings.Trim(u.Path, "/"), cfg)
+}
+
+// newS3Bucket returns a bucket whose blobs are objects beneath the given prefix of the given S3 bucket.
+func newS3Bucket(bucket, prefix string, cfg *aws.Config) (Bucket, error) {
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "connecting to S3")
+	}
+	b := &s3Bucket{svc: s3.New(sess), bucket: bucket, prefix: prefix}
+	b.createErr = b.probeConditionalWrites()
+	return b, nil
+}
+
+// probeConditionalWrites checks that the service supports conditional writes, which Create relies on. Some
+// S3-compatible services ignore the condition and overwrite the object instead, which would let two processes hold the
+// same stack lock at once. Such a service is detected by conditionally writing a scratch object twice: the second write
+// must fail. The scratch object's key is unique to this probe, so that the probe never touches another object.
+func (b *s3Bucket) probeConditionalWrites() error {
+	key, err := resource.NewUniqueHex(".pulumi/.probe-", 16, -1)
+	if err != nil {
+		return err
+	}
+	defer func() { contract.IgnoreError(b.Delete(key)) }()
+
+	if err = b.putIfNotExists(key, nil); err != nil {
+		return err
+	}
+	switch err = b.putIfNotExists(key, nil); {
+	case os.IsExist(err):
+		return nil
+	case err == nil:
+		return errors.Errorf("the S3 service at %s does not support conditional writes, which are required to lock "+
+			"stacks", b.URL(""))
+	default:
+		return err
+	}
+}
+
+func (b *s3Bucket) key(key string) string {
+	return path.Join(b.prefix, key)
+}
+
+func (b *s3Bucket) URL(key string) string {
+	return s3BackendURLPrefix + path.Join(b.bucket, b.key(key))
+}
+
+func (b *s3Bucket) ReadFile(key string) ([]byte, error) {
+	out, err := b.svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.key(key)),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, notExistError("read", b.URL(key))
+		}
+		return nil, errors.Wrapf(err, "reading %s", b.URL(key))
+	}
+	defer contract.IgnoreClose(out.Body)
+
+	return ioutil.ReadAll(out.Body)
+}
+
+func (b *s3Bucket) WriteFile(key string, data []byte) error {
+	_, err := b.svc.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.key(key)),
+		Body:   bytes.NewReader(data),
+	})
+	return errors.Wrapf(err, "writing %s", b.URL(key))
+}
+
+// Create writes the object with a conditional write (`If-None-Match: *`), which S3 rejects if the object already
+// exists, so that of several concurrent calls at most one succeeds. If the bucket was found not to support conditional
+// writes when it was opened, Create fails without writing anything.
+func (b *s3Bucket) Create(key string, data []byte) error {
+	if b.createErr != nil {
+		return errors.Wrapf(b.createErr, "creating %s", b.URL(key))
+	}
+	return b.putIfNotExists(key, data)
+}
+
+// putIfNotExists writes the object with the given key only if it does not already exist. If it does, the returned
+// error satisfies os.IsExist.
+func (b *s3Bucket) putIfNotExists(key string, data []byte) error {
+	req, _ := b.svc.PutObjectRequest(&s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.key(key)),
+		Body:   bytes.NewReader(data),
+	})
+	req.HTTPRequest.Header.Set("If-None-Match", "*")
+	if err := req.Send(); err != nil {
+		// S3 reports an object that already exists as a failed precondition, and a conflicting conditional write that
+		// is still in progress as a conflict.
+		if reqErr, ok := err.(awserr.RequestFailure); ok &&
+			(reqErr.StatusCode() == http.StatusPreconditionFailed || reqErr.StatusCode() == http.StatusConflict) {
+			return existError("create", b.URL(key))
+		}
+		return errors.Wrapf(err, "creating %s", b.URL(key))
+	}
+	return nil
+}
+
+func (b *s3Bucket) Delete(key string) error {
+	// S3 does not report an error when deleting an object that does not exist.
+	_, err := b.svc.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.key(key)),
+	})
+	return errors.Wrapf(err, "deleting %s", b.URL(key))
+}
+
+// Rename copies the blob to its new key and then deletes the original. Unlike a rename on the local filesystem, this
+// is not atomic.
+func (b *s3Bucket) Rename(from, to string) error {
+	data, err := b.ReadFile(from)
+	if err != nil {
+		return err
+	}
+	if err = b.WriteFile(to, data); err != nil {
+		return err
+	}
+	return b.Delete(from)
+}
+
+func (b *s3Bucket) List(dir string) ([]string, error) {
+	prefix := b.key(dir) + "/"
+
+	var names []string
+	input := &s3.ListObjectsV2Input{
+		Bucket:    aws.String(b.bucket),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	}
+	for {
+		out, err := b.svc.ListObjectsV2(input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing %s", b.URL(dir))
+		}
+
+		// Objects in nested directories are rolled up into common prefixes, which we ignore.
+		for _, obj := range out.Contents {
+			names = append(names, strings.TrimPrefix(aws.StringValue(obj.Key), prefix))
+		}
+
+		if !aws.BoolValue(out.IsTruncated) {
+			return names, nil
+		}
+		input.ContinuationToken = out.NextContinuationToken
+	}
+}
@@ -0,0 +1,407 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// fakeS3 is a stand-in for an S3-compatible server that supports just enough of the S3 REST API for an s3Bucket: path
+// style GET, PUT (optionally conditional on `If-None-Match: *`) and DELETE of objects, and version 2 listings.
+type fakeS3 struct {
+	lock             sync.Mutex
+	objects          map[string][]byte // the objects in the server's buckets, keyed by "<bucket>/<key>".
+	ignoreConditions bool              // true to overwrite objects regardless of any conditional write headers.
+}
+
+type fakeS3ListResult struct {
+	XMLName        xml.Name `xml:"ListBucketResult"`
+	Contents       []fakeS3Object
+	CommonPrefixes []fakeS3Prefix
+	IsTruncated    bool
+}
+
+type fakeS3Object struct {
+	Key string
+}
+
+type fakeS3Prefix struct {
+	Prefix string
+}
+
+type fakeS3Error struct {
+	XMLName xml.Name `xml:"Error"`
+	Code    string
+	Message string
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	switch {
+	case r.Method == "GET" && r.URL.Query().Get("list-type") == "2":
+		bucket := strings.TrimSuffix(name, "/")
+		prefix, delimiter := r.URL.Query().Get("prefix"), r.URL.Query().Get("delimiter")
+
+		var result fakeS3ListResult
+		seen := make(map[string]bool)
+		for key := range s.objects {
+			if !strings.HasPrefix(key, bucket+"/"+prefix) {
+				continue
+			}
+			key = strings.TrimPrefix(key, bucket+"/")
+			if i := strings.Index(key[len(prefix):], delimiter); delimiter != "" && i != -1 {
+				if common := key[:len(prefix)+i+1]; !seen[common] {
+					seen[common] = true
+					result.CommonPrefixes = append(result.CommonPrefixes, fakeS3Prefix{Prefix: common})
+				}
+				continue
+			}
+			result.Contents = append(result.Contents, fakeS3Object{Key: key})
+		}
+		sort.Slice(result.Contents, func(i, j int) bool { return result.Contents[i].Key < result.Contents[j].Key })
+		s.reply(w, http.StatusOK, result)
+	case r.Method == "GET":
+		data, has := s.objects[name]
+		if !has {
+			s.reply(w, http.StatusNotFound, fakeS3Error{Code: "NoSuchKey", Message: name})
+			return
+		}
+		_, err := w.Write(data)
+		contract.IgnoreError(err)
+	case r.Method == "PUT":
+		if _, has := s.objects[name]; has && !s.ignoreConditions && r.Header.Get("If-None-Match") == "*" {
+			s.reply(w, http.StatusPreconditionFailed, fakeS3Error{Code: "PreconditionFailed", Message: name})
+			return
+		}
+		data, err := ioutil.ReadAll(r.Body)
+		contract.AssertNoError(err)
+		s.objects[name] = data
+	case r.Method == "DELETE":
+		delete(s.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *fakeS3) reply(w http.ResponseWriter, status int, v interface{}) {
+	data, err := xml.Marshal(v)
+	contract.AssertNoError(err)
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, err = w.Write(data)
+	contract.IgnoreError(err)
+}
+
+// testBuckets runs the given test against each kind of bucket.
+func testBuckets(t *testing.T, test func(t *testing.T, b Bucket)) {
+	t.Run("file", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "bucket")
+		assert.NoError(t, err)
+		defer func() { contract.IgnoreError(os.RemoveAll(dir)) }()
+
+		b, err := openBucket(localBackendURLPrefix + dir)
+		assert.NoError(t, err)
+		test(t, b)
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		test(t, newMemoryBucket())
+	})
+
+	t.Run("s3", func(t *testing.T) {
+		testS3Bucket(t, &fakeS3{objects: make(map[string][]byte)}, test)
+	})
+}
+
+// testS3Bucket runs the given test against an s3Bucket whose objects are served by the given fake server.
+func testS3Bucket(t *testing.T, s *fakeS3, test func(t *testing.T, b Bucket)) {
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	// Credentials are taken from the environment.
+	for k, v := range map[string]string{"AWS_ACCESS_KEY_ID": "id", "AWS_SECRET_ACCESS_KEY": "secret"} {
+		k, old := k, os.Getenv(k)
+		assert.NoError(t, os.Setenv(k, v))
+		defer func() { contract.IgnoreError(os.Setenv(k, old)) }()
+	}
+
+	b, err := openBucket(s3BackendURLPrefix + "test/state?endpoint=" + server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, "s3://test/state/a/b.json", b.URL("a/b.json"))
+	test(t, b)
+}
+
+func TestBucket(t *testing.T) {
+	testBuckets(t, func(t *testing.T, b Bucket) {
+		// Missing blobs are reported as such, and missing directories are empty.
+		_, err := b.ReadFile("a/missing.json")
+		assert.True(t, os.IsNotExist(err))
+		names, err := b.List("a")
+		assert.NoError(t, err)
+		assert.Empty(t, names)
+		assert.NoError(t, b.Delete("a/missing.json"))
+
+		// Blobs can be written, read and replaced.
+		assert.NoError(t, b.WriteFile("a/one.json", []byte("1")))
+		assert.NoError(t, b.WriteFile("a/two.json", []byte("2")))
+		assert.NoError(t, b.WriteFile("a/two.json", []byte("22")))
+		assert.NoError(t, b.WriteFile("a/nested/three.json", []byte("3")))
+		data, err := b.ReadFile("a/two.json")
+		assert.NoError(t, err)
+		assert.Equal(t, "22", string(data))
+
+		// Listing a directory returns only the blobs directly beneath it.
+		names, err = b.List("a")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"one.json", "two.json"}, names)
+
+		// Renaming a blob replaces the target and removes the source.
+		assert.NoError(t, b.Rename("a/one.json", "a/two.json"))
+		data, err = b.ReadFile("a/two.json")
+		assert.NoError(t, err)
+		assert.Equal(t, "1", string(data))
+		_, err = b.ReadFile("a/one.json")
+		assert.True(t, os.IsNotExist(err))
+
+		// Deleting a directory's contents leaves nested directories alone.
+		assert.NoError(t, deleteAll(b, "a"))
+		names, err = b.List("a")
+		assert.NoError(t, err)
+		assert.Empty(t, names)
+		names, err = b.List("a/nested")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"three.json"}, names)
+
+		// Creating a blob fails if it already exists, and leaves the existing blob alone.
+		assert.NoError(t, b.Create("a/lock.json", []byte("1")))
+		err = b.Create("a/lock.json", []byte("2"))
+		assert.True(t, os.IsExist(err))
+		data, err = b.ReadFile("a/lock.json")
+		assert.NoError(t, err)
+		assert.Equal(t, "1", string(data))
+		names, err = b.List("a")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"lock.json"}, names)
+	})
+}
+
+func TestS3BucketWithoutConditionalWrites(t *testing.T) {
+	s := &fakeS3{objects: make(map[string][]byte), ignoreConditions: true}
+	testS3Bucket(t, s, func(t *testing.T, b Bucket) {
+		// Opening the bucket leaves nothing behind.
+		assert.Empty(t, s.objects)
+
+		// A service that ignores conditional writes cannot create blobs atomically, so creation fails outright
+		// without writing anything, and leaves any existing blob alone.
+		err := b.Create("a/lock.json", []byte("1"))
+		assert.Error(t, err)
+		assert.False(t, os.IsExist(err))
+		assert.Contains(t, err.Error(), "does not support conditional writes")
+		_, err = b.ReadFile("a/lock.json")
+		assert.True(t, os.IsNotExist(err))
+
+		assert.NoError(t, b.WriteFile("a/lock.json", []byte("1")))
+		assert.Error(t, b.Create("a/lock.json", []byte("2")))
+		data, err := b.ReadFile("a/lock.json")
+		assert.NoError(t, err)
+		assert.Equal(t, "1", string(data))
+	})
+}
+
+func TestBackendState(t *testing.T) {
+	testBuckets(t, func(t *testing.T, bucket Bucket) {
+		b := newLocalBackend(nil, "", "", bucket)
+		snap := deploy.NewSnapshot(deploy.Manifest{}, nil, nil)
+
+		// Stacks are listed once their checkpoints have been saved. Checkpoints of stacks with qualified names are
+		// kept in nested directories, which are not listed.
+		for _, name := range []tokens.QName{"dev", "team/prod"} {
+			key, err := b.saveStack(name, nil, nil, snap)
+			assert.NoError(t, err)
+			assert.Equal(t, ".pulumi/stacks/"+string(name)+".json", key)
+		}
+		_, err := b.saveStack("dev", nil, nil, snap)
+		assert.NoError(t, err)
+		stacks, err := b.getLocalStacks()
+		assert.NoError(t, err)
+		assert.Equal(t, []tokens.QName{"dev"}, stacks)
+		_, _, _, err = b.getStack("team/prod")
+		assert.NoError(t, err)
+
+		// Saving a checkpoint keeps a backup of the previous one.
+		_, err = bucket.ReadFile(".pulumi/stacks/dev.json.bak")
+		assert.NoError(t, err)
+
+		// Updates are recorded in the stack's history, newest first, and the checkpoint is backed up.
+		for _, message := range []string{"first", "second"} {
+			assert.NoError(t, b.addToHistory("dev", backend.UpdateInfo{Message: message}))
+		}
+		assert.NoError(t, b.backupStack("dev"))
+		history, err := b.getHistory("dev")
+		assert.NoError(t, err)
+		if assert.Len(t, history, 2) {
+			assert.Equal(t, "second", history[0].Message)
+			assert.Equal(t, "first", history[1].Message)
+		}
+		backups, err := bucket.List(b.backupDirectory("dev"))
+		assert.NoError(t, err)
+		assert.Len(t, backups, 1)
+
+		// Removing a stack removes its checkpoint and history.
+		assert.NoError(t, b.removeStack("dev"))
+		_, _, _, err = b.getStack("dev")
+		assert.True(t, os.IsNotExist(errors.Cause(err)))
+		history, err = b.getHistory("dev")
+		assert.NoError(t, err)
+		assert.Empty(t, history)
+	})
+}
+
+func TestStackTags(t *testing.T) {
+	testBuckets(t, func(t *testing.T, bucket Bucket) {
+		b := newLocalBackend(nil, "", "", bucket)
+		ctx, ref := context.Background(), localBackendReference{name: "dev"}
+
+		// A new stack has no tags.
+		_, err := b.saveStack("dev", nil, nil, nil)
+		assert.NoError(t, err)
+		tags, err := b.GetStackTags(ctx, ref)
+		assert.NoError(t, err)
+		assert.Empty(t, tags)
+
+		// Tags are replaced wholesale, and are kept when the stack's snapshot is saved.
+		assert.NoError(t, b.UpdateStackTags(ctx, ref, map[apitype.StackTagName]string{"a": "1", "b": "2"}))
+		assert.NoError(t, b.UpdateStackTags(ctx, ref, map[apitype.StackTagName]string{"a": "3"}))
+		persister := b.newSnapshotPersister("dev")
+		assert.NoError(t, persister.Save(deploy.NewSnapshot(deploy.Manifest{}, nil, nil)))
+		tags, err = b.GetStackTags(ctx, ref)
+		assert.NoError(t, err)
+		assert.Equal(t, map[apitype.StackTagName]string{"a": "3"}, tags)
+
+		// Tags cannot be changed while the stack is locked, and must be valid.
+		lock, err := b.lockStack("dev", "update")
+		assert.NoError(t, err)
+		assert.Error(t, b.UpdateStackTags(ctx, ref, map[apitype.StackTagName]string{"a": "4"}))
+		assert.NoError(t, b.unlockStack("dev", lock))
+		assert.Error(t, b.UpdateStackTags(ctx, ref, map[apitype.StackTagName]string{"": "empty"}))
+
+		// A stack that does not exist has no tags to update.
+		_, err = b.GetStackTags(ctx, localBackendReference{name: "prod"})
+		assert.True(t, os.IsNotExist(errors.Cause(err)))
+	})
+}
+
+func TestRenameStack(t *testing.T) {
+	testBuckets(t, func(t *testing.T, bucket Bucket) {
+		b := newLocalBackend(nil, "", "", bucket)
+		ctx, ref := context.Background(), localBackendReference{name: "dev"}
+
+		parent := &resource.State{Type: "test", URN: "urn:pulumi:dev::proj::test::parent"}
+		child := &resource.State{
+			Type:         "test",
+			URN:          "urn:pulumi:dev::proj::test$test::child",
+			Parent:       parent.URN,
+			Dependencies: []resource.URN{parent.URN},
+		}
+		snap := deploy.NewSnapshot(deploy.Manifest{}, []*resource.State{parent, child}, nil)
+		_, err := b.saveStack("dev", nil, map[apitype.StackTagName]string{"a": "1"}, snap)
+		assert.NoError(t, err)
+		assert.NoError(t, b.addToHistory("dev", backend.UpdateInfo{Message: "first"}))
+		_, err = b.saveStack("prod", nil, nil, nil)
+		assert.NoError(t, err)
+
+		// A stack cannot be renamed to the name of an existing stack, or to a name that is locked.
+		_, err = b.RenameStack(ctx, ref, "prod")
+		assert.IsType(t, &backend.StackAlreadyExistsError{}, err)
+		lock, err := b.lockStack("test", "update")
+		assert.NoError(t, err)
+		_, err = b.RenameStack(ctx, ref, "test")
+		assert.IsType(t, &StackLockedError{}, err)
+		assert.NoError(t, b.unlockStack("test", lock))
+
+		// Renaming a stack rewrites its URNs, and keeps its tags and history.
+		newRef, err := b.RenameStack(ctx, ref, "test")
+		assert.NoError(t, err)
+		assert.Equal(t, tokens.QName("test"), newRef.Name())
+		_, loaded, _, err := b.getStack("test")
+		assert.NoError(t, err)
+		if assert.NotNil(t, loaded) && assert.Len(t, loaded.Resources, 2) {
+			renamed := loaded.Resources[1]
+			assert.Equal(t, resource.URN("urn:pulumi:test::proj::test$test::child"), renamed.URN)
+			assert.Equal(t, resource.URN("urn:pulumi:test::proj::test::parent"), renamed.Parent)
+			assert.Equal(t, []resource.URN{"urn:pulumi:test::proj::test::parent"}, renamed.Dependencies)
+		}
+		tags, err := b.GetStackTags(ctx, newRef)
+		assert.NoError(t, err)
+		assert.Equal(t, map[apitype.StackTagName]string{"a": "1"}, tags)
+		history, err := b.getHistory("test")
+		assert.NoError(t, err)
+		if assert.Len(t, history, 1) {
+			assert.Equal(t, "first", history[0].Message)
+		}
+
+		// The renamed stack's history continues in order, and its checkpoint from before the rename is backed up.
+		assert.NoError(t, b.addToHistory("test", backend.UpdateInfo{Message: "second"}))
+		history, err = b.getHistory("test")
+		assert.NoError(t, err)
+		if assert.Len(t, history, 2) {
+			assert.Equal(t, "second", history[0].Message)
+			assert.Equal(t, "first", history[1].Message)
+		}
+		backups, err := bucket.List(b.backupDirectory("test"))
+		assert.NoError(t, err)
+		if assert.Len(t, backups, 1) {
+			assert.True(t, strings.HasPrefix(backups[0], "test."), backups[0])
+		}
+
+		// The old stack is gone, and neither of its names is left locked.
+		_, _, _, err = b.getStack("dev")
+		assert.True(t, os.IsNotExist(errors.Cause(err)))
+		history, err = b.getHistory("dev")
+		assert.NoError(t, err)
+		assert.Empty(t, history)
+		backups, err = bucket.List(b.backupDirectory("dev"))
+		assert.NoError(t, err)
+		assert.Empty(t, backups)
+		for _, name := range []tokens.QName{"dev", "test"} {
+			holder, err := b.getStackLock(name)
+			assert.NoError(t, err)
+			assert.Nil(t, holder)
+		}
+	})
+}
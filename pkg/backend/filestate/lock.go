@@ -0,0 +1,151 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// StackLock records the holder of a stack's lock. A stack is locked for the duration of each operation that changes
+// its state, so that two operations -- whether in the same process, different processes, or on different machines
+// that share a backend -- cannot interleave their changes to the stack's checkpoint.
+type StackLock struct {
+	ID        string    `json:"id"`        // a unique identifier for this acquisition of the lock.
+	User      string    `json:"user"`      // the name of the user that holds the lock.
+	Host      string    `json:"host"`      // the name of the machine on which the lock is held.
+	PID       int       `json:"pid"`       // the ID of the process that holds the lock.
+	Time      time.Time `json:"time"`      // the time at which the lock was acquired.
+	Operation string    `json:"operation"` // the operation for which the lock is held, e.g. "update".
+}
+
+func (l *StackLock) String() string {
+	return fmt.Sprintf("%s@%s (pid %d) for %s since %s",
+		l.User, l.Host, l.PID, l.Operation, l.Time.Format(time.RFC1123))
+}
+
+// StackLockedError is returned when an operation cannot proceed because another operation holds the stack's lock.
+type StackLockedError struct {
+	StackName tokens.QName // the name of the locked stack.
+	Lock      *StackLock   // the holder of the lock.
+}
+
+func (e *StackLockedError) Error() string {
+	return fmt.Sprintf("the stack '%s' is locked by %s; if no operation is running, "+
+		"run `pulumi stack unlock --force` to break the lock", e.StackName, e.Lock)
+}
+
+func (b *localBackend) lockPath(stack tokens.QName) string {
+	contract.Require(stack != "", "stack")
+	return path.Join(workspace.BookkeepingDir, workspace.LockDir, qnameKey(stack)+".json")
+}
+
+// getStackLock returns the holder of the given stack's lock, or nil if the stack is not locked.
+func (b *localBackend) getStackLock(name tokens.QName) (*StackLock, error) {
+	byts, err := b.bucket.ReadFile(b.lockPath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var lock StackLock
+	if err = json.Unmarshal(byts, &lock); err != nil {
+		return nil, errors.Wrapf(err, "reading lock for stack '%s'", name)
+	}
+	return &lock, nil
+}
+
+// lockStack acquires the given stack's lock on behalf of the given operation. If another operation holds the lock, a
+// StackLockedError is returned.
+func (b *localBackend) lockStack(name tokens.QName, operation string) (*StackLock, error) {
+	id, err := resource.NewUniqueHex("", 16, -1)
+	if err != nil {
+		return nil, err
+	}
+	lock := &StackLock{
+		ID:        id,
+		Host:      b.Name(),
+		PID:       os.Getpid(),
+		Time:      time.Now(),
+		Operation: operation,
+	}
+	if lock.User, err = b.CurrentUser(); err != nil {
+		return nil, err
+	}
+
+	byts, err := json.MarshalIndent(lock, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	if err = b.bucket.Create(b.lockPath(name), byts); err != nil {
+		if !os.IsExist(err) {
+			return nil, errors.Wrapf(err, "locking stack '%s'", name)
+		}
+		return nil, b.stackLockedError(name)
+	}
+
+	logging.V(7).Infof("Locked stack %s for %s (id=%s)", name, operation, lock.ID)
+	return lock, nil
+}
+
+// stackLockedError returns an error that describes the current holder of the given stack's lock.
+func (b *localBackend) stackLockedError(name tokens.QName) error {
+	holder, err := b.getStackLock(name)
+	if err != nil || holder == nil {
+		// The lock has been released since we tried to acquire it, or it is unreadable.
+		return errors.Errorf("the stack '%s' is locked by another operation", name)
+	}
+	return &StackLockedError{StackName: name, Lock: holder}
+}
+
+// unlockStack releases the given lock on the given stack. If the lock has since been broken, it is left alone.
+func (b *localBackend) unlockStack(name tokens.QName, lock *StackLock) error {
+	holder, err := b.getStackLock(name)
+	if err != nil {
+		return err
+	}
+	if holder == nil || holder.ID != lock.ID {
+		logging.V(7).Infof("Lock %s on stack %s was broken; not releasing it", lock.ID, name)
+		return nil
+	}
+
+	logging.V(7).Infof("Unlocked stack %s (id=%s)", name, lock.ID)
+	return b.bucket.Delete(b.lockPath(name))
+}
+
+func (b *localBackend) GetStackLock(ctx context.Context, stackRef backend.StackReference) (*StackLock, error) {
+	return b.getStackLock(stackRef.Name())
+}
+
+func (b *localBackend) BreakStackLock(ctx context.Context, stackRef backend.StackReference) error {
+	stackName := stackRef.Name()
+	logging.V(7).Infof("Breaking lock on stack %s", stackName)
+	return b.bucket.Delete(b.lockPath(stackName))
+}
@@ -0,0 +1,103 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStackLock(t *testing.T) {
+	testBuckets(t, func(t *testing.T, bucket Bucket) {
+		b := newLocalBackend(nil, "", "", bucket)
+		ctx, ref := context.Background(), localBackendReference{name: "dev"}
+
+		// A stack that has never been locked has no holder.
+		holder, err := b.GetStackLock(ctx, ref)
+		assert.NoError(t, err)
+		assert.Nil(t, holder)
+
+		// Once locked, the stack reports its holder, and cannot be locked again.
+		lock, err := b.lockStack("dev", "update")
+		if !assert.NoError(t, err) {
+			return
+		}
+		holder, err = b.GetStackLock(ctx, ref)
+		assert.NoError(t, err)
+		if assert.NotNil(t, holder) {
+			assert.Equal(t, lock.ID, holder.ID)
+			assert.Equal(t, os.Getpid(), holder.PID)
+			assert.Equal(t, "update", holder.Operation)
+			assert.NotEmpty(t, holder.User)
+			assert.NotEmpty(t, holder.Host)
+		}
+		_, err = b.lockStack("dev", "destroy")
+		if assert.IsType(t, &StackLockedError{}, err) {
+			assert.Equal(t, lock.ID, err.(*StackLockedError).Lock.ID)
+		}
+
+		// Other stacks are locked independently.
+		other, err := b.lockStack("prod", "update")
+		assert.NoError(t, err)
+		assert.NoError(t, b.unlockStack("prod", other))
+
+		// Once unlocked, the stack can be locked again.
+		assert.NoError(t, b.unlockStack("dev", lock))
+		lock, err = b.lockStack("dev", "refresh")
+		assert.NoError(t, err)
+
+		// Breaking a lock lets another operation take it, and the original holder does not release the new lock.
+		assert.NoError(t, b.BreakStackLock(ctx, ref))
+		newLock, err := b.lockStack("dev", "update")
+		assert.NoError(t, err)
+		assert.NoError(t, b.unlockStack("dev", lock))
+		holder, err = b.GetStackLock(ctx, ref)
+		assert.NoError(t, err)
+		if assert.NotNil(t, holder) {
+			assert.Equal(t, newLock.ID, holder.ID)
+		}
+	})
+}
+
+func TestStackLockContention(t *testing.T) {
+	testBuckets(t, func(t *testing.T, bucket Bucket) {
+		if _, ok := bucket.(*s3Bucket); ok {
+			t.Skip("S3 buckets cannot create objects atomically")
+		}
+
+		// When many operations race to lock the same stack, exactly one of them wins.
+		var wg sync.WaitGroup
+		var lock sync.Mutex
+		acquired := 0
+		for i := 0; i < 16; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				b := newLocalBackend(nil, "", "", bucket)
+				if _, err := b.lockStack("dev", "update"); err == nil {
+					lock.Lock()
+					acquired++
+					lock.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1, acquired)
+	})
+}
@@ -16,11 +16,26 @@ package filestate
 
 import (
 	"os"
+	"time"
 
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
 	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 )
 
+// EnableCheckpointJournalEnvVar is the name of an environment variable that, when truthy, makes the local backend
+// persist each step of an update by appending incremental records to the stack's checkpoint journal instead of
+// rewriting the whole checkpoint. Journaling is off by default. A journal left behind by an interrupted update is
+// replayed when the checkpoint is loaded regardless.
+const EnableCheckpointJournalEnvVar = "PULUMI_ENABLE_CHECKPOINT_JOURNAL"
+
 // localSnapshotManager is a simple SnapshotManager implementation that persists snapshots
 // to disk on the local machine.
 type localSnapshotPersister struct {
@@ -33,16 +48,49 @@ func (sm *localSnapshotPersister) Invalidate() error {
 }
 
 func (sm *localSnapshotPersister) Save(snapshot *deploy.Snapshot) error {
-	config, _, _, err := sm.backend.getStack(sm.name)
-	if err != nil && !os.IsNotExist(err) {
+	// Carry the stack's configuration and tags over to the new checkpoint.
+	var config config.Map
+	var tags map[apitype.StackTagName]string
+	chk, err := sm.backend.getCheckpoint(sm.name)
+	switch {
+	case err == nil:
+		config, tags = chk.Config, chk.Tags
+	case !os.IsNotExist(errors.Cause(err)):
 		return err
 	}
 
-	_, err = sm.backend.saveStack(sm.name, config, snapshot)
+	_, err = sm.backend.saveStack(sm.name, config, tags, snapshot)
 	return err
 
 }
 
-func (b *localBackend) newSnapshotPersister(stackName tokens.QName) *localSnapshotPersister {
-	return &localSnapshotPersister{name: stackName, backend: b}
+// localSnapshotJournaler is a localSnapshotPersister that persists snapshots incrementally by appending records to
+// the stack's checkpoint journal.
+type localSnapshotJournaler struct {
+	localSnapshotPersister
+	base     time.Time // the manifest time of the last saved snapshot, to which the journal applies.
+	sequence int       // the sequence number of the last record appended to the journal.
+}
+
+func (sm *localSnapshotJournaler) Save(snapshot *deploy.Snapshot) error {
+	if err := sm.localSnapshotPersister.Save(snapshot); err != nil {
+		return err
+	}
+	sm.base, sm.sequence = snapshot.Manifest.Time, 0
+	return nil
+}
+
+func (sm *localSnapshotJournaler) Append(snapshot *deploy.Snapshot, entries []stack.JournalEntry) error {
+	contract.Assertf(!sm.base.IsZero(), "a snapshot must be saved before it can be journaled")
+
+	sm.sequence++
+	return sm.backend.appendToJournal(sm.name, sm.base, sm.sequence, snapshot, entries)
+}
+
+func (b *localBackend) newSnapshotPersister(stackName tokens.QName) backend.SnapshotPersister {
+	persister := localSnapshotPersister{name: stackName, backend: b}
+	if !cmdutil.IsTruthy(os.Getenv(EnableCheckpointJournalEnvVar)) {
+		return &persister
+	}
+	return &localSnapshotJournaler{localSnapshotPersister: persister}
 }
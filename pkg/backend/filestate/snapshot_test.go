@@ -0,0 +1,83 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+func TestCheckpointJournal(t *testing.T) {
+	old := os.Getenv(EnableCheckpointJournalEnvVar)
+	defer func() { contract.IgnoreError(os.Setenv(EnableCheckpointJournalEnvVar, old)) }()
+
+	testBuckets(t, func(t *testing.T, bucket Bucket) {
+		b := newLocalBackend(nil, "", "", bucket)
+
+		// The journal is only used once it has been enabled.
+		assert.NoError(t, os.Setenv(EnableCheckpointJournalEnvVar, ""))
+		_, ok := b.newSnapshotPersister("dev").(*localSnapshotJournaler)
+		assert.False(t, ok)
+		assert.NoError(t, os.Setenv(EnableCheckpointJournalEnvVar, "true"))
+		persister, ok := b.newSnapshotPersister("dev").(*localSnapshotJournaler)
+		if !assert.True(t, ok) {
+			return
+		}
+
+		resourceA := &resource.State{Type: "test", URN: "a"}
+		resourceB := &resource.State{Type: "test", URN: "b"}
+		manifest := deploy.Manifest{Time: time.Now()}
+		assert.NoError(t, persister.Save(deploy.NewSnapshot(manifest, []*resource.State{resourceA}, nil)))
+
+		// Changes appended to the journal are replayed when the checkpoint is loaded.
+		manifest.Time = manifest.Time.Add(time.Second)
+		snap := deploy.NewSnapshot(manifest, []*resource.State{resourceA, resourceB}, nil)
+		entries := []stack.JournalEntry{{Kind: apitype.JournalEntryCreate, Index: 1, State: resourceB}}
+		assert.NoError(t, persister.Append(snap, entries))
+		_, loaded, _, err := b.getStack("dev")
+		assert.NoError(t, err)
+		if assert.NotNil(t, loaded) && assert.Len(t, loaded.Resources, 2) {
+			assert.Equal(t, resource.URN("a"), loaded.Resources[0].URN)
+			assert.Equal(t, resource.URN("b"), loaded.Resources[1].URN)
+		}
+
+		// Saving the snapshot compacts the journal.
+		assert.NoError(t, persister.Save(snap))
+		records, err := b.getJournal("dev")
+		assert.NoError(t, err)
+		assert.Empty(t, records)
+		_, loaded, _, err = b.getStack("dev")
+		assert.NoError(t, err)
+		if assert.NotNil(t, loaded) {
+			assert.Len(t, loaded.Resources, 2)
+		}
+
+		// Removing the stack removes its journal.
+		assert.NoError(t, persister.Append(snap, nil))
+		assert.NoError(t, b.removeStack("dev"))
+		records, err = b.getJournal("dev")
+		assert.NoError(t, err)
+		assert.Empty(t, records)
+	})
+}
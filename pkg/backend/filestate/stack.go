@@ -24,18 +24,19 @@ import (
 	"github.com/pulumi/pulumi/pkg/operations"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
 )
 
 // Stack is a local stack.  This simply adds some local-specific properties atop the standard backend stack interface.
 type Stack interface {
 	backend.Stack
-	Path() string // a path to the stack's checkpoint file on disk.
+	Path() string // a URL that identifies the stack's checkpoint, such as file:///home/user/.pulumi/stacks/dev.json.
 }
 
 // localStack is a local stack descriptor.
 type localStack struct {
 	ref      backend.StackReference // the stack's reference (qualified name).
-	path     string                 // a path to the stack's checkpoint file on disk.
+	path     string                 // a URL that identifies the stack's checkpoint.
 	config   config.Map             // the stack's config bag.
 	snapshot *deploy.Snapshot       // a snapshot representing the latest deployment state.
 	b        *localBackend          // a pointer to the backend this stack belongs to.
@@ -62,6 +63,10 @@ func (s *localStack) Remove(ctx context.Context, force bool) (bool, error) {
 	return backend.RemoveStack(ctx, s, force)
 }
 
+func (s *localStack) Rename(ctx context.Context, newName tokens.QName) (backend.StackReference, error) {
+	return backend.RenameStack(ctx, s, newName)
+}
+
 func (s *localStack) Preview(ctx context.Context, op backend.UpdateOperation) (engine.ResourceChanges, error) {
 	return backend.PreviewStack(ctx, s, op)
 }
@@ -74,6 +79,10 @@ func (s *localStack) Refresh(ctx context.Context, op backend.UpdateOperation) (e
 	return backend.RefreshStack(ctx, s, op)
 }
 
+func (s *localStack) Import(ctx context.Context, op backend.UpdateOperation) (engine.ResourceChanges, error) {
+	return backend.ImportStack(ctx, s, op)
+}
+
 func (s *localStack) Destroy(ctx context.Context, op backend.UpdateOperation) (engine.ResourceChanges, error) {
 	return backend.DestroyStack(ctx, s, op)
 }
@@ -17,10 +17,8 @@ package filestate
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path"
-	"path/filepath"
 	"strings"
 	"time"
 
@@ -35,7 +33,6 @@ import (
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/pulumi/pulumi/pkg/util/contract"
-	"github.com/pulumi/pulumi/pkg/util/fsutil"
 	"github.com/pulumi/pulumi/pkg/util/logging"
 	"github.com/pulumi/pulumi/pkg/workspace"
 )
@@ -128,7 +125,7 @@ func (b *localBackend) getStack(name tokens.QName) (config.Map, *deploy.Snapshot
 	}
 
 	// Materialize an actual snapshot object.
-	snapshot, err := stack.DeserializeCheckpoint(chk)
+	snapshot, err := stack.DeserializeCheckpoint(chk, b.checkpointCrypter(name))
 	if err != nil {
 		return nil, nil, "", err
 	}
@@ -144,68 +141,177 @@ func (b *localBackend) getStack(name tokens.QName) (config.Map, *deploy.Snapshot
 	return chk.Config, snapshot, file, nil
 }
 
-// GetCheckpoint loads a checkpoint file for the given stack in this project, from the current project workspace.
+// GetCheckpoint loads a checkpoint file for the given stack in this project, from the backend's bucket.
 func (b *localBackend) getCheckpoint(stackName tokens.QName) (*apitype.CheckpointV3, error) {
 	chkpath := b.stackPath(stackName)
-	bytes, err := ioutil.ReadFile(chkpath)
+	bytes, err := b.bucket.ReadFile(chkpath)
 	if err != nil {
 		return nil, err
 	}
 
-	return stack.UnmarshalVersionedCheckpointToLatestCheckpoint(bytes)
+	chk, err := stack.UnmarshalVersionedCheckpointToLatestCheckpoint(bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// If an update was interrupted before it could compact its journal, replay the journal on top of the checkpoint.
+	if chk.Latest != nil {
+		records, err := b.getJournal(stackName)
+		if err != nil {
+			return nil, err
+		}
+		if err = stack.ApplyJournal(chk.Latest, records); err != nil {
+			return nil, errors.Wrapf(err, "%s: replaying checkpoint journal", chkpath)
+		}
+	}
+
+	return chk, nil
+}
+
+// getJournal returns the records in the given stack's checkpoint journal, in the order in which they were appended.
+func (b *localBackend) getJournal(stackName tokens.QName) ([]apitype.JournalRecordV1, error) {
+	dir := b.journalDirectory(stackName)
+	files, err := b.bucket.List(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []apitype.JournalRecordV1
+	for _, file := range files {
+		byts, err := b.bucket.ReadFile(path.Join(dir, file))
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading journal record %s", file)
+		}
+
+		var record apitype.JournalRecordV1
+		if err = json.Unmarshal(byts, &record); err != nil {
+			return nil, errors.Wrapf(err, "reading journal record %s", file)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// appendToJournal appends a record of the given changes to the given stack's checkpoint journal. The changes apply to
+// the checkpoint whose manifest has the given base time, and result in the given snapshot.
+func (b *localBackend) appendToJournal(name tokens.QName, base time.Time, sequence int,
+	snap *deploy.Snapshot, entries []stack.JournalEntry) error {
+
+	record, err := stack.SerializeJournalRecord(base, snap, entries, b.checkpointCrypter(name))
+	if err != nil {
+		return errors.Wrap(err, "serializing journal record")
+	}
+	byts, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "serializing journal record")
+	}
+
+	// Records are named by sequence number, zero-padded so that listing the journal returns them in order.
+	file := path.Join(b.journalDirectory(name), fmt.Sprintf("%010d.json", sequence))
+	if err = b.bucket.WriteFile(file, byts); err != nil {
+		return errors.Wrap(err, "An IO error occurred during the current operation")
+	}
+
+	logging.V(7).Infof("Appended %d change(s) to stack %s journal: %s", len(entries), name, file)
+	return nil
 }
 
-func (b *localBackend) saveStack(name tokens.QName,
-	config map[config.Key]config.Value, snap *deploy.Snapshot) (string, error) {
+func (b *localBackend) saveStack(name tokens.QName, config map[config.Key]config.Value,
+	tags map[apitype.StackTagName]string, snap *deploy.Snapshot) (string, error) {
 	// Make a serializable stack and then use the encoder to encode it.
+	chk, err := stack.SerializeCheckpoint(name, config, tags, snap, b.checkpointCrypter(name))
+	if err != nil {
+		return "", errors.Wrap(err, "serializing checkpoint")
+	}
+	file, bck, err := b.writeCheckpoint(name, chk)
+	if err != nil {
+		return "", err
+	}
+
+	if !DisableIntegrityChecking {
+		// Finally, *after* writing the checkpoint, check the integrity.  This is done afterwards so that we write
+		// out the checkpoint file since it may contain resource state updates.  But we will warn the user that the
+		// file is already written and might be bad.
+		if verifyerr := snap.VerifyIntegrity(); verifyerr != nil {
+			return "", errors.Wrapf(verifyerr,
+				"%s: snapshot integrity failure; it was already written, but is invalid (backup available at %s)",
+				file, bck)
+		}
+	}
+
+	return file, nil
+}
+
+// writeCheckpoint writes the given checkpoint for the given stack, returning the keys of the checkpoint and of the
+// backup of the checkpoint that it replaced.
+func (b *localBackend) writeCheckpoint(name tokens.QName, chk *apitype.VersionedCheckpoint) (string, string, error) {
 	file := b.stackPath(name)
 	m, ext := encoding.Detect(file)
 	if m == nil {
-		return "", errors.Errorf("resource serialization failed; illegal markup extension: '%v'", ext)
+		return "", "", errors.Errorf("resource serialization failed; illegal markup extension: '%v'", ext)
 	}
-	if filepath.Ext(file) == "" {
+	if path.Ext(file) == "" {
 		file = file + ext
 	}
-	chk := stack.SerializeCheckpoint(name, config, snap)
 	byts, err := m.Marshal(chk)
 	if err != nil {
-		return "", errors.Wrap(err, "An IO error occurred during the current operation")
+		return "", "", errors.Wrap(err, "An IO error occurred during the current operation")
 	}
 
 	// Back up the existing file if it already exists.
-	bck := backupTarget(file)
-
-	// Ensure the directory exists.
-	if err = os.MkdirAll(filepath.Dir(file), 0700); err != nil {
-		return "", errors.Wrap(err, "An IO error occurred during the current operation")
-	}
+	bck := backupTarget(b.bucket, file)
 
 	// And now write out the new snapshot file, overwriting that location.
-	if err = ioutil.WriteFile(file, byts, 0600); err != nil {
-		return "", errors.Wrap(err, "An IO error occurred during the current operation")
+	if err = b.bucket.WriteFile(file, byts); err != nil {
+		return "", "", errors.Wrap(err, "An IO error occurred during the current operation")
 	}
 
 	logging.V(7).Infof("Saved stack %s checkpoint to: %s (backup=%s)", name, file, bck)
 
+	// The new checkpoint subsumes the journal, if any. Records that cannot be removed are harmless, since they no
+	// longer apply to the checkpoint.
+	if err = deleteAll(b.bucket, b.journalDirectory(name)); err != nil {
+		logging.V(7).Infof("Failed to remove stack %s journal: %v", name, err)
+	}
+
 	// And if we are retaining historical checkpoint information, write it out again
 	if cmdutil.IsTruthy(os.Getenv("PULUMI_RETAIN_CHECKPOINTS")) {
-		if err = ioutil.WriteFile(fmt.Sprintf("%v.%v", file, time.Now().UnixNano()), byts, 0600); err != nil {
-			return "", errors.Wrap(err, "An IO error occurred during the current operation")
+		if err = b.bucket.WriteFile(fmt.Sprintf("%v.%v", file, time.Now().UnixNano()), byts); err != nil {
+			return "", "", errors.Wrap(err, "An IO error occurred during the current operation")
 		}
 	}
 
-	if !DisableIntegrityChecking {
-		// Finally, *after* writing the checkpoint, check the integrity.  This is done afterwards so that we write
-		// out the checkpoint file since it may contain resource state updates.  But we will warn the user that the
-		// file is already written and might be bad.
-		if verifyerr := snap.VerifyIntegrity(); verifyerr != nil {
-			return "", errors.Wrapf(verifyerr,
-				"%s: snapshot integrity failure; it was already written, but is invalid (backup available at %s)",
-				file, bck)
-		}
+	return file, bck, nil
+}
+
+// updateStackTags replaces the tags recorded in the given stack's checkpoint. The checkpoint is only rewritten if its
+// tags have changed.
+func (b *localBackend) updateStackTags(name tokens.QName, tags map[apitype.StackTagName]string) error {
+	chk, err := b.getCheckpoint(name)
+	if err != nil {
+		return err
+	}
+	if stackTagsEqual(chk.Tags, tags) {
+		return nil
 	}
 
-	return file, nil
+	chk.Tags = tags
+	_, _, err = b.writeCheckpoint(name, stack.VersionCheckpoint(chk))
+	return err
+}
+
+// stackTagsEqual returns true if the given sets of tags have the same names and values.
+func stackTagsEqual(a, b map[apitype.StackTagName]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if other, has := b[k]; !has || other != v {
+			return false
+		}
+	}
+	return true
 }
 
 // removeStack removes information about a stack from the current workspace.
@@ -214,18 +320,85 @@ func (b *localBackend) removeStack(name tokens.QName) error {
 
 	// Just make a backup of the file and don't write out anything new.
 	file := b.stackPath(name)
-	backupTarget(file)
+	backupTarget(b.bucket, file)
+
+	if err := deleteAll(b.bucket, b.journalDirectory(name)); err != nil {
+		return err
+	}
 
 	historyDir := b.historyDirectory(name)
-	return os.RemoveAll(historyDir)
+	return deleteAll(b.bucket, historyDir)
+}
+
+// renameStack moves the given stack's checkpoint, history and backups to the given new name, rewriting the URNs of
+// its resources to refer to the new name. The checkpoint from before the rename is kept as a backup. The checkpoints
+// kept in the stack's history and backups are left as-is.
+func (b *localBackend) renameStack(oldName, newName tokens.QName) error {
+	contract.Require(oldName != "", "oldName")
+	contract.Require(newName != "", "newName")
+
+	// Ensure the destination stack does not already exist.
+	if _, err := b.getCheckpoint(newName); err == nil {
+		return &backend.StackAlreadyExistsError{StackName: string(newName)}
+	} else if !os.IsNotExist(errors.Cause(err)) {
+		return err
+	}
+
+	// Rewrite the stack's checkpoint for the new name. Rewriting the checkpoint directly, rather than a snapshot, means
+	// that secret values do not need to be decrypted.
+	chk, err := b.getCheckpoint(oldName)
+	if err != nil {
+		return err
+	}
+	chk.Stack = newName
+	if chk.Latest != nil {
+		if err = stack.RenameDeployment(chk.Latest, newName); err != nil {
+			return errors.Wrap(err, "renaming resources")
+		}
+	}
+	if err = b.backupStack(oldName); err != nil {
+		return errors.Wrap(err, "backing up the stack")
+	}
+	if _, _, err = b.writeCheckpoint(newName, stack.VersionCheckpoint(chk)); err != nil {
+		return err
+	}
+
+	// Move the stack's history and backups along with it. The files within them are named after the stack, and are
+	// renamed too, so that the new stack's files sort in the order in which they were written.
+	oldBase, newBase := path.Base(qnameKey(oldName)), path.Base(qnameKey(newName))
+	for _, move := range []struct {
+		from, to             string
+		oldPrefix, newPrefix string
+	}{
+		{b.historyDirectory(oldName), b.historyDirectory(newName), string(oldName) + "-", string(newName) + "-"},
+		{b.backupDirectory(oldName), b.backupDirectory(newName), oldBase + ".", newBase + "."},
+	} {
+		oldPrefix, newPrefix := move.oldPrefix, move.newPrefix
+		err = renameAll(b.bucket, move.from, move.to, func(name string) string {
+			if strings.HasPrefix(name, oldPrefix) {
+				return newPrefix + name[len(oldPrefix):]
+			}
+			return name
+		})
+		if err != nil {
+			return errors.Wrapf(err, "moving %s", move.from)
+		}
+	}
+
+	// Finally, remove the old stack, whose checkpoint has been backed up above. Its journal, if any, has already been
+	// applied to the renamed checkpoint.
+	if err = b.bucket.Delete(b.stackPath(oldName)); err != nil {
+		return err
+	}
+	return deleteAll(b.bucket, b.journalDirectory(oldName))
 }
 
 // backupTarget makes a backup of an existing file, in preparation for writing a new one.  Instead of a copy, it
 // simply renames the file, which is simpler, more efficient, etc.
-func backupTarget(file string) string {
+func backupTarget(bucket Bucket, file string) string {
 	contract.Require(file != "", "file")
 	bck := file + ".bak"
-	err := os.Rename(file, bck)
+	err := bucket.Rename(file, bck)
 	contract.IgnoreError(err) // ignore errors.
 	// IDEA: consider multiple backups (.bak.bak.bak...etc).
 	return bck
@@ -242,7 +415,7 @@ func (b *localBackend) backupStack(name tokens.QName) error {
 
 	// Read the current checkpoint file. (Assuming it aleady exists.)
 	stackPath := b.stackPath(name)
-	byts, err := ioutil.ReadFile(stackPath)
+	byts, err := b.bucket.ReadFile(stackPath)
 	if err != nil {
 		return err
 	}
@@ -250,36 +423,44 @@ func (b *localBackend) backupStack(name tokens.QName) error {
 	// Get the backup directory.
 	backupDir := b.backupDirectory(name)
 
-	// Ensure the backup directory exists.
-	if err = os.MkdirAll(backupDir, 0700); err != nil {
-		return err
-	}
-
 	// Write out the new backup checkpoint file.
-	stackFile := filepath.Base(stackPath)
-	ext := filepath.Ext(stackFile)
+	stackFile := path.Base(stackPath)
+	ext := path.Ext(stackFile)
 	base := strings.TrimSuffix(stackFile, ext)
 	backupFile := fmt.Sprintf("%s.%v%s", base, time.Now().UnixNano(), ext)
-	return ioutil.WriteFile(filepath.Join(backupDir, backupFile), byts, 0600)
+	return b.bucket.WriteFile(path.Join(backupDir, backupFile), byts)
 }
 
+// stackPath returns the key of the given stack's checkpoint within the backend's bucket, or, if the stack name is
+// empty, the key of the directory that contains all of the checkpoints.
 func (b *localBackend) stackPath(stack tokens.QName) string {
-	path := filepath.Join(b.StateDir(), workspace.StackDir)
+	key := path.Join(workspace.BookkeepingDir, workspace.StackDir)
 	if stack != "" {
-		path = filepath.Join(path, fsutil.QnamePath(stack)+".json")
+		key = path.Join(key, qnameKey(stack)+".json")
 	}
 
-	return path
+	return key
 }
 
 func (b *localBackend) historyDirectory(stack tokens.QName) string {
 	contract.Require(stack != "", "stack")
-	return filepath.Join(b.StateDir(), workspace.HistoryDir, fsutil.QnamePath(stack))
+	return path.Join(workspace.BookkeepingDir, workspace.HistoryDir, qnameKey(stack))
+}
+
+func (b *localBackend) journalDirectory(stack tokens.QName) string {
+	contract.Require(stack != "", "stack")
+	return path.Join(workspace.BookkeepingDir, workspace.JournalDir, qnameKey(stack))
 }
 
 func (b *localBackend) backupDirectory(stack tokens.QName) string {
 	contract.Require(stack != "", "stack")
-	return filepath.Join(b.StateDir(), workspace.BackupDir, fsutil.QnamePath(stack))
+	return path.Join(workspace.BookkeepingDir, workspace.BackupDir, qnameKey(stack))
+}
+
+// qnameKey returns the bucket key that corresponds to the given qualified name. Like fsutil.QnamePath, each part of a
+// qualified name becomes a directory, but keys are always separated by slashes.
+func qnameKey(nm tokens.QName) string {
+	return strings.Replace(string(nm), tokens.QNameDelimiter, "/", -1)
 }
 
 // getHistory returns locally stored update history. The first element of the result will be
@@ -287,23 +468,19 @@ func (b *localBackend) backupDirectory(stack tokens.QName) string {
 func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, error) {
 	contract.Require(name != "", "name")
 
+	// History doesn't exist until a stack has been updated, in which case the directory is empty.
 	dir := b.historyDirectory(name)
-	allFiles, err := ioutil.ReadDir(dir)
+	allFiles, err := b.bucket.List(dir)
 	if err != nil {
-		// History doesn't exist until a stack has been updated.
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
 		return nil, err
 	}
 
 	var updates []backend.UpdateInfo
 
-	// os.ReadDir returns the array sorted by file name, but because of how we name files, older updates come before
+	// List returns the array sorted by file name, but because of how we name files, older updates come before
 	// newer ones. Loop backwards so we added the newest updates to the array we will return first.
 	for i := len(allFiles) - 1; i >= 0; i-- {
-		file := allFiles[i]
-		filepath := path.Join(dir, file.Name())
+		filepath := path.Join(dir, allFiles[i])
 
 		// Open all of the history files, ignoring the checkpoints.
 		if !strings.HasSuffix(filepath, ".history.json") {
@@ -311,11 +488,11 @@ func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, erro
 		}
 
 		var update backend.UpdateInfo
-		b, err := ioutil.ReadFile(filepath)
+		byts, err := b.bucket.ReadFile(filepath)
 		if err != nil {
 			return nil, errors.Wrapf(err, "reading history file %s", filepath)
 		}
-		err = json.Unmarshal(b, &update)
+		err = json.Unmarshal(byts, &update)
 		if err != nil {
 			return nil, errors.Wrapf(err, "reading history file %s", filepath)
 		}
@@ -331,9 +508,6 @@ func (b *localBackend) addToHistory(name tokens.QName, update backend.UpdateInfo
 	contract.Require(name != "", "name")
 
 	dir := b.historyDirectory(name)
-	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-		return err
-	}
 
 	// Prefix for the update and checkpoint files.
 	pathPrefix := path.Join(dir, fmt.Sprintf("%s-%d", name, time.Now().UnixNano()))
@@ -345,16 +519,16 @@ func (b *localBackend) addToHistory(name tokens.QName, update backend.UpdateInfo
 	}
 
 	historyFile := fmt.Sprintf("%s.history.json", pathPrefix)
-	if err = ioutil.WriteFile(historyFile, byts, os.ModePerm); err != nil {
+	if err = b.bucket.WriteFile(historyFile, byts); err != nil {
 		return err
 	}
 
 	// Make a copy of the checkpoint file. (Assuming it aleady exists.)
-	byts, err = ioutil.ReadFile(b.stackPath(name))
+	byts, err = b.bucket.ReadFile(b.stackPath(name))
 	if err != nil {
 		return err
 	}
 
 	checkpointFile := fmt.Sprintf("%s.checkpoint.json", pathPrefix)
-	return ioutil.WriteFile(checkpointFile, byts, os.ModePerm)
+	return b.bucket.WriteFile(checkpointFile, byts)
 }
@@ -19,6 +19,7 @@ import (
 	cryptorand "crypto/rand"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net"
@@ -50,6 +51,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/pulumi/pulumi/pkg/util/contract"
@@ -591,6 +593,90 @@ func (b *cloudBackend) RemoveStack(ctx context.Context, stackRef backend.StackRe
 	return b.client.DeleteStack(ctx, stack, force)
 }
 
+func (b *cloudBackend) RenameStack(ctx context.Context, stackRef backend.StackReference,
+	newName tokens.QName) (backend.StackReference, error) {
+
+	stackID, err := b.getCloudStackIdentifier(stackRef)
+	if err != nil {
+		return nil, err
+	}
+	if err = backend.ValidateStackProperties(string(newName), nil); err != nil {
+		return nil, errors.Wrap(err, "validating stack properties")
+	}
+
+	// The renamed stack keeps its owner and project.
+	newRef := stackRef.(cloudBackendReference)
+	newRef.name = newName
+	existing, err := b.GetStack(ctx, newRef)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, &backend.StackAlreadyExistsError{StackName: newRef.String()}
+	}
+
+	// Rewrite the stack's resources to refer to the new name. The deployment is rewritten directly, rather than as a
+	// snapshot, so that secret values do not need to be decrypted.
+	untyped, err := b.ExportDeployment(ctx, stackRef)
+	if err != nil {
+		return nil, err
+	}
+	deployment, err := stack.UnmarshalUntypedDeployment(untyped)
+	if err != nil {
+		return nil, err
+	}
+	if err = stack.RenameDeployment(deployment, newName); err != nil {
+		return nil, errors.Wrap(err, "renaming resources")
+	}
+	data, err := json.Marshal(deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the renamed resources before touching the stack, so that a deployment that cannot be imported leaves the
+	// stack as it was. The check only needs the resources' structure, so secret values are left encrypted.
+	if err = stack.VerifyDeploymentIntegrity(deployment); err != nil {
+		return nil, errors.Wrap(err, "checking the renamed resources")
+	}
+
+	// Rename the stack itself, which keeps its update history, and then import the renamed resources.
+	if err = b.client.RenameStack(ctx, stackID, string(newName)); err != nil {
+		return nil, err
+	}
+	if len(deployment.Resources) > 0 || len(deployment.PendingOperations) > 0 {
+		err = b.ImportDeployment(ctx, newRef, &apitype.UntypedDeployment{
+			Version:    apitype.DeploymentSchemaVersionCurrent,
+			Deployment: json.RawMessage(data),
+		})
+		if err != nil {
+			return nil, b.rollbackRenameStack(ctx, stackID, stackRef, newRef, err)
+		}
+	}
+	return newRef, nil
+}
+
+// rollbackRenameStack restores the original name of a stack whose renamed resources could not be imported, and returns
+// an error that describes the failed import. If the original name cannot be restored, the error also describes how to
+// recover the stack by hand.
+func (b *cloudBackend) rollbackRenameStack(ctx context.Context, stackID client.StackIdentifier,
+	oldRef backend.StackReference, newRef cloudBackendReference, importErr error) error {
+
+	importErr = errors.Wrapf(importErr, "importing the renamed resources of stack '%s'", newRef)
+
+	// The stack is now known by its new name.
+	stackID.Stack = string(newRef.Name())
+	if err := b.client.RenameStack(ctx, stackID, string(oldRef.Name())); err != nil {
+		return errors.Errorf("%v\n"+
+			"The stack was renamed to '%s', but its resources still belong to '%s', and restoring its original "+
+			"name failed: %v\n"+
+			"To recover, either run `pulumi stack rename %s` to restore the original name, or export the stack's "+
+			"deployment with `pulumi stack export`, replace each occurrence of '%s' in its resources' URNs with "+
+			"'%s', and import it with `pulumi stack import`.",
+			importErr, newRef, oldRef, err, oldRef.Name(), oldRef.Name(), newRef.Name())
+	}
+	return errors.Errorf("%v\nThe stack's original name '%s' has been restored.", importErr, oldRef.Name())
+}
+
 // cloudCrypter is an encrypter/decrypter that uses the Pulumi cloud to encrypt/decrypt a stack's secrets.
 type cloudCrypter struct {
 	backend *cloudBackend
@@ -671,6 +757,15 @@ func (b *cloudBackend) Refresh(ctx context.Context, stackRef backend.StackRefere
 	return backend.PreviewThenPromptThenExecute(ctx, apitype.RefreshUpdate, stack, op, b.apply)
 }
 
+func (b *cloudBackend) Import(ctx context.Context, stackRef backend.StackReference,
+	op backend.UpdateOperation) (engine.ResourceChanges, error) {
+	stack, err := getStack(ctx, b, stackRef)
+	if err != nil {
+		return nil, err
+	}
+	return backend.PreviewThenPromptThenExecute(ctx, apitype.ResourceImportUpdate, stack, op, b.apply)
+}
+
 func (b *cloudBackend) Destroy(ctx context.Context, stackRef backend.StackReference,
 	op backend.UpdateOperation) (engine.ResourceChanges, error) {
 	stack, err := getStack(ctx, b, stackRef)
@@ -733,10 +828,12 @@ func (b *cloudBackend) createAndStartUpdate(
 // apply actually performs the provided type of update on a stack hosted in the Pulumi Cloud.
 func (b *cloudBackend) apply(ctx context.Context, kind apitype.UpdateKind, stack backend.Stack,
 	op backend.UpdateOperation, opts backend.ApplierOptions, events chan<- engine.Event) (engine.ResourceChanges, error) {
-	// Print a banner so it's clear this is going to the cloud.
+	// Print a banner so it's clear this is going to the cloud, unless we are writing JSON.
 	actionLabel := backend.ActionLabel(kind, opts.DryRun)
-	fmt.Printf(op.Opts.Display.Color.Colorize(
-		colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stack.Ref())
+	if !op.Opts.Display.JSONDisplay {
+		fmt.Printf(op.Opts.Display.Color.Colorize(
+			colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stack.Ref())
+	}
 
 	// Create an update object to persist results.
 	update, version, token, err := b.createAndStartUpdate(ctx, kind, stack, op, opts.DryRun)
@@ -744,7 +841,7 @@ func (b *cloudBackend) apply(ctx context.Context, kind apitype.UpdateKind, stack
 		return nil, err
 	}
 
-	if opts.ShowLink {
+	if opts.ShowLink && !op.Opts.Display.JSONDisplay {
 		// Print a URL at the end of the update pointing to the Pulumi Service.
 		var link string
 		base := b.cloudConsoleStackPath(update.StackIdentifier)
@@ -776,6 +873,10 @@ func (b *cloudBackend) runEngineAction(
 	if err != nil {
 		return nil, err
 	}
+	crypter, err := b.GetStackCrypter(stackRef)
+	if err != nil {
+		return nil, err
+	}
 
 	// displayEvents renders the event to the console and Pulumi service. The processor for the
 	// will signal all events have been proceed when a value is written to the displayDone channel.
@@ -802,7 +903,7 @@ func (b *cloudBackend) runEngineAction(
 
 	// The backend.SnapshotManager and backend.SnapshotPersister will keep track of any changes to
 	// the Snapshot (checkpoint file) in the HTTP backend.
-	persister := b.newSnapshotPersister(ctx, u.update, u.tokenSource)
+	persister := b.newSnapshotPersister(ctx, u.update, u.tokenSource, crypter)
 	snapshotManager := backend.NewSnapshotManager(persister, u.GetTarget().Snapshot)
 
 	// Depending on the action, kick off the relevant engine activity.  Note that we don't immediately check and
@@ -826,6 +927,8 @@ func (b *cloudBackend) runEngineAction(
 		changes, err = engine.Update(u, engineCtx, op.Opts.Engine, dryRun)
 	case apitype.RefreshUpdate:
 		changes, err = engine.Refresh(u, engineCtx, op.Opts.Engine, dryRun)
+	case apitype.ResourceImportUpdate:
+		changes, err = engine.Import(u, engineCtx, op.Opts.Engine, op.Imports, dryRun)
 	case apitype.DestroyUpdate:
 		changes, err = engine.Destroy(u, engineCtx, op.Opts.Engine, dryRun)
 	default:
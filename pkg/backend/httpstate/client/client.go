@@ -253,6 +253,12 @@ func (pc *Client) DeleteStack(ctx context.Context, stack StackIdentifier, force
 	return isStackHasResourcesError(err), err
 }
 
+// RenameStack renames the indicated stack. The stack keeps its update history.
+func (pc *Client) RenameStack(ctx context.Context, stack StackIdentifier, newName string) error {
+	req := apitype.StackRenameRequest{NewName: newName}
+	return pc.restCall(ctx, "POST", getStackPath(stack, "rename"), nil, &req, nil)
+}
+
 func isStackHasResourcesError(err error) bool {
 	if err == nil {
 		return false
@@ -392,6 +398,8 @@ func (pc *Client) CreateUpdate(
 		endpoint = "preview"
 	case apitype.RefreshUpdate:
 		endpoint = "refresh"
+	case apitype.ResourceImportUpdate:
+		endpoint = "resource-import"
 	case apitype.DestroyUpdate:
 		endpoint = "destroy"
 	default:
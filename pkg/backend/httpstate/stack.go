@@ -127,6 +127,10 @@ func (s *cloudStack) Remove(ctx context.Context, force bool) (bool, error) {
 	return backend.RemoveStack(ctx, s, force)
 }
 
+func (s *cloudStack) Rename(ctx context.Context, newName tokens.QName) (backend.StackReference, error) {
+	return backend.RenameStack(ctx, s, newName)
+}
+
 func (s *cloudStack) Preview(ctx context.Context, op backend.UpdateOperation) (engine.ResourceChanges, error) {
 	return backend.PreviewStack(ctx, s, op)
 }
@@ -139,6 +143,10 @@ func (s *cloudStack) Refresh(ctx context.Context, op backend.UpdateOperation) (e
 	return backend.RefreshStack(ctx, s, op)
 }
 
+func (s *cloudStack) Import(ctx context.Context, op backend.UpdateOperation) (engine.ResourceChanges, error) {
+	return backend.ImportStack(ctx, s, op)
+}
+
 func (s *cloudStack) Destroy(ctx context.Context, op backend.UpdateOperation) (engine.ResourceChanges, error) {
 	return backend.DestroyStack(ctx, s, op)
 }
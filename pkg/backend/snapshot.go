@@ -20,9 +20,12 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/engine"
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
 	"github.com/pulumi/pulumi/pkg/version"
@@ -37,6 +40,25 @@ type SnapshotPersister interface {
 	Save(snapshot *deploy.Snapshot) error
 }
 
+// SnapshotJournaler is a SnapshotPersister that can also persist a snapshot incrementally. Rather than saving the
+// entire snapshot each time a step changes it, the SnapshotManager appends a record of the resources that changed to
+// the persister's journal, and periodically -- and once the plan has finished -- compacts the journal by saving the
+// entire snapshot. The persisted snapshot is the last saved snapshot with each journal record since applied in turn.
+//
+// Only the local backend implements SnapshotJournaler, and only when its checkpoint journal has been enabled; see
+// filestate.EnableCheckpointJournalEnvVar. Other persisters save the entire snapshot each time.
+type SnapshotJournaler interface {
+	SnapshotPersister
+
+	// Append persists the given snapshot by recording the given changes to the resources of the last persisted
+	// snapshot. Append is only called once Save has been called at least once.
+	Append(snapshot *deploy.Snapshot, entries []stack.JournalEntry) error
+}
+
+// journalCompactionInterval is the number of records that the SnapshotManager appends to a journal before it compacts
+// the journal by saving the entire snapshot.
+const journalCompactionInterval = 100
+
 // SnapshotManager is an implementation of engine.SnapshotManager that inspects steps and performs
 // mutations on the global snapshot object serially. This implementation maintains two bits of state: the "base"
 // snapshot, which is completely immutable and represents the state of the world prior to the application
@@ -53,17 +75,26 @@ type SnapshotPersister interface {
 // This is subtle and a little confusing. The reason for this is that the engine directly mutates resource objects
 // that it creates and expects those mutations to be persisted directly to the snapshot.
 type SnapshotManager struct {
-	persister        SnapshotPersister        // The persister responsible for invalidating and persisting the snapshot
-	baseSnapshot     *deploy.Snapshot         // The base snapshot for this plan
-	resources        []*resource.State        // The list of resources operated upon by this plan
-	operations       []resource.Operation     // The set of operations known to be outstanding in this plan
-	dones            map[*resource.State]bool // The set of resources that have been operated upon already by this plan
-	completeOps      map[*resource.State]bool // The set of resources that have completed their operation
-	doVerify         bool                     // If true, verify the snapshot before persisting it
-	plugins          []workspace.PluginInfo   // The list of plugins loaded by the plan, to be saved in the manifest
-	mutationRequests chan<- mutationRequest   // The queue of mutation requests, to be retired serially by the manager
-	cancel           chan bool                // A channel used to request cancellation of any new mutation requests.
-	done             <-chan error             // A channel that sends a single result when the manager has shut down.
+	persister        SnapshotPersister             // The persister that invalidates and persists the snapshot
+	baseSnapshot     *deploy.Snapshot              // The base snapshot for this plan
+	resources        []*resource.State             // The list of resources operated upon by this plan
+	operations       []resource.Operation          // The set of operations known to be outstanding in this plan
+	dones            map[*resource.State]bool      // The set of resources already operated upon by this plan
+	aliases          map[resource.URN]resource.URN // A map from old URNs to the new URNs that aliased them in this plan
+	completeOps      map[*resource.State]bool      // The set of resources that have completed their operation
+	doVerify         bool                          // If true, verify the snapshot before persisting it
+	plugins          []workspace.PluginInfo        // The list of plugins loaded by the plan, to be saved in the manifest
+	mutationRequests chan<- mutationRequest        // The queue of mutation requests, to be retired serially by the manager
+	cancel           chan bool                     // A channel used to request cancellation of any new mutation requests.
+	done             <-chan error                  // A channel that sends a single result when the manager has shut down.
+
+	// The state used to journal changes to the snapshot, if the persister supports journaling. The resources of the
+	// last persisted snapshot are nil until the snapshot has been saved for the first time.
+	journaler     SnapshotJournaler        // The persister, if it supports journaling
+	journaled     []*resource.State        // The resources of the last persisted snapshot
+	journalLength int                      // The number of records appended since the snapshot was last saved
+	dirty         map[*resource.State]bool // The persisted resources that have since been changed in place
+	mustCompact   bool                     // If true, the snapshot must be saved in full the next time it is persisted
 }
 
 var _ engine.SnapshotManager = (*SnapshotManager)(nil)
@@ -117,7 +148,10 @@ func (sm *SnapshotManager) mutate(mutator func() bool) error {
 // Note that this is completely not thread-safe and defeats the purpose of having a `mutate` callback
 // entirely, but the hope is that this state of things will not be permament.
 func (sm *SnapshotManager) RegisterResourceOutputs(step deploy.Step) error {
-	return sm.mutate(func() bool { return true })
+	return sm.mutate(func() bool {
+		sm.markDirty(step.New())
+		return true
+	})
 }
 
 // RecordPlugin records that the current plan loaded a plugin and saves it in the snapshot.
@@ -147,7 +181,7 @@ func (sm *SnapshotManager) BeginMutation(step deploy.Step) (engine.SnapshotMutat
 		return sm.doDelete(step)
 	case deploy.OpReplace:
 		return &replaceSnapshotMutation{sm}, nil
-	case deploy.OpRead, deploy.OpReadReplacement:
+	case deploy.OpRead, deploy.OpReadReplacement, deploy.OpImport:
 		return sm.doRead(step)
 	case deploy.OpRefresh:
 		return &refreshSnapshotMutation{sm}, nil
@@ -177,10 +211,14 @@ type sameSnapshotMutation struct {
 // this step can be elided.
 func (ssm *sameSnapshotMutation) mustWrite(old, new *resource.State) bool {
 	contract.Assert(old.Type == new.Type)
-	contract.Assert(old.URN == new.URN)
 	contract.Assert(old.Delete == new.Delete)
 	contract.Assert(old.External == new.External)
 
+	// If this resource's URN has changed due to an alias, we must write the checkpoint.
+	if old.URN != new.URN {
+		return true
+	}
+
 	// If the kind of this resource has changed, we must write the checkpoint.
 	if old.Custom != new.Custom {
 		return true
@@ -199,6 +237,21 @@ func (ssm *sameSnapshotMutation) mustWrite(old, new *resource.State) bool {
 		return true
 	}
 
+	// If the retention attribute of this resource has changed, we must write the checkpoint.
+	if old.RetainOnDelete != new.RetainOnDelete {
+		return true
+	}
+
+	// If the retry policy of this resource has changed, we must write the checkpoint.
+	if old.RetryPolicy != new.RetryPolicy {
+		return true
+	}
+
+	// If the set of properties whose changes force a replacement has changed, we must write the checkpoint.
+	if !reflect.DeepEqual(old.ReplaceOnChanges, new.ReplaceOnChanges) {
+		return true
+	}
+
 	// If the inputs or outputs of this resource have changed, we must write the checkpoint. Note that it is possible
 	// for the inputs of a "same" resource to have changed even if the contents of the input bags are different if the
 	// resource's provider deems the physical change to be semantically irrelevant.
@@ -226,6 +279,7 @@ func (ssm *sameSnapshotMutation) End(step deploy.Step, successful bool) error {
 	return ssm.manager.mutate(func() bool {
 		ssm.manager.markDone(step.Old())
 		ssm.manager.markNew(step.New())
+		ssm.manager.markAliased(step.Old(), step.New())
 
 		// Note that "Same" steps only consider input and provider diffs, so it is possible to see a same step for a
 		// resource with new dependencies, outputs, parent, protection. etc.
@@ -262,6 +316,9 @@ func (csm *createSnapshotMutation) End(step deploy.Step, successful bool) error
 	logging.V(9).Infof("SnapshotManager: createSnapshotMutation.End(..., %v)", successful)
 	return csm.manager.mutate(func() bool {
 		csm.manager.markOperationComplete(step.New())
+		if old := step.Old(); old != nil {
+			csm.manager.markDirty(old)
+		}
 		if successful {
 			// There is some very subtle behind-the-scenes magic here that
 			// comes into play whenever this create is a CreateReplacement.
@@ -273,6 +330,9 @@ func (csm *createSnapshotMutation) End(step deploy.Step, successful bool) error
 			// (we have pointers to engine-allocated objects), this transparently
 			// "just works" for the SnapshotManager.
 			csm.manager.markNew(step.New())
+			if old := step.Old(); old != nil {
+				csm.manager.markAliased(old, step.New())
+			}
 
 			// If we had an old state that was marked as pending-replacement, mark its replacement as complete such
 			// that it is flushed from the state file.
@@ -286,29 +346,37 @@ func (csm *createSnapshotMutation) End(step deploy.Step, successful bool) error
 
 func (sm *SnapshotManager) doUpdate(step deploy.Step) (engine.SnapshotMutation, error) {
 	logging.V(9).Infof("SnapshotManager.doUpdate(%s)", step.URN())
+
+	// The new state of an updated resource is not assigned an ID until the update is applied. Journal a copy of the new
+	// state that carries the old state's ID so that an interrupted update can be reconciled with the resource's
+	// provider by a later `pulumi up --resume`.
+	pending := *step.New()
+	pending.ID = step.Old().ID
 	err := sm.mutate(func() bool {
-		sm.markOperationPending(step.New(), resource.OperationTypeUpdating)
+		sm.markOperationPending(&pending, resource.OperationTypeUpdating)
 		return true
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &updateSnapshotMutation{sm}, nil
+	return &updateSnapshotMutation{sm, &pending}, nil
 }
 
 type updateSnapshotMutation struct {
 	manager *SnapshotManager
+	pending *resource.State // the state journaled for the pending update.
 }
 
 func (usm *updateSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step != nil, "step != nil")
 	logging.V(9).Infof("SnapshotManager: updateSnapshotMutation.End(..., %v)", successful)
 	return usm.manager.mutate(func() bool {
-		usm.manager.markOperationComplete(step.New())
+		usm.manager.markOperationComplete(usm.pending)
 		if successful {
 			usm.manager.markDone(step.Old())
 			usm.manager.markNew(step.New())
+			usm.manager.markAliased(step.Old(), step.New())
 		}
 		return true
 	})
@@ -336,6 +404,7 @@ func (dsm *deleteSnapshotMutation) End(step deploy.Step, successful bool) error
 	logging.V(9).Infof("SnapshotManager: deleteSnapshotMutation.End(..., %v)", successful)
 	return dsm.manager.mutate(func() bool {
 		dsm.manager.markOperationComplete(step.Old())
+		dsm.manager.markDirty(step.Old())
 		if successful {
 			contract.Assert(!step.Old().Protect)
 			if !step.Old().PendingReplacement {
@@ -400,7 +469,8 @@ func (rsm *refreshSnapshotMutation) End(step deploy.Step, successful bool) error
 		// We always elide refreshes. The expectation is that all of these run before any actual mutations and that
 		// some other component will rewrite the base snapshot in-memory, so there's no action the snapshot
 		// manager needs to take other than to remember that the base snapshot--and therefore the actual snapshot--may
-		// have changed.
+		// have changed. The base snapshot's resources are rewritten in place, so the next write must not be journaled.
+		rsm.manager.mustCompact = true
 		return false
 	})
 }
@@ -437,6 +507,24 @@ func (sm *SnapshotManager) markNew(state *resource.State) {
 	logging.V(9).Infof("Appended new state snapshot to be written: %v", state.URN)
 }
 
+// markAliased records that the given new state aliases the given old state if their URNs differ. References to the
+// old state's URN from resources that were not operated upon by this plan are rewritten to refer to the new URN.
+func (sm *SnapshotManager) markAliased(old, new *resource.State) {
+	contract.Assert(old != nil && new != nil)
+	if old.URN != new.URN {
+		sm.aliases[old.URN] = new.URN
+		logging.V(9).Infof("Marked old state snapshot %v as aliased by %v", old.URN, new.URN)
+	}
+}
+
+// markDirty marks a resource as having been changed in place, so that it is rewritten the next time that the snapshot
+// is journaled.
+func (sm *SnapshotManager) markDirty(state *resource.State) {
+	if sm.journaler != nil && state != nil {
+		sm.dirty[state] = true
+	}
+}
+
 // markOperationPending marks a resource as undergoing an operation that will now be considered pending.
 func (sm *SnapshotManager) markOperationPending(state *resource.State, op resource.OperationType) {
 	contract.Assert(state != nil)
@@ -487,11 +575,12 @@ func (sm *SnapshotManager) snap() *deploy.Snapshot {
 	resources := make([]*resource.State, len(sm.resources))
 	copy(resources, sm.resources)
 
-	// Append any resources from the base plan that were not produced by the current plan.
+	// Append any resources from the base plan that were not produced by the current plan. Any references these
+	// resources hold to resources that were aliased by the current plan are rewritten to refer to the new URNs.
 	if base := sm.baseSnapshot; base != nil {
 		for _, res := range base.Resources {
 			if !sm.dones[res] {
-				resources = append(resources, res)
+				resources = append(resources, sm.rewriteAliases(res))
 			}
 		}
 	}
@@ -514,12 +603,89 @@ func (sm *SnapshotManager) snap() *deploy.Snapshot {
 	return deploy.NewSnapshot(manifest, resources, operations)
 }
 
-// saveSnapshot persists the current snapshot and optionally verifies it afterwards.
+// rewriteAliases returns a copy of the given resource state with any references to aliased URNs rewritten to refer to
+// the URNs that aliased them. If the state holds no such references, it is returned as-is.
+func (sm *SnapshotManager) rewriteAliases(res *resource.State) *resource.State {
+	if len(sm.aliases) == 0 {
+		return res
+	}
+
+	rewrite := func(urn resource.URN) (resource.URN, bool) {
+		if alias, ok := sm.aliases[urn]; ok {
+			return alias, true
+		}
+		return urn, false
+	}
+	rewriteAll := func(urns []resource.URN) ([]resource.URN, bool) {
+		var result []resource.URN
+		changed := false
+		for _, urn := range urns {
+			newURN, ok := rewrite(urn)
+			result, changed = append(result, newURN), changed || ok
+		}
+		return result, changed
+	}
+
+	rewritten := *res
+	changed := false
+	if parent, ok := rewrite(res.Parent); ok {
+		rewritten.Parent, changed = parent, true
+	}
+	if deps, ok := rewriteAll(res.Dependencies); ok {
+		rewritten.Dependencies, changed = deps, true
+	}
+	if len(res.PropertyDependencies) != 0 {
+		propertyDeps, propertyDepsChanged := make(map[resource.PropertyKey][]resource.URN), false
+		for k, deps := range res.PropertyDependencies {
+			newDeps, ok := rewriteAll(deps)
+			propertyDeps[k], propertyDepsChanged = newDeps, propertyDepsChanged || ok
+		}
+		if propertyDepsChanged {
+			rewritten.PropertyDependencies, changed = propertyDeps, true
+		}
+	}
+	if res.Provider != "" {
+		if ref, err := providers.ParseReference(res.Provider); err == nil {
+			if providerURN, ok := rewrite(ref.URN()); ok {
+				newRef, err := providers.NewReference(providerURN, ref.ID())
+				contract.Assert(err == nil)
+				rewritten.Provider, changed = newRef.String(), true
+			}
+		}
+	}
+
+	if !changed {
+		return res
+	}
+	return &rewritten
+}
+
+// saveSnapshot persists the current snapshot and optionally verifies it afterwards. If the persister supports
+// journaling, the snapshot is persisted by appending the changes since the last persisted snapshot to the journal,
+// unless the journal is due to be compacted or the changes cannot be expressed as a journal record.
 func (sm *SnapshotManager) saveSnapshot() error {
 	snap := sm.snap()
-	if err := sm.persister.Save(snap); err != nil {
-		return errors.Wrap(err, "failed to save snapshot")
+
+	journaled := false
+	if sm.journaler != nil && sm.journaled != nil && !sm.mustCompact && sm.journalLength < journalCompactionInterval {
+		if entries, ok := sm.journalEntries(snap.Resources); ok {
+			if err := sm.journaler.Append(snap, entries); err != nil {
+				return errors.Wrap(err, "failed to journal snapshot")
+			}
+			sm.journalLength++
+			journaled = true
+		}
+	}
+	if !journaled {
+		if err := sm.persister.Save(snap); err != nil {
+			return errors.Wrap(err, "failed to save snapshot")
+		}
+		sm.journalLength = 0
+	}
+	if sm.journaler != nil {
+		sm.journaled, sm.dirty, sm.mustCompact = snap.Resources, make(map[*resource.State]bool), false
 	}
+
 	if sm.doVerify {
 		if err := snap.VerifyIntegrity(); err != nil {
 			return errors.Wrapf(err, "failed to verify snapshot")
@@ -528,6 +694,45 @@ func (sm *SnapshotManager) saveSnapshot() error {
 	return nil
 }
 
+// journalEntries returns the changes that turn the resources of the last persisted snapshot into the given resources.
+// Resources are compared by identity: a resource that is only in the given list is created, one that is only in the
+// persisted list is deleted, and one in both lists is updated if it has been changed in place. If the resources that
+// are in both lists are not in the same relative order, the changes cannot be expressed as a journal record, and
+// journalEntries returns false.
+func (sm *SnapshotManager) journalEntries(resources []*resource.State) ([]stack.JournalEntry, bool) {
+	old := sm.journaled
+	inOld, inNew := make(map[*resource.State]bool), make(map[*resource.State]bool)
+	for _, res := range old {
+		inOld[res] = true
+	}
+	for _, res := range resources {
+		inNew[res] = true
+	}
+
+	// Walk both lists in step. The index in the new list is also the index in the list that results from applying
+	// the entries so far, as every resource before it has been created, kept or deleted already.
+	var entries []stack.JournalEntry
+	i, j := 0, 0
+	for i < len(old) || j < len(resources) {
+		switch {
+		case i < len(old) && !inNew[old[i]]:
+			entries = append(entries, stack.JournalEntry{Kind: apitype.JournalEntryDelete, Index: j})
+			i++
+		case j < len(resources) && !inOld[resources[j]]:
+			entries = append(entries, stack.JournalEntry{Kind: apitype.JournalEntryCreate, Index: j, State: resources[j]})
+			j++
+		case i < len(old) && j < len(resources) && old[i] == resources[j]:
+			if sm.dirty[old[i]] {
+				entries = append(entries, stack.JournalEntry{Kind: apitype.JournalEntryUpdate, Index: j, State: old[i]})
+			}
+			i, j = i+1, j+1
+		default:
+			return nil, false
+		}
+	}
+	return entries, true
+}
+
 // NewSnapshotManager creates a new SnapshotManager for the given stack name, using the given persister
 // and base snapshot.
 //
@@ -541,12 +746,16 @@ func NewSnapshotManager(persister SnapshotPersister, baseSnap *deploy.Snapshot)
 		persister:        persister,
 		baseSnapshot:     baseSnap,
 		dones:            make(map[*resource.State]bool),
+		aliases:          make(map[resource.URN]resource.URN),
 		completeOps:      make(map[*resource.State]bool),
 		doVerify:         true,
 		mutationRequests: mutationRequests,
 		cancel:           cancel,
 		done:             done,
 	}
+	if journaler, ok := persister.(SnapshotJournaler); ok {
+		manager.journaler, manager.dirty = journaler, make(map[*resource.State]bool)
+	}
 
 	go func() {
 		// True if we have elided writes since the last actual write.
@@ -570,10 +779,12 @@ func NewSnapshotManager(persister SnapshotPersister, baseSnap *deploy.Snapshot)
 			}
 		}
 
-		// If we still have elided writes once the channel has closed, flush the snapshot.
+		// If we still have elided writes once the channel has closed, flush the snapshot. If we have journaled any
+		// writes, compact the journal.
 		var err error
-		if hasElidedWrites {
+		if hasElidedWrites || manager.journalLength > 0 {
 			logging.V(9).Infof("SnapshotManager: flushing elided writes...")
+			manager.mustCompact = true
 			err = manager.saveSnapshot()
 		}
 		done <- err
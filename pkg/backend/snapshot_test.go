@@ -20,8 +20,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/version"
 	"github.com/pulumi/pulumi/pkg/workspace"
@@ -85,7 +87,7 @@ func TestIdenticalSames(t *testing.T) {
 
 	// The engine generates a SameStep on sameState.
 	engineGeneratedSame := NewResource(string(sameState.URN))
-	same := deploy.NewSameStep(nil, nil, sameState, engineGeneratedSame)
+	same := deploy.NewSameStep(nil, nil, sameState, engineGeneratedSame, nil)
 
 	mutation, err := manager.BeginMutation(same)
 	assert.NoError(t, err)
@@ -137,7 +139,7 @@ func TestSamesWithDependencyChanges(t *testing.T) {
 	// note: now depends on B
 
 	// The engine first generates a Same for b:
-	bSame := deploy.NewSameStep(nil, nil, resourceB, resourceBUpdated)
+	bSame := deploy.NewSameStep(nil, nil, resourceB, resourceBUpdated, nil)
 	mutation, err := manager.BeginMutation(bSame)
 	assert.NoError(t, err)
 	err = mutation.End(bSame, true)
@@ -157,7 +159,7 @@ func TestSamesWithDependencyChanges(t *testing.T) {
 	assert.Len(t, firstSnap.Resources[1].Dependencies, 0)
 
 	// The engine then generates a Same for a:
-	aSame := deploy.NewSameStep(nil, nil, resourceA, resourceAUpdated)
+	aSame := deploy.NewSameStep(nil, nil, resourceA, resourceAUpdated, nil)
 	mutation, err = manager.BeginMutation(aSame)
 	assert.NoError(t, err)
 	err = mutation.End(aSame, true)
@@ -178,6 +180,43 @@ func TestSamesWithDependencyChanges(t *testing.T) {
 	assert.Equal(t, resourceB.URN, secondSnap.Resources[1].Dependencies[0])
 }
 
+// This test exercises same steps for resources whose URNs have changed due to an alias. References to the old URN from
+// resources that have not yet been operated upon must be rewritten to refer to the new URN.
+func TestSamesWithAliases(t *testing.T) {
+	resourceA := NewResource("a-unique-urn-resource-a")
+	resourceB := NewResource("a-unique-urn-resource-b", resourceA.URN)
+	resourceB.Parent = resourceA.URN
+
+	snap := NewSnapshot([]*resource.State{
+		resourceA,
+		resourceB,
+	})
+
+	manager, sp := MockSetup(t, snap)
+
+	// The engine generates a Same for a under its new URN.
+	resourceAAliased := NewResource("a-unique-urn-resource-a-renamed")
+	aSame := deploy.NewSameStep(nil, nil, resourceA, resourceAAliased, nil)
+	mutation, err := manager.BeginMutation(aSame)
+	assert.NoError(t, err)
+	err = mutation.End(aSame, true)
+	assert.NoError(t, err)
+
+	// The snapshot should now contain a under its new URN, and b should refer to a's new URN.
+	assert.Len(t, sp.SavedSnapshots, 1)
+	firstSnap := sp.LastSnap()
+	assert.NoError(t, firstSnap.VerifyIntegrity())
+	assert.Len(t, firstSnap.Resources, 2)
+	assert.Equal(t, resourceAAliased.URN, firstSnap.Resources[0].URN)
+	assert.Equal(t, resourceB.URN, firstSnap.Resources[1].URN)
+	assert.Equal(t, resourceAAliased.URN, firstSnap.Resources[1].Parent)
+	assert.Equal(t, []resource.URN{resourceAAliased.URN}, firstSnap.Resources[1].Dependencies)
+
+	// The base snapshot must not have been modified.
+	assert.Equal(t, resourceA.URN, resourceB.Parent)
+	assert.Equal(t, []resource.URN{resourceA.URN}, resourceB.Dependencies)
+}
+
 // This test exercises same steps with meaningful changes to properties _other_ than `Dependencies` in order to ensure
 // that the snapshot is written.
 func TestSamesWithOtherMeaningfulChanges(t *testing.T) {
@@ -212,7 +251,7 @@ func TestSamesWithOtherMeaningfulChanges(t *testing.T) {
 
 		// The engine generates a Same for p. This is not a meaningful change, so the snapshot is not written.
 		pUpdated := NewResource(string(resourceP.URN))
-		pSame := deploy.NewSameStep(nil, nil, resourceP, pUpdated)
+		pSame := deploy.NewSameStep(nil, nil, resourceP, pUpdated, nil)
 		mutation, err := manager.BeginMutation(pSame)
 		assert.NoError(t, err)
 		err = mutation.End(pSame, true)
@@ -220,7 +259,7 @@ func TestSamesWithOtherMeaningfulChanges(t *testing.T) {
 		assert.Empty(t, sp.SavedSnapshots)
 
 		// The engine generates a Same for a. Because this is a meaningful change, the snapshot is written:
-		aSame := deploy.NewSameStep(nil, nil, resourceA, c)
+		aSame := deploy.NewSameStep(nil, nil, resourceA, c, nil)
 		mutation, err = manager.BeginMutation(aSame)
 		assert.NoError(t, err)
 		err = mutation.End(aSame, true)
@@ -322,14 +361,15 @@ func TestVexingDeployment(t *testing.T) {
 
 	// b now depends on nothing
 	bPrime := NewResource(string(b.URN))
-	applyStep(deploy.NewSameStep(nil, MockRegisterResourceEvent{}, b, bPrime))
+	applyStep(deploy.NewSameStep(nil, MockRegisterResourceEvent{}, b, bPrime, nil))
 
 	// c now only depends on b
 	cPrime := NewResource(string(c.URN), bPrime.URN)
 
 	// mocking out the behavior of a provider indicating that this resource needs to be deleted
-	createReplacement := deploy.NewCreateReplacementStep(nil, MockRegisterResourceEvent{}, c, cPrime, nil, true)
-	replace := deploy.NewReplaceStep(nil, c, cPrime, nil, true)
+	createReplacement := deploy.NewCreateReplacementStep(nil, MockRegisterResourceEvent{}, c, cPrime, nil, nil,
+		false, nil, true)
+	replace := deploy.NewReplaceStep(nil, c, cPrime, nil, nil, false, nil, true)
 	c.Delete = true
 
 	applyStep(createReplacement)
@@ -338,7 +378,7 @@ func TestVexingDeployment(t *testing.T) {
 	// cPrime now exists, c is now pending deletion
 	// dPrime now depends on cPrime, which got replaced
 	dPrime := NewResource(string(d.URN), cPrime.URN)
-	applyStep(deploy.NewUpdateStep(nil, MockRegisterResourceEvent{}, d, dPrime, nil))
+	applyStep(deploy.NewUpdateStep(nil, MockRegisterResourceEvent{}, d, dPrime, nil, nil, nil))
 
 	lastSnap := sp.SavedSnapshots[len(sp.SavedSnapshots)-1]
 	assert.Len(t, lastSnap.Resources, 6)
@@ -490,6 +530,7 @@ func TestRecordingCreateFailure(t *testing.T) {
 
 func TestRecordingUpdateSuccess(t *testing.T) {
 	resourceA := NewResource("a")
+	resourceA.ID = "a-id"
 	resourceA.Inputs["key"] = resource.NewStringProperty("old")
 	resourceANew := NewResource("a")
 	resourceANew.Inputs["key"] = resource.NewStringProperty("new")
@@ -498,18 +539,19 @@ func TestRecordingUpdateSuccess(t *testing.T) {
 	})
 
 	manager, sp := MockSetup(t, snap)
-	step := deploy.NewUpdateStep(nil, &MockRegisterResourceEvent{}, resourceA, resourceANew, nil)
+	step := deploy.NewUpdateStep(nil, &MockRegisterResourceEvent{}, resourceA, resourceANew, nil, nil, nil)
 	mutation, err := manager.BeginMutation(step)
 	if !assert.NoError(t, err) {
 		t.FailNow()
 	}
 
 	// Beginning the update mutation should have placed a pending "updating" operation into
-	// the operations list, with the resource's new inputs.
+	// the operations list, with the resource's ID and new inputs.
 	snap = sp.LastSnap()
 	assert.Len(t, snap.Resources, 1)
 	assert.Len(t, snap.PendingOperations, 1)
 	assert.Equal(t, resourceA.URN, snap.PendingOperations[0].Resource.URN)
+	assert.Equal(t, resourceA.ID, snap.PendingOperations[0].Resource.ID)
 	assert.Equal(t, resource.OperationTypeUpdating, snap.PendingOperations[0].Type)
 	assert.Equal(t, resource.NewStringProperty("new"), snap.PendingOperations[0].Resource.Inputs["key"])
 
@@ -537,7 +579,7 @@ func TestRecordingUpdateFailure(t *testing.T) {
 	})
 
 	manager, sp := MockSetup(t, snap)
-	step := deploy.NewUpdateStep(nil, &MockRegisterResourceEvent{}, resourceA, resourceANew, nil)
+	step := deploy.NewUpdateStep(nil, &MockRegisterResourceEvent{}, resourceA, resourceANew, nil, nil, nil)
 	mutation, err := manager.BeginMutation(step)
 	if !assert.NoError(t, err) {
 		t.FailNow()
@@ -784,7 +826,7 @@ func TestRegisterOutputs(t *testing.T) {
 	assert.Len(t, sp.SavedSnapshots, 0)
 
 	// The step here is not important.
-	step := deploy.NewSameStep(nil, nil, resourceA, resourceA)
+	step := deploy.NewSameStep(nil, nil, resourceA, resourceA, nil)
 	err := manager.RegisterResourceOutputs(step)
 	if !assert.NoError(t, err) {
 		t.FailNow()
@@ -815,3 +857,99 @@ func TestSavePlugins(t *testing.T) {
 	assert.Len(t, lastSnap.Manifest.Plugins, 1)
 	assert.Equal(t, "myplugin", lastSnap.Manifest.Plugins[0].Name)
 }
+
+// MockStackJournaler is a SnapshotJournaler that replays each journal record on top of the last saved snapshot.
+type MockStackJournaler struct {
+	MockStackPersister
+	t *testing.T
+
+	Deployment *apitype.DeploymentV3 // the last saved snapshot with each journal record since applied.
+	Appends    int                   // the number of journal records appended.
+}
+
+func (m *MockStackJournaler) Save(snap *deploy.Snapshot) error {
+	deployment, err := stack.SerializeDeployment(snap, nil)
+	if err != nil {
+		return err
+	}
+	m.Deployment = deployment
+	return m.MockStackPersister.Save(snap)
+}
+
+func (m *MockStackJournaler) Append(snap *deploy.Snapshot, entries []stack.JournalEntry) error {
+	record, err := stack.SerializeJournalRecord(m.Deployment.Manifest.Time, snap, entries, nil)
+	if err != nil {
+		return err
+	}
+	if err = stack.ApplyJournal(m.Deployment, []apitype.JournalRecordV1{*record}); err != nil {
+		return err
+	}
+	m.Appends++
+
+	// Replaying the journal must produce the snapshot that it persists.
+	expected, err := stack.SerializeDeployment(snap, nil)
+	if err != nil {
+		return err
+	}
+	assert.Equal(m.t, expected.Resources, m.Deployment.Resources)
+	assert.Equal(m.t, expected.PendingOperations, m.Deployment.PendingOperations)
+	return nil
+}
+
+func TestJournaling(t *testing.T) {
+	resourceA := NewResource("a")
+	resourceA.ID = "a-id"
+	resourceA.Inputs["key"] = resource.NewStringProperty("old")
+	resourceB := NewResource("b")
+	resourceB.ID = "b-id"
+	snap := NewSnapshot([]*resource.State{
+		resourceA,
+		resourceB,
+	})
+	if !assert.NoError(t, snap.VerifyIntegrity()) {
+		t.FailNow()
+	}
+
+	sp := &MockStackJournaler{t: t}
+	manager := NewSnapshotManager(sp, snap)
+
+	run := func(step deploy.Step) {
+		mutation, err := manager.BeginMutation(step)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		if !assert.NoError(t, mutation.End(step, true /* successful */)) {
+			t.FailNow()
+		}
+	}
+
+	// Update A, create C, register C's outputs, and delete B.
+	resourceANew := NewResource("a")
+	resourceANew.Inputs["key"] = resource.NewStringProperty("new")
+	run(deploy.NewUpdateStep(nil, &MockRegisterResourceEvent{}, resourceA, resourceANew, nil, nil, nil))
+
+	resourceC := NewResource("c")
+	create := deploy.NewCreateStep(nil, &MockRegisterResourceEvent{}, resourceC)
+	run(create)
+	resourceC.Outputs["out"] = resource.NewStringProperty("value")
+	if !assert.NoError(t, manager.RegisterResourceOutputs(create)) {
+		t.FailNow()
+	}
+
+	run(deploy.NewDeleteStep(nil, resourceB))
+
+	// The first write saves the snapshot in full; each later write is journaled.
+	assert.Len(t, sp.SavedSnapshots, 1)
+	assert.True(t, sp.Appends > 0)
+
+	// Closing the manager compacts the journal.
+	if !assert.NoError(t, manager.Close()) {
+		t.FailNow()
+	}
+	assert.Len(t, sp.SavedSnapshots, 2)
+	lastSnap := sp.LastSnap()
+	if assert.Len(t, lastSnap.Resources, 2) {
+		assert.Equal(t, resourceANew, lastSnap.Resources[0])
+		assert.Equal(t, resourceC, lastSnap.Resources[1])
+	}
+}
@@ -28,6 +28,8 @@ import (
 	"github.com/pulumi/pulumi/pkg/operations"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/ciutil"
 	"github.com/pulumi/pulumi/pkg/util/gitutil"
 	"github.com/pulumi/pulumi/pkg/workspace"
 )
@@ -45,11 +47,15 @@ type Stack interface {
 	Update(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, error)
 	// Refresh this stack's state from the cloud provider.
 	Refresh(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, error)
+	// Import existing resources into this stack.
+	Import(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, error)
 	// Destroy this stack's resources.
 	Destroy(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, error)
 
 	// remove this stack.
 	Remove(ctx context.Context, force bool) (bool, error)
+	// rename this stack.
+	Rename(ctx context.Context, newName tokens.QName) (StackReference, error)
 	// list log entries for this stack.
 	GetLogs(ctx context.Context, query operations.LogQuery) ([]operations.LogEntry, error)
 	// export this stack's deployment.
@@ -63,6 +69,11 @@ func RemoveStack(ctx context.Context, s Stack, force bool) (bool, error) {
 	return s.Backend().RemoveStack(ctx, s.Ref(), force)
 }
 
+// RenameStack renames the stack, or returns an error if it cannot.
+func RenameStack(ctx context.Context, s Stack, newName tokens.QName) (StackReference, error) {
+	return s.Backend().RenameStack(ctx, s.Ref(), newName)
+}
+
 // PreviewStack previews changes to this stack.
 func PreviewStack(ctx context.Context, s Stack, op UpdateOperation) (engine.ResourceChanges, error) {
 	return s.Backend().Preview(ctx, s.Ref(), op)
@@ -78,6 +89,11 @@ func RefreshStack(ctx context.Context, s Stack, op UpdateOperation) (engine.Reso
 	return s.Backend().Refresh(ctx, s.Ref(), op)
 }
 
+// ImportStack adopts the existing resources listed in the operation into the stack.
+func ImportStack(ctx context.Context, s Stack, op UpdateOperation) (engine.ResourceChanges, error) {
+	return s.Backend().Import(ctx, s.Ref(), op)
+}
+
 // DestroyStack destroys all of this stack's resources.
 func DestroyStack(ctx context.Context, s Stack, op UpdateOperation) (engine.ResourceChanges, error) {
 	return s.Backend().Destroy(ctx, s.Ref(), op)
@@ -88,6 +104,14 @@ func GetStackCrypter(s Stack) (config.Crypter, error) {
 	return s.Backend().GetStackCrypter(s.Ref())
 }
 
+// GetLazyStackCrypter returns an encrypter/decrypter for a stack that is only fetched once a value actually needs to be
+// encrypted or decrypted.  This avoids prompting for a passphrase when a stack's state contains no secrets.
+func GetLazyStackCrypter(s Stack) config.Crypter {
+	return config.NewLazyCrypter(func() (config.Crypter, error) {
+		return GetStackCrypter(s)
+	})
+}
+
 // GetLatestConfiguration returns the configuration for the most recent deployment of the stack.
 func GetLatestConfiguration(ctx context.Context, s Stack) (config.Map, error) {
 	return s.Backend().GetLatestConfiguration(ctx, s.Ref())
@@ -171,6 +195,11 @@ func GetEnvironmentTagsForCurrentStack() (map[apitype.StackTagName]string, error
 		contract.IgnoreError(ignoredErr)
 	}
 
+	// Tags based on the CI system, if any.
+	if system := ciutil.DetectSystem(); system != "" {
+		tags[apitype.CISystemTag] = string(system)
+	}
+
 	return tags, nil
 }
 
@@ -52,3 +52,50 @@ func GetAnalyzeResourceFailureError(urn resource.URN) *Diag {
 func GetPreviewFailedError(urn resource.URN) *Diag {
 	return newError(urn, 2005, "Preview failed: %v")
 }
+
+func GetResourceWillBeCreatedButWasNotSpecifiedInTargetList(urn resource.URN) *Diag {
+	return newError(urn, 2006, "Resource '%v' will be created but was not specified in --target list")
+}
+
+func GetResourceWillBeReplacedButWasNotSpecifiedInTargetList(urn resource.URN) *Diag {
+	return newError(urn, 2007,
+		"Resource '%v' must be replaced due to a change to '%v' but was not specified in --target list")
+}
+
+func GetResourceWillBeOrphanedButWasNotSpecifiedInTargetList(urn resource.URN) *Diag {
+	return newError(urn, 2008,
+		"Resource '%v' depends on '%v', which will be deleted, but was not specified in --target list")
+}
+
+func GetDuplicateResourceAliasError(urn resource.URN) *Diag {
+	return newError(urn, 2009,
+		"Duplicate resource URN '%v' conflicts with alias on resource with URN '%v'")
+}
+
+func GetInvalidIgnoreChangesPathError(urn resource.URN) *Diag {
+	return newError(urn, 2010, "Cannot ignore changes to '%v': %v")
+}
+
+func GetImportResourceExistsError(urn resource.URN) *Diag {
+	return newError(urn, 2011, "Cannot import resource '%v': a resource with this URN already exists in the stack")
+}
+
+func GetResourceViolatesPlanError(urn resource.URN) *Diag {
+	return newError(urn, 2012, "Resource '%v' violates the update plan: %v")
+}
+
+func GetResourceSkippedWarning(urn resource.URN) *Diag {
+	return newError(urn, 2013, "Resource '%v' was skipped because a resource on which it depends failed")
+}
+
+func GetInvalidReplaceOnChangesPathError(urn resource.URN) *Diag {
+	return newError(urn, 2014, "Cannot replace on changes to '%v': %v")
+}
+
+func GetAnalyzeResourceFailureWarning(urn resource.URN) *Diag {
+	return newError(urn, 2015,
+		"Analyzer '%v' reported an advisory resource error:\n"+
+			"\tResource: %v\n"+
+			"\tProperty: %v\n"+
+			"\tReason: %v")
+}
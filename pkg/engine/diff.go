@@ -28,6 +28,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 )
 
@@ -59,6 +60,10 @@ func printStepHeader(b *bytes.Buffer, step StepEventMetadata) {
 		// show a locked symbol, since we are either newly protecting this resource, or retaining protection.
 		extra = " 🔒"
 	}
+	if (step.Op == deploy.OpDelete || step.Op == deploy.OpDeleteReplaced) && old != nil && old.RetainOnDelete {
+		// note that the resource will be forgotten rather than deleted.
+		extra += " [retained]"
+	}
 	writeString(b, fmt.Sprintf("%s: (%s)%s\n", string(step.Type), step.Op, extra))
 }
 
@@ -171,6 +176,16 @@ func GetResourcePropertiesSummary(step StepEventMetadata, indent int) string {
 		}
 	}
 
+	// If the resource is being replaced because its replacement was explicitly requested, say so.
+	if step.ForceReplace {
+		writeWithIndentNoPrefix(&b, indent+1, simplePropOp, "[forced replacement]\n")
+	}
+
+	// If changes to any properties were ignored, list them.
+	if len(step.Ignores) > 0 {
+		writeWithIndentNoPrefix(&b, indent+1, simplePropOp, "[ignoreChanges=%s]\n", strings.Join(step.Ignores, ", "))
+	}
+
 	return b.String()
 }
 
@@ -195,6 +210,13 @@ func GetResourcePropertiesDetails(
 		if !summary {
 			printObject(&b, old.Inputs, planning, indent, step.Op, false, debug)
 		}
+	} else if step.DetailedDiff != nil {
+		// If the provider reported a detailed diff, display exactly the changes that it reported.
+		if diff := TranslateDetailedDiff(step); len(diff.Adds) > 0 || len(diff.Deletes) > 0 || len(diff.Updates) > 0 {
+			printObjectDiff(&b, *diff, planning, indent, summary, debug)
+		} else {
+			printObject(&b, new.Inputs, planning, indent, deploy.OpSame, true, debug)
+		}
 	} else if len(new.Outputs) > 0 {
 		printOldNewDiffs(&b, old.Outputs, new.Outputs, planning, indent, step.Op, summary, debug)
 	} else {
@@ -239,12 +261,14 @@ func GetResourceOutputsPropertiesString(
 	//   1) not doing a preview
 	//   2) doing a refresh
 	//   3) doing a read
+	//   4) doing an import
 	//
-	// Technically, 2 and 3 are the same, since they're both bottoming out at a provider's implementation of Read, but
-	// the upshot is that either way we're ending up with outputs that are exactly accurate. If we are not sure that we
-	// are in one of the above states, we shouldn't try to print outputs.
+	// Technically, 2, 3, and 4 are the same, since they all bottom out at a provider's implementation of Read, but the
+	// upshot is that either way we're ending up with outputs that are exactly accurate. If we are not sure that we are
+	// in one of the above states, we shouldn't try to print outputs.
 	if planning {
-		printOutputDuringPlanning := refresh || step.Op == deploy.OpRead || step.Op == deploy.OpReadReplacement
+		printOutputDuringPlanning := refresh || step.Op == deploy.OpRead || step.Op == deploy.OpReadReplacement ||
+			step.Op == deploy.OpImport
 		if !printOutputDuringPlanning {
 			return ""
 		}
@@ -311,7 +335,8 @@ func GetResourceOutputsPropertiesString(
 
 func considerSameIfNotCreateOrDelete(op deploy.StepOp) deploy.StepOp {
 	switch op {
-	case deploy.OpCreate, deploy.OpDelete, deploy.OpDeleteReplaced, deploy.OpReadDiscard, deploy.OpDiscardReplaced:
+	case deploy.OpCreate, deploy.OpDelete, deploy.OpDeleteReplaced, deploy.OpReadDiscard, deploy.OpDiscardReplaced,
+		deploy.OpImport:
 		return op
 	default:
 		return deploy.OpSame
@@ -443,6 +468,178 @@ func shortHash(hash string) string {
 	return hash
 }
 
+// TranslateDetailedDiff converts the detailed diff reported by a step's provider into an ObjectDiff that is
+// appropriate for display. Only the properties that the provider reported as changed are recorded as adds, deletes,
+// or updates; all other properties are recorded as sames. The step must have old and new states and a detailed diff.
+func TranslateDetailedDiff(step StepEventMetadata) *resource.ObjectDiff {
+	contract.Assert(step.DetailedDiff != nil)
+	contract.Assert(step.Old != nil && step.New != nil)
+
+	// Visit the paths in sorted order so that changes to an object or array are always seen before changes to any
+	// of its elements.
+	paths := make([]string, 0, len(step.DetailedDiff))
+	for path := range step.DetailedDiff {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	news := resource.NewObjectProperty(step.New.Inputs)
+	diff := resource.ValueDiff{Object: newEmptyObjectDiff()}
+	for _, path := range paths {
+		pdiff := step.DetailedDiff[path]
+
+		// If the provider reported a path that we cannot parse, treat it as the name of a top-level property.
+		elements, err := resource.ParsePropertyPath(path)
+		if err != nil {
+			elements = resource.PropertyPath{path}
+		}
+
+		// Input diffs compare the old inputs to the new inputs; all other diffs compare the old state to the new
+		// inputs.
+		olds := resource.NewObjectProperty(step.Old.Outputs)
+		if pdiff.InputDiff {
+			olds = resource.NewObjectProperty(step.Old.Inputs)
+		}
+
+		addDetailedDiff(&diff, elements, pdiff.Kind, olds, news)
+	}
+	fillDetailedDiffSames(&diff, news)
+	return diff.Object
+}
+
+func newEmptyObjectDiff() *resource.ObjectDiff {
+	return &resource.ObjectDiff{
+		Adds:    make(resource.PropertyMap),
+		Deletes: make(resource.PropertyMap),
+		Sames:   make(resource.PropertyMap),
+		Updates: make(map[resource.PropertyKey]resource.ValueDiff),
+	}
+}
+
+func newEmptyArrayDiff() *resource.ArrayDiff {
+	return &resource.ArrayDiff{
+		Adds:    make(map[int]resource.PropertyValue),
+		Deletes: make(map[int]resource.PropertyValue),
+		Sames:   make(map[int]resource.PropertyValue),
+		Updates: make(map[int]resource.ValueDiff),
+	}
+}
+
+// addDetailedDiff records a single property diff reported by a provider in the given parent diff. The path is
+// relative to the parent, and olds and news are the parent's old and new values.
+func addDetailedDiff(parent *resource.ValueDiff, path resource.PropertyPath, kind plugin.DiffKind,
+	olds, news resource.PropertyValue) {
+
+	contract.Assert(len(path) > 0)
+
+	element := resource.PropertyPath{path[0]}
+	old, _ := element.Get(olds)
+	new, _ := element.Get(news)
+
+	// Compute the diff for this element. If this is not the last element in the path, the diff is an update that
+	// contains the diff for the rest of the path.
+	var add, del, update bool
+	var vd resource.ValueDiff
+	switch {
+	case len(path) > 1:
+		update, vd = true, resource.ValueDiff{Old: old, New: new}
+	case kind == plugin.DiffAdd || kind == plugin.DiffAddReplace:
+		add = true
+	case kind == plugin.DiffDelete || kind == plugin.DiffDeleteReplace:
+		del = true
+	default:
+		update = true
+		if d := old.Diff(new); d != nil {
+			vd = *d
+		} else {
+			vd = resource.ValueDiff{Old: old, New: new}
+		}
+	}
+
+	switch key := path[0].(type) {
+	case int:
+		if parent.Array == nil {
+			parent.Array = newEmptyArrayDiff()
+		}
+		diff := parent.Array
+		if _, has := diff.Adds[key]; has {
+			return
+		}
+		if _, has := diff.Deletes[key]; has {
+			return
+		}
+		switch {
+		case add:
+			diff.Adds[key] = new
+		case del:
+			diff.Deletes[key] = old
+		case update:
+			if len(path) > 1 {
+				if existing, has := diff.Updates[key]; has {
+					vd = existing
+				}
+				addDetailedDiff(&vd, path[1:], kind, old, new)
+			}
+			diff.Updates[key] = vd
+		}
+	case string:
+		if parent.Object == nil {
+			parent.Object = newEmptyObjectDiff()
+		}
+		diff, k := parent.Object, resource.PropertyKey(key)
+		if _, has := diff.Adds[k]; has {
+			return
+		}
+		if _, has := diff.Deletes[k]; has {
+			return
+		}
+		switch {
+		case add:
+			diff.Adds[k] = new
+		case del:
+			diff.Deletes[k] = old
+		case update:
+			if len(path) > 1 {
+				if existing, has := diff.Updates[k]; has {
+					vd = existing
+				}
+				addDetailedDiff(&vd, path[1:], kind, old, new)
+			}
+			diff.Updates[k] = vd
+		}
+	default:
+		contract.Failf("unexpected property path element %v", key)
+	}
+}
+
+// fillDetailedDiffSames records each element of news that is not otherwise part of the given diff as unchanged.
+func fillDetailedDiffSames(diff *resource.ValueDiff, news resource.PropertyValue) {
+	switch {
+	case diff.Object != nil && news.IsObject():
+		for k, v := range news.ObjectValue() {
+			if update, has := diff.Object.Updates[k]; has {
+				fillDetailedDiffSames(&update, v)
+				diff.Object.Updates[k] = update
+			} else if _, has = diff.Object.Adds[k]; !has {
+				if _, has = diff.Object.Deletes[k]; !has {
+					diff.Object.Sames[k] = v
+				}
+			}
+		}
+	case diff.Array != nil && news.IsArray():
+		for i, v := range news.ArrayValue() {
+			if update, has := diff.Array.Updates[i]; has {
+				fillDetailedDiffSames(&update, v)
+				diff.Array.Updates[i] = update
+			} else if _, has = diff.Array.Adds[i]; !has {
+				if _, has = diff.Array.Deletes[i]; !has {
+					diff.Array.Sames[i] = v
+				}
+			}
+		}
+	}
+}
+
 func printOldNewDiffs(
 	b *bytes.Buffer, olds resource.PropertyMap, news resource.PropertyMap,
 	planning bool, indent int, op deploy.StepOp, summary bool, debug bool) {
@@ -564,7 +761,7 @@ func printPropertyValueDiff(
 
 func isPrimitive(value resource.PropertyValue) bool {
 	return value.IsNull() || value.IsString() || value.IsNumber() ||
-		value.IsBool() || value.IsComputed() || value.IsOutput()
+		value.IsBool() || value.IsComputed() || value.IsOutput() || value.IsSecret()
 }
 
 func printPrimitivePropertyValue(b *bytes.Buffer, v resource.PropertyValue, planning bool, op deploy.StepOp) {
@@ -591,6 +788,9 @@ func printPrimitivePropertyValue(b *bytes.Buffer, v resource.PropertyValue, plan
 		} else {
 			write(b, op, "undefined")
 		}
+	} else if v.IsSecret() {
+		// Never print the underlying value of a secret.
+		write(b, op, "[secret]")
 	} else {
 		contract.Failf("Unexpected property value kind")
 	}
@@ -23,6 +23,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
@@ -75,10 +76,12 @@ type PreludeEventPayload struct {
 }
 
 type SummaryEventPayload struct {
-	IsPreview       bool            // true if this summary is for a plan operation
-	MaybeCorrupt    bool            // true if one or more resources may be corrupt
-	Duration        time.Duration   // the duration of the entire update operation (zero values for previews)
-	ResourceChanges ResourceChanges // count of changed resources, useful for reporting
+	IsPreview        bool            // true if this summary is for a plan operation
+	MaybeCorrupt     bool            // true if one or more resources may be corrupt
+	Duration         time.Duration   // the duration of the entire update operation (zero values for previews)
+	ResourceChanges  ResourceChanges // count of changed resources, useful for reporting
+	FailedResources  []resource.URN  // the resources whose operations failed
+	SkippedResources []resource.URN  // the resources whose operations were skipped due to a failed dependency
 }
 
 type ResourceOperationFailedPayload struct {
@@ -109,6 +112,12 @@ type StepEventMetadata struct {
 	Keys     []resource.PropertyKey  // the keys causing replacement (only for CreateStep and ReplaceStep).
 	Logical  bool                    // true if this step represents a logical operation in the program.
 	Provider string                  // the provider that performed this step.
+	Ignores  []string                // the property paths whose changes were ignored (not for creates or deletes).
+	// true if the replacement was explicitly requested rather than caused by a diff (only for CreateStep and
+	// ReplaceStep).
+	ForceReplace bool
+	// the structured property diff reported by the provider, if any (only for UpdateStep, CreateStep, and ReplaceStep).
+	DetailedDiff map[string]plugin.PropertyDiff
 }
 
 type StepEventStateMetadata struct {
@@ -126,6 +135,8 @@ type StepEventStateMetadata struct {
 	Parent resource.URN
 	// true to "protect" this resource (protected resources cannot be deleted).
 	Protect bool
+	// true if this resource is removed from the stack, rather than deleted by its provider, when it is deleted.
+	RetainOnDelete bool
 	// the resource's input properties (as specified by the program). Note: because this will cross
 	// over rpc boundaries it will be slightly different than the Inputs found in resource_state.
 	// Specifically, secrets will have been filtered out, and large values (like assets) will be
@@ -178,23 +189,47 @@ func makeStepEventMetadata(op deploy.StepOp, step deploy.Step, debug bool) StepE
 	contract.Assert(op == step.Op() || step.Op() == deploy.OpRefresh)
 
 	var keys []resource.PropertyKey
+	var ignores []string
+	var detailedDiff map[string]plugin.PropertyDiff
+	var forceReplace bool
 	if step.Op() == deploy.OpCreateReplacement {
 		keys = step.(*deploy.CreateStep).Keys()
+		ignores = step.(*deploy.CreateStep).IgnoreChanges()
+		detailedDiff = step.(*deploy.CreateStep).DetailedDiff()
+		forceReplace = step.(*deploy.CreateStep).ForceReplace()
 	} else if step.Op() == deploy.OpReplace {
 		keys = step.(*deploy.ReplaceStep).Keys()
+		ignores = step.(*deploy.ReplaceStep).IgnoreChanges()
+		detailedDiff = step.(*deploy.ReplaceStep).DetailedDiff()
+		forceReplace = step.(*deploy.ReplaceStep).ForceReplace()
+	} else if step.Op() == deploy.OpUpdate {
+		ignores = step.(*deploy.UpdateStep).IgnoreChanges()
+		detailedDiff = step.(*deploy.UpdateStep).DetailedDiff()
+	} else if step.Op() == deploy.OpSame {
+		ignores = step.(*deploy.SameStep).IgnoreChanges()
 	}
 
-	return StepEventMetadata{
-		Op:       op,
-		URN:      step.URN(),
-		Type:     step.Type(),
-		Keys:     keys,
-		Old:      makeStepEventStateMetadata(step.Old(), debug),
-		New:      makeStepEventStateMetadata(step.New(), debug),
-		Res:      makeStepEventStateMetadata(step.Res(), debug),
-		Logical:  step.Logical(),
-		Provider: step.Provider(),
+	md := StepEventMetadata{
+		Op:           op,
+		URN:          step.URN(),
+		Type:         step.Type(),
+		Keys:         keys,
+		Ignores:      ignores,
+		ForceReplace: forceReplace,
+		DetailedDiff: detailedDiff,
+		Old:          makeStepEventStateMetadata(step.Old(), debug),
+		New:          makeStepEventStateMetadata(step.New(), debug),
+		Res:          makeStepEventStateMetadata(step.Res(), debug),
+		Logical:      step.Logical(),
+		Provider:     step.Provider(),
 	}
+
+	// Whether a deleted resource is retained is decided by its delete step, which may differ from the setting with
+	// which the resource was last deployed if the resource is being replaced.
+	if del, isDelete := step.(*deploy.DeleteStep); isDelete && md.Old != nil {
+		md.Old.RetainOnDelete = del.RetainOnDelete()
+	}
+	return md
 }
 
 func makeStepEventStateMetadata(state *resource.State, debug bool) *StepEventStateMetadata {
@@ -203,17 +238,18 @@ func makeStepEventStateMetadata(state *resource.State, debug bool) *StepEventSta
 	}
 
 	return &StepEventStateMetadata{
-		Type:       state.Type,
-		URN:        state.URN,
-		Custom:     state.Custom,
-		Delete:     state.Delete,
-		ID:         state.ID,
-		Parent:     state.Parent,
-		Protect:    state.Protect,
-		Inputs:     filterPropertyMap(state.Inputs, debug),
-		Outputs:    filterPropertyMap(state.Outputs, debug),
-		Provider:   state.Provider,
-		InitErrors: state.InitErrors,
+		Type:           state.Type,
+		URN:            state.URN,
+		Custom:         state.Custom,
+		Delete:         state.Delete,
+		ID:             state.ID,
+		Parent:         state.Parent,
+		Protect:        state.Protect,
+		RetainOnDelete: state.RetainOnDelete,
+		Inputs:         filterPropertyMap(state.Inputs, debug),
+		Outputs:        filterPropertyMap(state.Outputs, debug),
+		Provider:       state.Provider,
+		InitErrors:     state.InitErrors,
 	}
 }
 
@@ -285,6 +321,10 @@ func filterPropertyMap(propertyMap resource.PropertyMap, debug bool) resource.Pr
 			return resource.Output{
 				Element: filterPropertyValue(t.Element),
 			}
+		case *resource.Secret:
+			return &resource.Secret{
+				Element: filterPropertyValue(t.Element),
+			}
 		}
 
 		// Next, see if it's an array, slice, pointer or struct, and handle each accordingly.
@@ -404,16 +444,18 @@ func (e *eventEmitter) previewSummaryEvent(resourceChanges ResourceChanges) {
 }
 
 func (e *eventEmitter) updateSummaryEvent(maybeCorrupt bool,
-	duration time.Duration, resourceChanges ResourceChanges) {
+	duration time.Duration, resourceChanges ResourceChanges, failed, skipped []resource.URN) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.Chan <- Event{
 		Type: SummaryEvent,
 		Payload: SummaryEventPayload{
-			IsPreview:       false,
-			MaybeCorrupt:    maybeCorrupt,
-			Duration:        duration,
-			ResourceChanges: resourceChanges,
+			IsPreview:        false,
+			MaybeCorrupt:     maybeCorrupt,
+			Duration:         duration,
+			ResourceChanges:  resourceChanges,
+			FailedResources:  failed,
+			SkippedResources: skipped,
 		},
 	}
 }
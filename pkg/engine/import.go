@@ -0,0 +1,89 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"github.com/blang/semver"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// Import adopts the given existing resources into a stack. Each resource's state is read from its provider and
+// recorded in the stack as a managed resource; no other resources in the stack are affected.
+func Import(u UpdateInfo, ctx *Context, opts UpdateOptions, imports []deploy.Import,
+	dryRun bool) (ResourceChanges, error) {
+
+	contract.Require(u != nil, "u")
+	contract.Require(ctx != nil, "ctx")
+
+	defer func() { ctx.Events <- cancelEvent() }()
+
+	info, err := newPlanContext(u, "import", ctx.ParentSpan)
+	if err != nil {
+		return nil, err
+	}
+	defer info.Close()
+
+	emitter, err := makeEventEmitter(ctx.Events, u)
+	if err != nil {
+		return nil, err
+	}
+
+	// Restrict the plan to the imported resources so that the rest of the stack is left untouched.
+	proj, target := u.GetProject(), u.GetTarget()
+	opts.UpdateTargets = nil
+	for _, imp := range imports {
+		opts.UpdateTargets = append(opts.UpdateTargets, resource.NewURN(target.Name, proj.Name, "", imp.Type, imp.Name))
+	}
+
+	return update(ctx, info, planOptions{
+		UpdateOptions: opts,
+		SourceFunc: func(opts planOptions, proj *workspace.Project, pwd, main string,
+			target *deploy.Target, plugctx *plugin.Context, dryRun bool) (deploy.Source, error) {
+
+			return newImportSource(proj, target, imports), nil
+		},
+		Events:     emitter,
+		Diag:       newEventSink(emitter, false),
+		StatusDiag: newEventSink(emitter, true),
+		isImport:   true,
+	}, dryRun)
+}
+
+func newImportSource(proj *workspace.Project, target *deploy.Target, imports []deploy.Import) deploy.Source {
+	// Use the same versions as the stack's existing default providers, if any, so that importing resources does not
+	// cause those providers to be replaced.
+	defaultProviderVersions := make(map[tokens.Package]*semver.Version)
+	if target.Snapshot != nil {
+		for _, res := range target.Snapshot.Resources {
+			if !providers.IsDefaultProvider(res.URN) || res.Delete {
+				continue
+			}
+			if v, ok := res.Inputs["version"]; ok && v.IsString() {
+				if version, err := semver.ParseTolerant(v.StringValue()); err == nil {
+					defaultProviderVersions[tokens.Package(res.Type.Name())] = &version
+				}
+			}
+		}
+	}
+
+	return deploy.NewImportSource(proj.Name, target, defaultProviderVersions, imports)
+}
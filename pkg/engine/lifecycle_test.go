@@ -21,7 +21,9 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/mitchellh/copystructure"
@@ -43,6 +45,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/util/logging"
 	"github.com/pulumi/pulumi/pkg/util/rpcutil/rpcerror"
 	"github.com/pulumi/pulumi/pkg/workspace"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
 )
 
 type JournalEntryKind int
@@ -125,15 +128,15 @@ func (j *Journal) Snap(base *deploy.Snapshot) *deploy.Snapshot {
 				ops = append(ops, resource.NewOperation(e.Step.New(), resource.OperationTypeCreating))
 			case deploy.OpDelete, deploy.OpDeleteReplaced, deploy.OpReadDiscard, deploy.OpDiscardReplaced:
 				ops = append(ops, resource.NewOperation(e.Step.Old(), resource.OperationTypeDeleting))
-			case deploy.OpRead, deploy.OpReadReplacement:
+			case deploy.OpRead, deploy.OpReadReplacement, deploy.OpImport:
 				ops = append(ops, resource.NewOperation(e.Step.New(), resource.OperationTypeReading))
 			case deploy.OpUpdate:
 				ops = append(ops, resource.NewOperation(e.Step.New(), resource.OperationTypeUpdating))
 			}
 		case JournalEntryFailure, JournalEntrySuccess:
 			switch e.Step.Op() {
-			// nolint: lll
-			case deploy.OpCreate, deploy.OpCreateReplacement, deploy.OpRead, deploy.OpReadReplacement, deploy.OpUpdate:
+			case deploy.OpCreate, deploy.OpCreateReplacement, deploy.OpRead, deploy.OpReadReplacement, deploy.OpUpdate,
+				deploy.OpImport:
 				doneOps[e.Step.New()] = true
 			case deploy.OpDelete, deploy.OpDeleteReplaced, deploy.OpReadDiscard, deploy.OpDiscardReplaced:
 				doneOps[e.Step.Old()] = true
@@ -157,7 +160,7 @@ func (j *Journal) Snap(base *deploy.Snapshot) *deploy.Snapshot {
 				}
 			case deploy.OpReplace:
 				// do nothing.
-			case deploy.OpRead, deploy.OpReadReplacement:
+			case deploy.OpRead, deploy.OpReadReplacement, deploy.OpImport:
 				resources = append(resources, e.Step.New())
 				if e.Step.Old() != nil {
 					dones[e.Step.Old()] = true
@@ -294,15 +297,19 @@ func (op TestOp) RunWithContext(callerCtx context.Context, project workspace.Pro
 
 	// Begin draining events.
 	var firedEvents []Event
+	drained := make(chan bool)
 	go func() {
 		for e := range events {
 			firedEvents = append(firedEvents, e)
 		}
+		close(drained)
 	}()
 
 	// Run the step and its validator.
 	_, err := op(info, ctx, opts, dryRun)
 	contract.IgnoreClose(journal)
+	close(events)
+	<-drained
 
 	if dryRun {
 		return nil, err
@@ -334,10 +341,12 @@ type TestPlan struct {
 	Decrypter     config.Decrypter
 	BackendClient deploy.BackendClient
 	Options       UpdateOptions
+	Retry         *workspace.ProjectRetryPolicy
+	Parallel      map[string]int
 	Steps         []TestStep
 }
 
-//nolint: goconst
+// nolint: goconst
 func (p *TestPlan) getNames() (stack tokens.QName, project tokens.PackageName, runtime string) {
 	project = tokens.PackageName(p.Project)
 	if project == "" {
@@ -371,8 +380,10 @@ func (p *TestPlan) GetProject() workspace.Project {
 	_, projectName, runtime := p.getNames()
 
 	return workspace.Project{
-		Name:    projectName,
-		Runtime: workspace.NewProjectRuntimeInfo(runtime, nil),
+		Name:     projectName,
+		Runtime:  workspace.NewProjectRuntimeInfo(runtime, nil),
+		Retry:    p.Retry,
+		Parallel: p.Parallel,
 	}
 }
 
@@ -2134,6 +2145,146 @@ func TestPreviewWithPendingOperations(t *testing.T) {
 	assert.EqualError(t, err, deploy.PlanPendingOperationsError{}.Error())
 }
 
+// Tests that an update with Resume set reconciles the pending operations left behind by an interrupted update.
+func TestResumeWithPendingOperations(t *testing.T) {
+	p := &TestPlan{}
+
+	const resType = "pkgA:m:typA"
+	urnA := p.NewURN(resType, "resA", "")
+	urnB := p.NewURN(resType, "resB", "")
+	urnC := p.NewURN(resType, "resC", "")
+	urnD := p.NewURN(resType, "resD", "")
+
+	newResource := func(urn resource.URN, id resource.ID, delete bool) *resource.State {
+		return &resource.State{
+			Type:    urn.Type(),
+			URN:     urn,
+			Custom:  true,
+			Delete:  delete,
+			ID:      id,
+			Inputs:  resource.PropertyMap{},
+			Outputs: resource.PropertyMap{},
+		}
+	}
+
+	// resA was being updated and resB was being deleted when the update was interrupted.
+	old := &deploy.Snapshot{
+		PendingOperations: []resource.Operation{
+			{Resource: newResource(urnA, "0", false), Type: resource.OperationTypeUpdating},
+			{Resource: newResource(urnB, "1", false), Type: resource.OperationTypeDeleting},
+		},
+		Resources: []*resource.State{
+			newResource(urnA, "0", false),
+			newResource(urnB, "1", false),
+			newResource(urnD, "3", false),
+		},
+	}
+
+	var reads []resource.URN
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				ReadF: func(urn resource.URN, id resource.ID,
+					props resource.PropertyMap) (resource.PropertyMap, resource.Status, error) {
+
+					reads = append(reads, urn)
+					if urn == urnB {
+						// The delete of resB completed before the update was interrupted.
+						return nil, resource.StatusOK, nil
+					}
+					return resource.PropertyMap{"actual": resource.NewStringProperty("read")}, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		for _, name := range []string{"resA", "resC", "resD"} {
+			_, _, _, err := monitor.RegisterResource(resType, name, true, "", false, nil, "", resource.PropertyMap{}, nil, false)
+			assert.NoError(t, err)
+		}
+		return nil
+	})
+
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Resume = true
+	p.Steps = []TestStep{{
+		Op:          Update,
+		SkipPreview: true,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, events []Event, err error) error {
+			// Only the resources with pending updates or deletes should have been read.
+			assert.ElementsMatch(t, []resource.URN{urnA, urnB}, reads)
+			return err
+		},
+	}}
+	snap := p.Run(t, old)
+
+	assert.Len(t, snap.PendingOperations, 0)
+	resources := make(map[resource.URN]*resource.State)
+	for _, r := range snap.Resources {
+		resources[r.URN] = r
+	}
+	assert.Len(t, resources, 4) // resA, resC, resD, and the default provider
+	assert.NotContains(t, resources, urnB)
+	assert.Equal(t, resource.NewStringProperty("read"), resources[urnA].Outputs["actual"])
+	assert.NotContains(t, resources[urnD].Outputs, resource.PropertyKey("actual"))
+	assert.Contains(t, resources, urnC)
+}
+
+// Tests that an update with Resume set refuses to proceed if an interrupted create was left pending, as the create
+// cannot be read from its provider.
+func TestResumeWithPendingCreate(t *testing.T) {
+	p := &TestPlan{}
+
+	const resType = "pkgA:m:typA"
+	urnA := p.NewURN(resType, "resA", "")
+	urnB := p.NewURN(resType, "resB", "")
+
+	old := &deploy.Snapshot{
+		PendingOperations: []resource.Operation{
+			{
+				Resource: &resource.State{Type: resType, URN: urnA, Custom: true, ID: "0"},
+				Type:     resource.OperationTypeUpdating,
+			},
+			{
+				Resource: &resource.State{Type: resType, URN: urnB, Custom: true},
+				Type:     resource.OperationTypeCreating,
+			},
+		},
+		Resources: []*resource.State{
+			{Type: resType, URN: urnA, Custom: true, ID: "0"},
+		},
+	}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		return nil
+	})
+
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Resume = true
+	p.Steps = []TestStep{{
+		Op:            Update,
+		ExpectFailure: true,
+		SkipPreview:   true,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, events []Event, err error) error {
+			// Only the interrupted create should be reported.
+			if pendingErr, ok := err.(deploy.PlanPendingOperationsError); assert.True(t, ok) {
+				if assert.Len(t, pendingErr.Operations, 1) {
+					assert.Equal(t, urnB, pendingErr.Operations[0].Resource.URN)
+				}
+			}
+			return err
+		},
+	}}
+	p.Run(t, old)
+}
+
 // Tests that a failed partial update causes the engine to persist the resource's old inputs and new outputs.
 func TestUpdatePartialFailure(t *testing.T) {
 	loaders := []*deploytest.ProviderLoader{
@@ -2816,3 +2967,1525 @@ func TestExplicitDeleteBeforeReplace(t *testing.T) {
 	}}
 	p.Run(t, snap)
 }
+
+// Tests that a targeted update only changes the targeted resources and carries all others forward unchanged.
+func TestUpdateTarget(t *testing.T) {
+	p := &TestPlan{}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	const resType = "pkgA:m:typA"
+
+	inputs := resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "bar"})
+	createC := false
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource(resType, "resA", true, "", false, nil, "", inputs, nil, false)
+		assert.NoError(t, err)
+
+		_, _, _, err = monitor.RegisterResource(resType, "resB", true, "", false, nil, "", inputs, nil, false)
+		assert.NoError(t, err)
+
+		if createC {
+			_, _, _, err = monitor.RegisterResource(resType, "resC", true, "", false, nil, "", inputs, nil, false)
+			assert.NoError(t, err)
+		}
+		return nil
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+	assert.Len(t, snap.Resources, 3)
+
+	urnA, urnB := p.NewURN(resType, "resA", ""), p.NewURN(resType, "resB", "")
+
+	// Change the inputs of both resources, but only target resA.
+	inputs = resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "baz"})
+	p.Options.UpdateTargets = []resource.URN{urnA}
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal,
+			_ []Event, err error) error {
+
+			for _, entry := range j.Entries {
+				switch urn := entry.Step.URN(); urn {
+				case urnA:
+					assert.Equal(t, deploy.OpUpdate, entry.Step.Op())
+				case urnB:
+					assert.Equal(t, deploy.OpSame, entry.Step.Op())
+				}
+			}
+			return err
+		},
+	}}
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 3)
+	for _, res := range snap.Resources {
+		switch res.URN {
+		case urnA:
+			assert.Equal(t, "baz", res.Inputs["foo"].StringValue())
+		case urnB:
+			assert.Equal(t, "bar", res.Inputs["foo"].StringValue())
+		}
+	}
+
+	// Creating a resource that was not targeted must fail.
+	createC = true
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true}}
+	p.Run(t, snap)
+
+	// ...unless it is targeted.
+	p.Options.UpdateTargets = append(p.Options.UpdateTargets, p.NewURN(resType, "resC", ""))
+	p.Steps = []TestStep{{Op: Update}}
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 4)
+}
+
+// Tests that a targeted destroy only deletes the targeted resources and fails if it would orphan a dependent.
+func TestDestroyTarget(t *testing.T) {
+	p := &TestPlan{}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	const resType = "pkgA:m:typA"
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		urnA, _, _, err := monitor.RegisterResource(resType, "resA", true, "", false, nil, "", nil, nil, false)
+		assert.NoError(t, err)
+
+		_, _, _, err = monitor.RegisterResource(resType, "resB", true, "", false, []resource.URN{urnA}, "", nil, nil, false)
+		assert.NoError(t, err)
+
+		_, _, _, err = monitor.RegisterResource(resType, "resC", true, "", false, nil, "", nil, nil, false)
+		assert.NoError(t, err)
+		return nil
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+	assert.Len(t, snap.Resources, 4)
+
+	urnA, urnB, urnC := p.NewURN(resType, "resA", ""), p.NewURN(resType, "resB", ""), p.NewURN(resType, "resC", "")
+
+	// Destroying resA alone must fail, as resB depends upon it.
+	p.Options.UpdateTargets = []resource.URN{urnA}
+	p.Steps = []TestStep{{Op: Destroy, ExpectFailure: true}}
+	p.Run(t, snap)
+
+	// Destroying resA and its dependents must succeed and leave resC and the default provider alone.
+	p.Options.TargetDependents = true
+	p.Steps = []TestStep{{
+		Op: Destroy,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal,
+			_ []Event, err error) error {
+
+			deleted := make(map[resource.URN]bool)
+			for _, entry := range j.Entries {
+				assert.Equal(t, deploy.OpDelete, entry.Step.Op())
+				deleted[entry.Step.URN()] = true
+			}
+			assert.Equal(t, map[resource.URN]bool{urnA: true, urnB: true}, deleted)
+			return err
+		},
+	}}
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 2)
+	assert.Equal(t, urnC, snap.Resources[1].URN)
+}
+
+// Tests that resources marked for replacement are replaced even if their inputs have not changed.
+func TestReplaceTarget(t *testing.T) {
+	p := &TestPlan{}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	const resType = "pkgA:m:typA"
+
+	dbrA := false
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		urnA, _, _, err := monitor.RegisterResource(resType, "resA", true, "", false, nil, "", nil, nil, dbrA)
+		assert.NoError(t, err)
+
+		_, _, _, err = monitor.RegisterResource(resType, "resB", true, "", false, []resource.URN{urnA}, "", nil, nil, false)
+		assert.NoError(t, err)
+		return nil
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+	assert.Len(t, snap.Resources, 3)
+
+	urnA, urnB := p.NewURN(resType, "resA", ""), p.NewURN(resType, "resB", "")
+	p.Options.ReplaceTargets = []resource.URN{urnA}
+
+	validate := func(expected []deploy.StepOp) ValidateFunc {
+		return func(project workspace.Project, target deploy.Target, j *Journal, _ []Event, err error) error {
+			var opsA []deploy.StepOp
+			for _, entry := range j.Entries {
+				if entry.Kind != JournalEntrySuccess {
+					continue
+				}
+				switch urn := entry.Step.URN(); urn {
+				case urnA:
+					opsA = append(opsA, entry.Step.Op())
+					switch step := entry.Step.(type) {
+					case *deploy.CreateStep:
+						assert.True(t, step.ForceReplace())
+						assert.Empty(t, step.Keys())
+					case *deploy.ReplaceStep:
+						assert.True(t, step.ForceReplace())
+						assert.Empty(t, step.Keys())
+					}
+				case urnB:
+					assert.Equal(t, deploy.OpSame, entry.Step.Op())
+				}
+			}
+			assert.Equal(t, expected, opsA)
+			return err
+		}
+	}
+
+	// By default, the replacement should create the new resource before deleting the old one.
+	p.Steps = []TestStep{{
+		Op:       Update,
+		Validate: validate([]deploy.StepOp{deploy.OpCreateReplacement, deploy.OpReplace, deploy.OpDeleteReplaced}),
+	}}
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 3)
+
+	// If the resource is delete-before-replace, the old resource should be deleted first.
+	dbrA = true
+	p.Steps = []TestStep{{
+		Op:       Update,
+		Validate: validate([]deploy.StepOp{deploy.OpDeleteReplaced, deploy.OpReplace, deploy.OpCreateReplacement}),
+	}}
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 3)
+}
+
+// Tests that resources whose URNs change are matched to their old state via their aliases rather than being replaced.
+func TestAliases(t *testing.T) {
+	p := &TestPlan{}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	const resType = "pkgA:m:typA"
+
+	type registration struct {
+		name    string
+		parent  string
+		aliases []resource.URN
+	}
+	var registrations []registration
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		urns := make(map[string]resource.URN)
+		for _, r := range registrations {
+			urn, _, _, err := monitor.RegisterResourceWithOptions(resType, r.name, true, deploytest.ResourceOptions{
+				Parent:  urns[r.parent],
+				Aliases: r.aliases,
+			})
+			assert.NoError(t, err)
+			urns[r.name] = urn
+		}
+		return nil
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	// Only same steps are expected when resources are renamed or re-parented using aliases.
+	onlySames := func(project workspace.Project, target deploy.Target, j *Journal, _ []Event, err error) error {
+		for _, entry := range j.Entries {
+			if entry.Step.Op() != deploy.OpSame {
+				t.Errorf("unexpected %v step for '%v'", entry.Step.Op(), entry.Step.URN())
+			}
+		}
+		return err
+	}
+
+	registrations = []registration{{name: "resA"}, {name: "resB", parent: "resA"}}
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+	assert.Len(t, snap.Resources, 3)
+
+	// Rename resA.
+	registrations = []registration{
+		{name: "resA2", aliases: []resource.URN{p.NewURN(resType, "resA", "")}},
+		{name: "resB", parent: "resA2"},
+	}
+	p.Steps = []TestStep{{Op: Update, Validate: onlySames}}
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 3)
+	urnA2 := p.NewURN(resType, "resA2", "")
+	for _, res := range snap.Resources {
+		switch res.URN {
+		case urnA2:
+		case p.NewURN(resType, "resB", urnA2):
+			assert.Equal(t, urnA2, res.Parent)
+		default:
+			assert.True(t, providers.IsDefaultProvider(res.URN), "unexpected resource %v", res.URN)
+		}
+	}
+
+	// Move resB out from under resA2.
+	registrations = []registration{
+		{name: "resA2"},
+		{name: "resB", aliases: []resource.URN{p.NewURN(resType, "resB", urnA2)}},
+	}
+	p.Steps = []TestStep{{Op: Update, Validate: onlySames}}
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 3)
+
+	// Two resources may not claim the same alias.
+	registrations = []registration{
+		{name: "resA2"},
+		{name: "resB"},
+		{name: "resC", aliases: []resource.URN{p.NewURN(resType, "resA2", "")}},
+	}
+	p.Steps = []TestStep{{Op: Update, Validate: func(project workspace.Project, target deploy.Target, j *Journal,
+		_ []Event, err error) error {
+
+		for _, entry := range j.Entries {
+			if entry.Step.URN() == p.NewURN(resType, "resC", "") {
+				assert.Equal(t, deploy.OpCreate, entry.Step.Op())
+			}
+		}
+		return err
+	}}}
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 4)
+}
+
+// Tests that a change to a property listed in a resource's replaceOnChanges option forces the resource to be replaced
+// even if its provider would update it in place.
+func TestReplaceOnChanges(t *testing.T) {
+	p := &TestPlan{}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap) (plugin.DiffResult, error) {
+
+					// The provider is able to update every property in place.
+					if !olds.DeepEquals(news) {
+						return plugin.DiffResult{Changes: plugin.DiffSome}, nil
+					}
+					return plugin.DiffResult{Changes: plugin.DiffNone}, nil
+				},
+			}, nil
+		}),
+	}
+
+	const resType = "pkgA:index:typ"
+
+	inputsA := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"engine": map[string]interface{}{"version": "1"},
+		"size":   1,
+	})
+	dbrA := false
+	replaceOnChangesA := []string{"engine.version"}
+	inputsB := resource.NewPropertyMapFromMap(map[string]interface{}{"A": "foo"})
+
+	var provURN, urnA, urnB resource.URN
+	var provID resource.ID
+	var err error
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		provURN, provID, _, err = monitor.RegisterResource(
+			providers.MakeProviderType("pkgA"), "provA", true, "", false, nil, "", nil, nil, false)
+		assert.NoError(t, err)
+
+		if provID == "" {
+			provID = providers.UnknownID
+		}
+		provRef, err := providers.NewReference(provURN, provID)
+		assert.NoError(t, err)
+		provA := provRef.String()
+
+		urnA, _, _, err = monitor.RegisterResourceWithOptions(resType, "resA", true, deploytest.ResourceOptions{
+			Provider:            provA,
+			Inputs:              inputsA,
+			DeleteBeforeReplace: dbrA,
+			ReplaceOnChanges:    replaceOnChangesA,
+		})
+		if err != nil {
+			return err
+		}
+
+		urnB, _, _, err = monitor.RegisterResourceWithOptions(resType, "resB", true, deploytest.ResourceOptions{
+			Dependencies:     []resource.URN{urnA},
+			Provider:         provA,
+			Inputs:           inputsB,
+			PropertyDeps:     map[resource.PropertyKey][]resource.URN{"A": {urnA}},
+			ReplaceOnChanges: []string{"A"},
+		})
+		assert.NoError(t, err)
+
+		return nil
+	})
+
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+
+	// Change the value of resA.size. Only resA should be updated, and the update should happen in place.
+	inputsA["size"] = resource.NewNumberProperty(2)
+	p.Steps = []TestStep{{
+		Op: Update,
+
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, evts []Event, err error) error {
+			assert.NoError(t, err)
+
+			AssertSameSteps(t, []StepSummary{
+				{Op: deploy.OpSame, URN: provURN},
+				{Op: deploy.OpUpdate, URN: urnA},
+				{Op: deploy.OpSame, URN: urnB},
+			}, j.SuccessfulSteps())
+
+			return err
+		},
+	}}
+	snap = p.Run(t, snap)
+
+	// Change the value of resA.engine.version. Only resA should be replaced, and the replacement should be
+	// create-before-delete.
+	inputsA["engine"] = resource.NewObjectProperty(resource.NewPropertyMapFromMap(map[string]interface{}{
+		"version": "2",
+	}))
+	p.Steps = []TestStep{{
+		Op: Update,
+
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, evts []Event, err error) error {
+			assert.NoError(t, err)
+
+			AssertSameSteps(t, []StepSummary{
+				{Op: deploy.OpSame, URN: provURN},
+				{Op: deploy.OpCreateReplacement, URN: urnA},
+				{Op: deploy.OpReplace, URN: urnA},
+				{Op: deploy.OpSame, URN: urnB},
+				{Op: deploy.OpDeleteReplaced, URN: urnA},
+			}, j.SuccessfulSteps())
+
+			for _, entry := range j.Entries {
+				if entry.Step.URN() == urnA && entry.Step.Op() == deploy.OpReplace {
+					assert.Equal(t, []resource.PropertyKey{"engine"}, entry.Step.(*deploy.ReplaceStep).Keys())
+				}
+			}
+
+			return err
+		},
+	}}
+	snap = p.Run(t, snap)
+
+	// Change the registration of resA such that it requires delete-before-replace and change the value of
+	// resA.engine.version. Although its provider could update it in place, resB must also be replaced, as resB.A
+	// depends on resA and forces resB's replacement.
+	dbrA = true
+	inputsA["engine"] = resource.NewObjectProperty(resource.NewPropertyMapFromMap(map[string]interface{}{
+		"version": "3",
+	}))
+	p.Steps = []TestStep{{
+		Op: Update,
+
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, evts []Event, err error) error {
+			assert.NoError(t, err)
+
+			AssertSameSteps(t, []StepSummary{
+				{Op: deploy.OpSame, URN: provURN},
+				{Op: deploy.OpDeleteReplaced, URN: urnB},
+				{Op: deploy.OpDeleteReplaced, URN: urnA},
+				{Op: deploy.OpReplace, URN: urnA},
+				{Op: deploy.OpCreateReplacement, URN: urnA},
+				{Op: deploy.OpReplace, URN: urnB},
+				{Op: deploy.OpCreateReplacement, URN: urnB},
+			}, j.SuccessfulSteps())
+
+			return err
+		},
+	}}
+	snap = p.Run(t, snap)
+
+	// An invalid property path should cause the update to fail.
+	replaceOnChangesA = []string{"[0]"}
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true}}
+	p.Run(t, snap)
+}
+
+func TestIgnoreChanges(t *testing.T) {
+	p := &TestPlan{}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	var inputs resource.PropertyMap
+	var ignoreChanges []string
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResourceWithOptions("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs:        inputs,
+			IgnoreChanges: ignoreChanges,
+		})
+		assert.NoError(t, err)
+		return nil
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	resURN := p.NewURN("pkgA:m:typA", "resA", "")
+	expectOp := func(op deploy.StepOp) ValidateFunc {
+		return func(project workspace.Project, target deploy.Target, j *Journal, events []Event, err error) error {
+			for _, entry := range j.Entries {
+				if entry.Step.URN() == resURN {
+					assert.Equal(t, op, entry.Step.Op())
+				}
+			}
+
+			// The ignored properties should be reported for sames as well as updates.
+			for _, e := range events {
+				if e.Type == ResourcePreEvent {
+					if md := e.Payload.(ResourcePreEventPayload).Metadata; md.URN == resURN {
+						assert.Equal(t, ignoreChanges, md.Ignores)
+					}
+				}
+			}
+			return err
+		}
+	}
+	resInputs := func(snap *deploy.Snapshot) resource.PropertyMap {
+		for _, res := range snap.Resources {
+			if res.URN == resURN {
+				return res.Inputs
+			}
+		}
+		t.Fatalf("missing resource %v", resURN)
+		return nil
+	}
+
+	inputs = resource.NewPropertyMapFromMap(map[string]interface{}{
+		"tags":  map[string]interface{}{"owner": "alice", "team": "infra"},
+		"ports": []interface{}{80, 443},
+		"count": 1,
+	})
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+
+	// Changes to ignored nested properties and array elements should not cause an update.
+	inputs = resource.NewPropertyMapFromMap(map[string]interface{}{
+		"tags":  map[string]interface{}{"owner": "bob", "team": "infra"},
+		"ports": []interface{}{80, 8443},
+		"count": 1,
+	})
+	ignoreChanges = []string{"tags.owner", "ports[1]"}
+	p.Steps = []TestStep{{Op: Update, Validate: expectOp(deploy.OpSame)}}
+	snap = p.Run(t, snap)
+	assert.Equal(t, "alice", resInputs(snap)["tags"].ObjectValue()["owner"].StringValue())
+	assert.Equal(t, float64(443), resInputs(snap)["ports"].ArrayValue()[1].NumberValue())
+
+	// Changes to other properties should cause an update that retains the old values of the ignored properties.
+	inputs["count"] = resource.NewNumberProperty(2)
+	p.Steps = []TestStep{{Op: Update, Validate: expectOp(deploy.OpUpdate)}}
+	snap = p.Run(t, snap)
+	assert.Equal(t, "alice", resInputs(snap)["tags"].ObjectValue()["owner"].StringValue())
+	assert.Equal(t, float64(2), resInputs(snap)["count"].NumberValue())
+
+	// Invalid paths should cause an error.
+	ignoreChanges = []string{"tags["}
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true, SkipPreview: true}}
+	p.Run(t, snap)
+
+	// Paths that cannot be reset because an element is missing from the new inputs should cause an error.
+	delete(inputs, "tags")
+	ignoreChanges = []string{"tags.owner"}
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true, SkipPreview: true}}
+	p.Run(t, snap)
+}
+
+func TestCustomTimeouts(t *testing.T) {
+	p := &TestPlan{}
+
+	// A provider that times out gives up on the operation once its deadline has passed. A provider that hangs ignores
+	// its deadline and does not complete the operation until it is unblocked.
+	var timeOut bool
+	var hang chan struct{}
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN,
+					news resource.PropertyMap) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					if timeOut {
+						return "", nil, resource.StatusUnknown, rpcerror.New(codes.DeadlineExceeded, "deadline exceeded")
+					}
+					if hang != nil {
+						<-hang
+					}
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	var timeouts *pulumirpc.RegisterResourceRequest_CustomTimeouts
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResourceWithOptions("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			CustomTimeouts: timeouts,
+		})
+		return err
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	// A create that does not complete within its timeout should fail, and its operation should be left pending, as it
+	// may still be in flight.
+	expectTimeout := func(project workspace.Project, target deploy.Target, j *Journal, evts []Event, err error) error {
+		sawTimeout := false
+		for _, evt := range evts {
+			if evt.Type == DiagEvent {
+				e := evt.Payload.(DiagEventPayload)
+				msg := colors.Never.Colorize(e.Message)
+				sawTimeout = sawTimeout || strings.Contains(msg, "timed out after 10ms") && e.Severity == diag.Error
+			}
+		}
+		assert.True(t, sawTimeout)
+
+		snap := j.Snap(nil)
+		if assert.Len(t, snap.PendingOperations, 1) {
+			assert.Equal(t, resource.OperationTypeCreating, snap.PendingOperations[0].Type)
+		}
+		return err
+	}
+	timeOut = true
+	timeouts = &pulumirpc.RegisterResourceRequest_CustomTimeouts{Create: "10ms"}
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true, SkipPreview: true, Validate: expectTimeout}}
+	p.Run(t, nil)
+
+	// The engine should enforce the timeout even if the provider ignores its deadline.
+	timeOut, hang = false, make(chan struct{})
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true, SkipPreview: true, Validate: expectTimeout}}
+	p.Run(t, nil)
+	close(hang)
+	hang = nil
+
+	// A create that completes within its timeout should succeed, and the timeouts should be recorded in the snapshot.
+	timeOut = false
+	timeouts = &pulumirpc.RegisterResourceRequest_CustomTimeouts{Create: "1m", Delete: "1h"}
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+	for _, res := range snap.Resources {
+		if res.URN == p.NewURN("pkgA:m:typA", "resA", "") {
+			assert.Equal(t, resource.CustomTimeouts{Create: 60, Delete: 3600}, res.CustomTimeouts)
+		}
+	}
+
+	// Invalid timeouts should cause an error.
+	timeouts = &pulumirpc.RegisterResourceRequest_CustomTimeouts{Update: "soon"}
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true, SkipPreview: true}}
+	p.Run(t, snap)
+}
+
+func TestSecrets(t *testing.T) {
+	p := &TestPlan{}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN,
+					news resource.PropertyMap) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		inputs := resource.PropertyMap{
+			"password": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+			"username": resource.NewStringProperty("admin"),
+		}
+		_, _, outs, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "", inputs, nil, false)
+		assert.NoError(t, err)
+
+		// The language host receives the plain values of secrets.
+		assert.Equal(t, resource.NewStringProperty("hunter2"), outs["password"])
+		return nil
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	resURN := p.NewURN("pkgA:m:typA", "resA", "")
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, evts []Event, err error) error {
+			// Secret values must never be rendered in the display.
+			for _, e := range evts {
+				if e.Type == ResourcePreEvent {
+					p := e.Payload.(ResourcePreEventPayload)
+					if p.Metadata.URN == resURN {
+						summary := GetResourcePropertiesSummary(p.Metadata, 0)
+						assert.NotContains(t, summary, "hunter2")
+					}
+				}
+			}
+			return err
+		},
+	}}
+	snap := p.Run(t, nil)
+
+	for _, res := range snap.Resources {
+		if res.URN == resURN {
+			assert.True(t, res.Inputs["password"].IsSecret())
+			assert.False(t, res.Inputs["username"].IsSecret())
+			assert.True(t, res.Outputs["password"].IsSecret())
+			assert.Equal(t, "hunter2", res.Outputs["password"].SecretValue().Element.StringValue())
+		}
+	}
+}
+
+func TestImport(t *testing.T) {
+	p := &TestPlan{}
+
+	readOutputs := resource.PropertyMap{
+		"foo": resource.NewStringProperty("bar"),
+		"arn": resource.NewStringProperty("arn:existing-id"),
+	}
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap) (plugin.DiffResult, error) {
+
+					if olds["foo"].DeepEquals(news["foo"]) {
+						return plugin.DiffResult{Changes: plugin.DiffNone}, nil
+					}
+					return plugin.DiffResult{Changes: plugin.DiffSome}, nil
+				},
+				CreateF: func(urn resource.URN,
+					news resource.PropertyMap) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					assert.Fail(t, "imported resources must not be created")
+					return "", nil, resource.StatusOK, errors.New("unexpected create")
+				},
+				ReadF: func(urn resource.URN, id resource.ID,
+					props resource.PropertyMap) (resource.PropertyMap, resource.Status, error) {
+
+					if id != "existing-id" {
+						return nil, resource.StatusOK, nil
+					}
+					return readOutputs, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	inputs := resource.PropertyMap{"foo": resource.NewStringProperty("bar")}
+	importID := resource.ID("existing-id")
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResourceWithOptions("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs:   inputs,
+			ImportID: importID,
+		})
+		return err
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	resURN := p.NewURN("pkgA:m:typA", "resA", "")
+
+	// Importing a resource that does not exist should fail.
+	importID = "missing-id"
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true}}
+	p.Run(t, nil)
+
+	// Importing a resource whose inputs do not match its current state should fail.
+	importID, inputs = "existing-id", resource.PropertyMap{"foo": resource.NewStringProperty("baz")}
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true}}
+	p.Run(t, nil)
+
+	// Importing a resource whose inputs match its current state should succeed, and should record the resource as a
+	// managed resource.
+	inputs = resource.PropertyMap{"foo": resource.NewStringProperty("bar")}
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, evts []Event, err error) error {
+			for _, entry := range j.Entries {
+				if entry.Step.URN() == resURN {
+					assert.Equal(t, deploy.OpImport, entry.Step.Op())
+				}
+			}
+			return err
+		},
+	}}
+	snap := p.Run(t, nil)
+	for _, res := range snap.Resources {
+		if res.URN == resURN {
+			assert.Equal(t, resource.ID("existing-id"), res.ID)
+			assert.False(t, res.External)
+			assert.Equal(t, inputs, res.Inputs)
+			assert.Equal(t, readOutputs, res.Outputs)
+		}
+	}
+
+	// Once the resource has been imported, subsequent updates should treat it like any other resource.
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, evts []Event, err error) error {
+			for _, entry := range j.Entries {
+				if entry.Step.URN() == resURN {
+					assert.Equal(t, deploy.OpSame, entry.Step.Op())
+				}
+			}
+			return err
+		},
+	}}
+	p.Run(t, snap)
+}
+
+func TestImportSource(t *testing.T) {
+	p := &TestPlan{}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				ReadF: func(urn resource.URN, id resource.ID,
+					props resource.PropertyMap) (resource.PropertyMap, resource.Status, error) {
+
+					if id == "missing-id" {
+						return nil, resource.StatusOK, nil
+					}
+					return resource.PropertyMap{"id": resource.NewStringProperty(string(id))}, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "", nil, nil, false)
+		return err
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	// Create a stack with a single resource.
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+
+	importOp := func(imports []deploy.Import) TestOp {
+		return func(u UpdateInfo, ctx *Context, opts UpdateOptions, dryRun bool) (ResourceChanges, error) {
+			return Import(u, ctx, opts, imports, dryRun)
+		}
+	}
+
+	// Importing a resource that does not exist should fail.
+	p.Steps = []TestStep{{
+		Op:            importOp([]deploy.Import{{Type: "pkgA:m:typA", Name: "resB", ID: "missing-id"}}),
+		ExpectFailure: true,
+	}}
+	p.Run(t, snap)
+
+	// Importing a resource with the same URN as an existing resource should fail.
+	p.Steps = []TestStep{{
+		Op:            importOp([]deploy.Import{{Type: "pkgA:m:typA", Name: "resA", ID: "id-a"}}),
+		ExpectFailure: true,
+	}}
+	p.Run(t, snap)
+
+	// Importing several resources should add them to the stack without affecting the existing resource.
+	p.Steps = []TestStep{{
+		Op: importOp([]deploy.Import{
+			{Type: "pkgA:m:typA", Name: "resB", ID: "id-b"},
+			{Type: "pkgA:m:typA", Name: "resC", ID: "id-c"},
+		}),
+	}}
+	snap = p.Run(t, snap)
+
+	ids := make(map[resource.URN]resource.ID)
+	for _, res := range snap.Resources {
+		if !providers.IsProviderType(res.Type) {
+			assert.False(t, res.External)
+			ids[res.URN] = res.ID
+		}
+	}
+	assert.Len(t, ids, 3)
+	assert.Equal(t, resource.ID("id-b"), ids[p.NewURN("pkgA:m:typA", "resB", "")])
+	assert.Equal(t, resource.ID("id-c"), ids[p.NewURN("pkgA:m:typA", "resC", "")])
+	for _, res := range snap.Resources {
+		if res.URN == p.NewURN("pkgA:m:typA", "resB", "") {
+			assert.Equal(t, resource.PropertyMap{"id": resource.NewStringProperty("id-b")}, res.Inputs)
+		}
+	}
+}
+
+func TestDetailedDiff(t *testing.T) {
+	p := &TestPlan{}
+
+	var detailedDiff map[string]plugin.PropertyDiff
+	var replaceKeys []resource.PropertyKey
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap) (plugin.DiffResult, error) {
+
+					return plugin.DiffResult{
+						Changes:      plugin.DiffSome,
+						ReplaceKeys:  replaceKeys,
+						DetailedDiff: detailedDiff,
+					}, nil
+				},
+			}, nil
+		}),
+	}
+
+	var inputs resource.PropertyMap
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "", inputs, nil, false)
+		assert.NoError(t, err)
+		return nil
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	resURN := p.NewURN("pkgA:m:typA", "resA", "")
+	validateDiff := func(expectedOps ...deploy.StepOp) ValidateFunc {
+		return func(project workspace.Project, target deploy.Target, j *Journal, evts []Event, err error) error {
+			var ops []deploy.StepOp
+			for _, e := range evts {
+				if e.Type != ResourcePreEvent {
+					continue
+				}
+				md := e.Payload.(ResourcePreEventPayload).Metadata
+				if md.URN != resURN || md.Op == deploy.OpDeleteReplaced {
+					continue
+				}
+				ops = append(ops, md.Op)
+				assert.Equal(t, detailedDiff, md.DetailedDiff)
+
+				// Only the changes reported by the provider should be displayed as changes.
+				diff := TranslateDetailedDiff(md)
+				assert.Len(t, diff.Adds, 0)
+				assert.Len(t, diff.Deletes, 0)
+				assert.Len(t, diff.Updates, 1)
+				assert.Contains(t, diff.Sames, resource.PropertyKey("count"))
+				tags := diff.Updates["tags"]
+				if assert.NotNil(t, tags.Object) {
+					assert.Equal(t, resource.NewStringProperty("bob"), tags.Object.Adds["owner"])
+					assert.Equal(t, resource.NewStringProperty("infra"), tags.Object.Sames["team"])
+				}
+			}
+			assert.Equal(t, expectedOps, ops)
+			return err
+		}
+	}
+
+	inputs = resource.NewPropertyMapFromMap(map[string]interface{}{
+		"tags":  map[string]interface{}{"team": "infra"},
+		"count": 1,
+	})
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+
+	// The provider reports that only the added tag has changed, even though the count has also changed.
+	inputs = resource.NewPropertyMapFromMap(map[string]interface{}{
+		"tags":  map[string]interface{}{"owner": "bob", "team": "infra"},
+		"count": 2,
+	})
+	detailedDiff = map[string]plugin.PropertyDiff{
+		"tags.owner": {Kind: plugin.DiffAdd, InputDiff: true},
+	}
+	p.Steps = []TestStep{{Op: Update, Validate: validateDiff(deploy.OpUpdate)}}
+	p.Run(t, snap)
+
+	// The detailed diff should be attached to replacements as well.
+	detailedDiff = map[string]plugin.PropertyDiff{
+		"tags.owner": {Kind: plugin.DiffAddReplace},
+	}
+	replaceKeys = []resource.PropertyKey{"tags"}
+	p.Steps = []TestStep{{Op: Update, Validate: validateDiff(deploy.OpCreateReplacement, deploy.OpReplace)}}
+	p.Run(t, snap)
+}
+
+func TestUpdatePlan(t *testing.T) {
+	p := &TestPlan{}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	var inputs resource.PropertyMap
+	createB := false
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "", inputs, nil, false)
+		assert.NoError(t, err)
+
+		if createB {
+			_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true, "", false, nil, "", nil, nil, false)
+			assert.NoError(t, err)
+		}
+		return nil
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	project := p.GetProject()
+
+	inputs = resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "bar"})
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+
+	// Record a plan that updates resA.
+	inputs = resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "baz"})
+	plan := deploy.NewUpdatePlan()
+	opts := p.Options
+	opts.RecordPlan = plan
+	_, err := TestOp(Update).Run(project, p.GetTarget(CloneSnapshot(t, snap)), opts, true, p.BackendClient, nil)
+	assert.NoError(t, err)
+
+	resURN := p.NewURN("pkgA:m:typA", "resA", "")
+	if assert.Contains(t, plan.ResourcePlans, resURN) {
+		assert.Equal(t, []deploy.StepOp{deploy.OpUpdate}, plan.ResourcePlans[resURN].Ops)
+		assert.Equal(t, inputs, plan.ResourcePlans[resURN].Inputs)
+	}
+
+	// An update whose inputs differ from the plan should fail.
+	p.Options.UpdatePlan = plan
+	inputs = resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "qux"})
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true}}
+	p.Run(t, CloneSnapshot(t, snap))
+
+	// An update that creates a resource that is not in the plan should fail.
+	inputs, createB = resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "baz"}), true
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true}}
+	p.Run(t, CloneSnapshot(t, snap))
+
+	// An update that matches the plan should succeed.
+	createB = false
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, _ []Event, err error) error {
+			for _, entry := range j.Entries {
+				if entry.Step.URN() == resURN {
+					assert.Equal(t, deploy.OpUpdate, entry.Step.Op())
+				}
+			}
+			return err
+		},
+	}}
+	p.Run(t, snap)
+}
+
+func TestRetainOnDelete(t *testing.T) {
+	p := &TestPlan{}
+
+	var deleted []resource.URN
+	replace := false
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap) (plugin.DiffResult, error) {
+
+					if replace {
+						return plugin.DiffResult{Changes: plugin.DiffSome, ReplaceKeys: []resource.PropertyKey{"foo"}}, nil
+					}
+					return plugin.DiffResult{}, nil
+				},
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap) (resource.Status, error) {
+					deleted = append(deleted, urn)
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	createA, retain, deleteBeforeReplace, inputs := true, true, false, resource.PropertyMap{}
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		if createA {
+			_, _, _, err := monitor.RegisterResourceWithOptions("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+				Inputs:              inputs,
+				RetainOnDelete:      retain,
+				DeleteBeforeReplace: deleteBeforeReplace,
+			})
+			assert.NoError(t, err)
+		}
+		return nil
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	resURN := p.NewURN("pkgA:m:typA", "resA", "")
+	validate := func(expectedOp deploy.StepOp) ValidateFunc {
+		return func(project workspace.Project, target deploy.Target, j *Journal, evts []Event, err error) error {
+			found := false
+			for _, entry := range j.Entries {
+				if entry.Step.URN() == resURN && entry.Step.Op() == expectedOp {
+					found = true
+				}
+			}
+			assert.True(t, found)
+			assert.Len(t, deleted, 0)
+			return err
+		}
+	}
+
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+	assert.True(t, snap.Resources[1].RetainOnDelete)
+
+	// Replacing the resource should not delete the original, either after or before creating its replacement.
+	replace, inputs = true, resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "bar"})
+	p.Steps = []TestStep{{Op: Update, Validate: validate(deploy.OpDeleteReplaced)}}
+	snap = p.Run(t, snap)
+	deleteBeforeReplace, inputs = true, resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "baz"})
+	p.Steps = []TestStep{{Op: Update, Validate: validate(deploy.OpDeleteReplaced)}}
+	snap = p.Run(t, snap)
+
+	// Removing the resource from the program should remove it from the stack without deleting it.
+	replace, createA = false, false
+	p.Steps = []TestStep{{Op: Update, Validate: validate(deploy.OpDelete)}}
+	snap = p.Run(t, snap)
+	for _, res := range snap.Resources {
+		assert.NotEqual(t, resURN, res.URN)
+	}
+
+	// Replacing a resource follows the program's current setting, without changing the state of the original.
+	createA = true
+	p.Steps = []TestStep{{Op: Update}}
+	snap = p.Run(t, snap)
+	retain, deleteBeforeReplace = false, false
+	replace, inputs = true, resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "qux"})
+	p.Steps = []TestStep{{Op: Update}}
+	p.Run(t, snap)
+	assert.Equal(t, []resource.URN{resURN}, deleted)
+	assert.True(t, snap.Resources[1].RetainOnDelete)
+}
+
+func TestContinueOnError(t *testing.T) {
+	p := &TestPlan{}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN,
+					news resource.PropertyMap) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					if urn.Name() == "resA" {
+						return "", nil, resource.StatusOK, errors.New("oh no")
+					}
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		resA, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "", nil, nil, false)
+		assert.NoError(t, err)
+
+		// resB depends on resA, and resD depends on resB: both should be skipped. resC is independent of resA, and
+		// should be created.
+		resB, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resB", true, "", false, []resource.URN{resA}, "", nil,
+			nil, false)
+		assert.NoError(t, err)
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resC", true, "", false, nil, "", nil, nil, false)
+		assert.NoError(t, err)
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resD", true, "", false, []resource.URN{resB}, "", nil, nil,
+			false)
+		assert.NoError(t, err)
+		return nil
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.ContinueOnError = true
+
+	urnA, urnB := p.NewURN("pkgA:m:typA", "resA", ""), p.NewURN("pkgA:m:typA", "resB", "")
+	urnC, urnD := p.NewURN("pkgA:m:typA", "resC", ""), p.NewURN("pkgA:m:typA", "resD", "")
+	p.Steps = []TestStep{{
+		Op:            Update,
+		ExpectFailure: true,
+		SkipPreview:   true,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, evts []Event, err error) error {
+			var summary *SummaryEventPayload
+			for _, evt := range evts {
+				if evt.Type == SummaryEvent {
+					payload := evt.Payload.(SummaryEventPayload)
+					summary = &payload
+				}
+			}
+			if assert.NotNil(t, summary) {
+				assert.Equal(t, []resource.URN{urnA}, summary.FailedResources)
+				assert.Equal(t, []resource.URN{urnB, urnD}, summary.SkippedResources)
+			}
+			return err
+		},
+	}}
+	snap := p.Run(t, nil)
+
+	urns := make(map[resource.URN]bool)
+	for _, res := range snap.Resources {
+		urns[res.URN] = true
+	}
+	assert.False(t, urns[urnA])
+	assert.False(t, urns[urnB])
+	assert.True(t, urns[urnC])
+	assert.False(t, urns[urnD])
+}
+
+func TestRetryPolicy(t *testing.T) {
+	p := &TestPlan{}
+
+	// Providers may be called concurrently, so the number of attempts is updated atomically.
+	var attempts int32
+	failures, code := int32(0), codes.Unavailable
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN,
+					news resource.PropertyMap) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					if atomic.AddInt32(&attempts, 1) <= failures {
+						return "", nil, resource.StatusOK, rpcerror.New(code, "try again later")
+					}
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	var retryPolicy *pulumirpc.RegisterResourceRequest_RetryPolicy
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResourceWithOptions("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			RetryPolicy: retryPolicy,
+		})
+		return err
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Retry = &workspace.ProjectRetryPolicy{MaxAttempts: 3, Delay: "1ms"}
+
+	countRetries := func(evts []Event) int {
+		retries := 0
+		for _, evt := range evts {
+			if evt.Type == DiagEvent {
+				e := evt.Payload.(DiagEventPayload)
+				if strings.Contains(colors.Never.Colorize(e.Message), "retrying in") && e.Severity == diag.Warning {
+					retries++
+				}
+			}
+		}
+		return retries
+	}
+	run := func(expectFailure bool, expectedAttempts int32, expectedRetries int) {
+		atomic.StoreInt32(&attempts, 0)
+		p.Steps = []TestStep{{
+			Op:            Update,
+			ExpectFailure: expectFailure,
+			SkipPreview:   true,
+			Validate: func(project workspace.Project, target deploy.Target, j *Journal, evts []Event, err error) error {
+				assert.Equal(t, expectedAttempts, atomic.LoadInt32(&attempts))
+				assert.Equal(t, expectedRetries, countRetries(evts))
+				return err
+			},
+		}}
+		p.Run(t, nil)
+	}
+
+	// A create that fails transiently fewer times than the project's policy allows should succeed.
+	failures = 2
+	run(false, 3, 2)
+
+	// A create that fails transiently more times than the project's policy allows should fail.
+	failures = 3
+	run(true, 3, 2)
+
+	// A resource may override the project's policy.
+	retryPolicy = &pulumirpc.RegisterResourceRequest_RetryPolicy{MaxAttempts: 4}
+	run(false, 4, 3)
+
+	// An error that is not transient should not be retried.
+	code = codes.InvalidArgument
+	run(true, 1, 0)
+
+	// An error that a provider marks as retryable should be retried regardless of its code.
+	code = codes.Unknown
+	failures, retryPolicy = 1, nil
+	loaders[0] = deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+		return &deploytest.Provider{
+			CreateF: func(urn resource.URN,
+				news resource.PropertyMap) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+				if atomic.AddInt32(&attempts, 1) <= failures {
+					return "", nil, resource.StatusOK, rpcerror.WithRetryable(rpcerror.New(code, "throttled"), true)
+				}
+				return "created-id", news, resource.StatusOK, nil
+			},
+		}, nil
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	run(false, 2, 1)
+}
+
+func TestProviderParallelism(t *testing.T) {
+	p := &TestPlan{}
+
+	// Creates run concurrently, so the counts of in-flight creates are guarded by a lock.
+	var lock sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN,
+					news resource.PropertyMap) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					lock.Lock()
+					inFlight++
+					if inFlight > maxInFlight {
+						maxInFlight = inFlight
+					}
+					lock.Unlock()
+
+					time.Sleep(20 * time.Millisecond)
+
+					lock.Lock()
+					inFlight--
+					lock.Unlock()
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	// Register several independent resources at once so that their creates may run concurrently.
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		var wg sync.WaitGroup
+		errs := make(chan error, 6)
+		for i := 0; i < 6; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, _, _, err := monitor.RegisterResource("pkgA:m:typA", fmt.Sprintf("res%d", i), true, "", false, nil, "", nil, nil,
+					false)
+				errs <- err
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Parallel = 10
+	p.Parallel = map[string]int{"pkgA": 2}
+
+	countWaits := func(evts []Event) int {
+		waits := 0
+		for _, evt := range evts {
+			if evt.Type == DiagEvent {
+				e := evt.Payload.(DiagEventPayload)
+				if strings.Contains(e.Message, "in-flight pkgA operations") && e.Ephemeral {
+					waits++
+				}
+			}
+		}
+		return waits
+	}
+	run := func(expectedMax int) {
+		lock.Lock()
+		maxInFlight = 0
+		lock.Unlock()
+		p.Steps = []TestStep{{
+			Op:          Update,
+			SkipPreview: true,
+			Validate: func(project workspace.Project, target deploy.Target, j *Journal, evts []Event, err error) error {
+				lock.Lock()
+				observed := maxInFlight
+				lock.Unlock()
+				assert.True(t, observed <= expectedMax, "%d creates were in flight at once", observed)
+				assert.True(t, countWaits(evts) > 0)
+
+				// The limit is not part of the default provider's configuration.
+				for _, entry := range j.Entries {
+					if entry.Step.Op() == deploy.OpCreate && providers.IsProviderType(entry.Step.Type()) {
+						assert.NotContains(t, entry.Step.New().Inputs, resource.PropertyKey("parallel"))
+					}
+				}
+				return err
+			},
+		}}
+		p.Run(t, nil)
+	}
+
+	// The project's limit applies to each provider for the package.
+	run(2)
+
+	// The stack's configuration overrides the project's limit.
+	p.Config = config.Map{config.MustMakeKey("pkgA", "parallel"): config.NewValue("1")}
+	run(1)
+}
+
+func TestPolicyPackEnforcementLevels(t *testing.T) {
+	p := &TestPlan{}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	// resB is a child of resA and depends on it.
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		resA, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "",
+			resource.PropertyMap{"acl": resource.NewStringProperty("public")}, nil, false)
+		if err != nil {
+			return err
+		}
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true, resA, false, []resource.URN{resA}, "", nil, nil,
+			false)
+		return err
+	})
+
+	// The policy pack's analyzer rejects public ACLs per resource, and stacks with resources that depend on others.
+	resourceLevel, stackLevel := plugin.Advisory, plugin.Advisory
+	var analyzed []plugin.AnalyzerResource
+	analyzers := []*deploytest.AnalyzerLoader{
+		deploytest.NewAnalyzerLoader("policies", func() (plugin.Analyzer, error) {
+			return &deploytest.Analyzer{
+				AnalyzerName: "policies",
+				AnalyzeF: func(t tokens.Type, props resource.PropertyMap) ([]plugin.AnalyzeFailure, error) {
+					if props["acl"].IsString() && props["acl"].StringValue() == "public" {
+						return []plugin.AnalyzeFailure{{
+							Property:         "acl",
+							Reason:           "ACLs must not be public",
+							EnforcementLevel: resourceLevel,
+						}}, nil
+					}
+					return nil, nil
+				},
+				AnalyzeStackF: func(resources []plugin.AnalyzerResource) ([]plugin.AnalyzeFailure, error) {
+					analyzed = resources
+					var failures []plugin.AnalyzeFailure
+					for _, r := range resources {
+						if len(r.Dependencies) > 0 {
+							failures = append(failures, plugin.AnalyzeFailure{
+								URN:              r.URN,
+								Reason:           "resources must not have dependencies",
+								EnforcementLevel: stackLevel,
+							})
+						}
+					}
+					return failures, nil
+				},
+			}, nil
+		}),
+	}
+	p.Options.host = deploytest.NewPluginHostWithAnalyzers(nil, nil, program, analyzers, loaders...)
+	p.Options.PolicyPacks = []string{"policies"}
+
+	getMessages := func(evts []Event, sev diag.Severity) []string {
+		var messages []string
+		for _, evt := range evts {
+			if evt.Type == DiagEvent {
+				e := evt.Payload.(DiagEventPayload)
+				if e.Severity == sev && strings.Contains(e.Message, "policies") {
+					messages = append(messages, colors.Never.Colorize(e.Message))
+				}
+			}
+		}
+		return messages
+	}
+
+	// Advisory failures are reported as warnings, and the whole stack is analyzed once the program has finished.
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, evts []Event, err error) error {
+			warnings := getMessages(evts, diag.Warning)
+			if assert.Len(t, warnings, 2) {
+				assert.Contains(t, warnings[0], "ACLs must not be public")
+				assert.Contains(t, warnings[1], "resources must not have dependencies")
+			}
+			assert.Empty(t, getMessages(evts, diag.Error))
+
+			var resA, resB *plugin.AnalyzerResource
+			for i, r := range analyzed {
+				switch r.URN.Name() {
+				case "resA":
+					resA = &analyzed[i]
+				case "resB":
+					resB = &analyzed[i]
+				}
+			}
+			if assert.NotNil(t, resA) && assert.NotNil(t, resB) {
+				assert.Equal(t, resource.NewStringProperty("public"), resA.Properties["acl"])
+				assert.NotEmpty(t, resA.Provider)
+				assert.Equal(t, resA.URN, resB.Parent)
+				assert.Equal(t, []resource.URN{resA.URN}, resB.Dependencies)
+			}
+			return err
+		},
+	}}
+	p.Run(t, nil)
+
+	// Disabled failures are ignored.
+	resourceLevel, stackLevel = plugin.Disabled, plugin.Disabled
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, evts []Event, err error) error {
+			assert.Empty(t, getMessages(evts, diag.Warning))
+			assert.Empty(t, getMessages(evts, diag.Error))
+			return err
+		},
+	}}
+	p.Run(t, nil)
+
+	// Mandatory failures from stack analysis fail the update. The stack is analyzed once the program has finished, so
+	// its resources have already been created by then.
+	stackLevel = plugin.Mandatory
+	p.Steps = []TestStep{{
+		Op:            Update,
+		ExpectFailure: true,
+		SkipPreview:   true,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, evts []Event, err error) error {
+			errs := getMessages(evts, diag.Error)
+			if assert.Len(t, errs, 1) {
+				assert.Contains(t, errs[0], "resources must not have dependencies")
+			}
+			created := false
+			for _, entry := range j.Entries {
+				created = created || entry.Step.Op() == deploy.OpCreate && entry.Step.URN().Name() == "resB"
+			}
+			assert.True(t, created)
+			return err
+		},
+	}}
+	p.Run(t, nil)
+
+	// Mandatory failures from resource analysis block the update before the resource is created.
+	resourceLevel, stackLevel = plugin.Mandatory, plugin.Disabled
+	p.Steps = []TestStep{{
+		Op:            Update,
+		ExpectFailure: true,
+		SkipPreview:   true,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, evts []Event, err error) error {
+			errs := getMessages(evts, diag.Error)
+			if assert.Len(t, errs, 1) {
+				assert.Contains(t, errs[0], "ACLs must not be public")
+			}
+			for _, entry := range j.Entries {
+				assert.NotEqual(t, "resA", string(entry.Step.URN().Name()))
+			}
+			return err
+		},
+	}}
+	p.Run(t, nil)
+}
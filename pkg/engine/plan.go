@@ -17,6 +17,8 @@ package engine
 import (
 	"context"
 	"os"
+	"path/filepath"
+	"strconv"
 	"sync"
 
 	"github.com/opentracing/opentracing-go"
@@ -98,9 +100,18 @@ type planOptions struct {
 	// true if we're planning a refresh.
 	isRefresh bool
 
+	// true if we're planning an import of existing resources.
+	isImport bool
+
 	// true if we should trust the dependency graph reported by the language host. Not all Pulumi-supported languages
 	// correctly report their dependencies, in which case this will be false.
 	trustDependencies bool
+
+	// the project's policy for retrying resource operations that fail with transient errors.
+	retryPolicy resource.RetryPolicy
+
+	// the limit on each provider's concurrent operations, by package.
+	providerParallel map[tokens.Package]int
 }
 
 // planSourceFunc is a callback that will be used to prepare for, and evaluate, the "new" state for a stack.
@@ -126,6 +137,18 @@ func plan(ctx *Context, info *planContext, opts planOptions, dryRun bool) (*plan
 	proj, target := info.Update.GetProject(), info.Update.GetTarget()
 	contract.Assert(proj != nil)
 	contract.Assert(target != nil)
+	if r := proj.Retry; r != nil {
+		policy, err := resource.ParseRetryPolicy(r.MaxAttempts, r.Delay, r.MaxDelay, r.Backoff, r.Jitter)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid retry policy in project")
+		}
+		opts.retryPolicy = policy
+	}
+	providerParallel, err := getProviderParallelism(proj, target)
+	if err != nil {
+		return nil, err
+	}
+	opts.providerParallel = providerParallel
 	projinfo := &Projinfo{Proj: proj, Root: info.Update.GetRoot()}
 	pwd, main, plugctx, err := ProjectInfoContext(projinfo, opts.host, target, pluginEvents,
 		opts.Diag, opts.StatusDiag, info.TracingSpan)
@@ -142,10 +165,13 @@ func plan(ctx *Context, info *planContext, opts planOptions, dryRun bool) (*plan
 		return nil, err
 	}
 
-	// If there are any analyzers in the project file, add them.
+	// If there are any analyzers in the project file, add them. Rules files are relative to the project's root.
 	var analyzers []tokens.QName
 	if as := projinfo.Proj.Analyzers; as != nil {
 		for _, a := range *as {
+			if plugin.IsRulesAnalyzer(a) && !filepath.IsAbs(string(a)) {
+				a = tokens.QName(filepath.Join(projinfo.Root, string(a)))
+			}
 			analyzers = append(analyzers, a)
 		}
 	}
@@ -155,8 +181,19 @@ func plan(ctx *Context, info *planContext, opts planOptions, dryRun bool) (*plan
 		analyzers = append(analyzers, tokens.QName(a))
 	}
 
+	// Load the analyzers of any policy packs and append them as well.
+	for _, path := range opts.PolicyPacks {
+		analyzer, err := plugctx.Host.PolicyAnalyzer(path)
+		if err != nil {
+			contract.IgnoreClose(plugctx)
+			return nil, errors.Wrapf(err, "loading policy pack %s", path)
+		}
+		analyzers = append(analyzers, analyzer.Name())
+	}
+
 	// Generate a plan; this API handles all interesting cases (create, update, delete).
-	plan, err := deploy.NewPlan(plugctx, target, target.Snapshot, source, analyzers, dryRun, ctx.BackendClient)
+	plan, err := deploy.NewPlan(plugctx, target, target.Snapshot, source, analyzers, dryRun, opts.Resume,
+		ctx.BackendClient)
 	if err != nil {
 		contract.IgnoreClose(plugctx)
 		return nil, err
@@ -169,6 +206,31 @@ func plan(ctx *Context, info *planContext, opts planOptions, dryRun bool) (*plan
 	}, nil
 }
 
+// getProviderParallelism returns the limit on each provider's concurrent operations for each package. Limits are read
+// from the project and then from the `<package>:parallel` values in the stack's configuration, which take precedence.
+func getProviderParallelism(proj *workspace.Project, target *deploy.Target) (map[tokens.Package]int, error) {
+	result := make(map[tokens.Package]int)
+	for pkg, limit := range proj.Parallel {
+		result[tokens.Package(pkg)] = limit
+	}
+	for k, c := range target.Config {
+		// The project's own namespace holds the program's configuration, so it never configures a provider.
+		if k.Name() != deploy.ProviderParallelKey || k.Namespace() == string(proj.Name) {
+			continue
+		}
+		v, err := c.Value(target.Decrypter)
+		if err != nil {
+			return nil, err
+		}
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return nil, errors.Errorf("configuration value '%v' must be a positive integer", k)
+		}
+		result[tokens.Package(k.Namespace())] = limit
+	}
+	return result, nil
+}
+
 type planResult struct {
 	Ctx     *planContext    // plan context information.
 	Plugctx *plugin.Context // the context containing plugins and their state.
@@ -212,6 +274,15 @@ func (res *planResult) Walk(cancelCtx *Context, events deploy.Events, preview bo
 			Refresh:           res.Options.Refresh,
 			RefreshOnly:       res.Options.isRefresh,
 			TrustDependencies: res.Options.trustDependencies,
+			UpdateTargets:     res.Options.UpdateTargets,
+			TargetDependents:  res.Options.TargetDependents,
+			ReplaceTargets:    res.Options.ReplaceTargets,
+			UpdatePlan:        res.Options.UpdatePlan,
+			RecordPlan:        res.Options.RecordPlan,
+			ContinueOnError:   res.Options.ContinueOnError,
+			Import:            res.Options.isImport,
+			RetryPolicy:       res.Options.retryPolicy,
+			ProviderParallel:  res.Options.providerParallel,
 		}
 		err = res.Plan.Execute(ctx, opts, preview)
 		close(done)
@@ -346,6 +417,10 @@ func ShouldRecordReadStep(step deploy.Step) bool {
 		step.Old().Outputs.Diff(step.New().Outputs) != nil
 }
 
+func (acts *planActions) OnResourceStepSkipped(step deploy.Step) {
+	acts.Opts.Diag.Warningf(diag.GetResourceSkippedWarning(step.URN()), step.URN())
+}
+
 func (acts *planActions) OnResourceOutputs(step deploy.Step) error {
 	acts.MapLock.Lock()
 	assertSeen(acts.Seen, step)
@@ -368,6 +443,5 @@ func assertSeen(seen map[resource.URN]deploy.Step, step deploy.Step) {
 }
 
 func isDefaultProviderStep(step deploy.Step) bool {
-	urn := step.URN()
-	return providers.IsProviderType(urn.Type()) && urn.Name() == "default"
+	return providers.IsDefaultProvider(step.URN())
 }
@@ -39,6 +39,9 @@ type UpdateOptions struct {
 	// an optional set of analyzers to run as part of this deployment.
 	Analyzers []string
 
+	// an optional set of policy pack directories whose analyzers run as part of this deployment.
+	PolicyPacks []string
+
 	// the degree of parallelism for resource operations (<=1 for serial).
 	Parallel int
 
@@ -48,6 +51,30 @@ type UpdateOptions struct {
 	// true if the plan should refresh before executing.
 	Refresh bool
 
+	// Specific resources to update during an update operation. If empty, all resources are updated.
+	UpdateTargets []resource.URN
+
+	// true if resources that depend upon the update targets should also be updated.
+	TargetDependents bool
+
+	// Specific resources to replace during an update operation, regardless of whether or not they have changed.
+	ReplaceTargets []resource.URN
+
+	// An optional plan to which the update must conform. The update fails if it would perform any step that is not
+	// part of the plan or if any resource's inputs differ from those recorded in the plan.
+	UpdatePlan *deploy.UpdatePlan
+
+	// An optional plan in which to record each step that the update (or preview) performs.
+	RecordPlan *deploy.UpdatePlan
+
+	// true if the update should continue after a step fails, skipping only those steps that depend on a failed
+	// resource.
+	ContinueOnError bool
+
+	// true if the update should reconcile any operations that were left pending by an interrupted update rather than
+	// failing.
+	Resume bool
+
 	// true if we should report events for steps that involve default providers.
 	reportDefaultProviderSteps bool
 
@@ -164,9 +191,10 @@ func update(ctx *Context, info *planContext, opts planOptions, dryRun bool) (Res
 			err = result.Walk(ctx, actions, false)
 			resourceChanges = ResourceChanges(actions.Ops)
 
-			if len(resourceChanges) != 0 {
+			if len(resourceChanges) != 0 || len(actions.Failed) != 0 || len(actions.Skipped) != 0 {
 				// Print out the total number of steps performed (and their kinds), the duration, and any summary info.
-				opts.Events.updateSummaryEvent(actions.MaybeCorrupt, time.Since(start), resourceChanges)
+				opts.Events.updateSummaryEvent(actions.MaybeCorrupt, time.Since(start), resourceChanges,
+					actions.Failed, actions.Skipped)
 			}
 		}
 	}
@@ -189,6 +217,8 @@ type updateActions struct {
 	Steps        int
 	Ops          map[deploy.StepOp]int
 	Seen         map[resource.URN]deploy.Step
+	Failed       []resource.URN
+	Skipped      []resource.URN
 	MapLock      sync.Mutex
 	MaybeCorrupt bool
 	Update       UpdateInfo
@@ -247,6 +277,10 @@ func (acts *updateActions) OnResourceStepPost(
 			errorURN = step.URN()
 		}
 
+		acts.MapLock.Lock()
+		acts.Failed = append(acts.Failed, step.URN())
+		acts.MapLock.Unlock()
+
 		// Issue a true, bonafide error.
 		acts.Opts.Diag.Errorf(diag.GetPlanApplyFailedError(errorURN), err)
 		if reportStep {
@@ -304,12 +338,27 @@ func (acts *updateActions) OnResourceStepPost(
 		}
 	}
 
+	// A step that timed out may still be in flight in its provider. Rather than ending the step's mutation, which would
+	// remove its pending operation from the snapshot, leave the operation pending so that it can be reconciled by
+	// `pulumi up --resume` or `pulumi stack recover`.
+	if _, timedOut := err.(*deploy.StepTimeoutError); timedOut {
+		return nil
+	}
+
 	// Write out the current snapshot. Note that even if a failure has occurred, we should still have a
 	// safe checkpoint.  Note that any error that occurs when writing the checkpoint trumps the error
 	// reported above.
 	return ctx.(SnapshotMutation).End(step, err == nil || status == resource.StatusPartialFailure)
 }
 
+func (acts *updateActions) OnResourceStepSkipped(step deploy.Step) {
+	acts.MapLock.Lock()
+	acts.Skipped = append(acts.Skipped, step.URN())
+	acts.MapLock.Unlock()
+
+	acts.Opts.Diag.Warningf(diag.GetResourceSkippedWarning(step.URN()), step.URN())
+}
+
 func (acts *updateActions) OnResourceOutputs(step deploy.Step) error {
 	acts.MapLock.Lock()
 	assertSeen(acts.Seen, step)
@@ -22,6 +22,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/util/contract"
@@ -81,6 +82,43 @@ func (p panicCrypter) DecryptValue(ciphertext string) (string, error) {
 	panic("attempt to decrypt value")
 }
 
+// NewLazyCrypter returns a crypter that defers calling create to construct the underlying crypter until a value
+// actually needs to be encrypted or decrypted.  This is useful when constructing the crypter may prompt the user (e.g.
+// for a passphrase) and the values being processed may not contain any secrets at all.
+func NewLazyCrypter(create func() (Crypter, error)) Crypter {
+	return &lazyCrypter{create: create}
+}
+
+type lazyCrypter struct {
+	create  func() (Crypter, error)
+	once    sync.Once
+	crypter Crypter
+	err     error
+}
+
+func (c *lazyCrypter) getCrypter() (Crypter, error) {
+	c.once.Do(func() {
+		c.crypter, c.err = c.create()
+	})
+	return c.crypter, c.err
+}
+
+func (c *lazyCrypter) EncryptValue(plaintext string) (string, error) {
+	crypter, err := c.getCrypter()
+	if err != nil {
+		return "", err
+	}
+	return crypter.EncryptValue(plaintext)
+}
+
+func (c *lazyCrypter) DecryptValue(ciphertext string) (string, error) {
+	crypter, err := c.getCrypter()
+	if err != nil {
+		return "", err
+	}
+	return crypter.DecryptValue(ciphertext)
+}
+
 // NewSymmetricCrypter creates a crypter that encrypts and decrypts values using AES-256-GCM.  The nonce is stored with
 // the value itself as a pair of base64 values separated by a colon and a version tag `v1` is prepended.
 func NewSymmetricCrypter(key []byte) Crypter {
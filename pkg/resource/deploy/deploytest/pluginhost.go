@@ -15,6 +15,7 @@
 package deploytest
 
 import (
+	"path/filepath"
 	"sync"
 
 	"github.com/blang/semver"
@@ -55,13 +56,29 @@ func NewProviderLoaderWithHost(pkg tokens.Package, version semver.Version,
 	}
 }
 
+type LoadAnalyzerFunc func() (plugin.Analyzer, error)
+
+type AnalyzerLoader struct {
+	name tokens.QName
+	load LoadAnalyzerFunc
+}
+
+func NewAnalyzerLoader(name tokens.QName, load LoadAnalyzerFunc) *AnalyzerLoader {
+	return &AnalyzerLoader{
+		name: name,
+		load: load,
+	}
+}
+
 type pluginHost struct {
 	providerLoaders []*ProviderLoader
+	analyzerLoaders []*AnalyzerLoader
 	languageRuntime plugin.LanguageRuntime
 	sink            diag.Sink
 	statusSink      diag.Sink
 
 	providers map[plugin.Provider]struct{}
+	analyzers map[tokens.QName]plugin.Analyzer
 	closed    bool
 	m         sync.Mutex
 }
@@ -69,12 +86,22 @@ type pluginHost struct {
 func NewPluginHost(sink, statusSink diag.Sink, languageRuntime plugin.LanguageRuntime,
 	providerLoaders ...*ProviderLoader) plugin.Host {
 
+	return NewPluginHostWithAnalyzers(sink, statusSink, languageRuntime, nil, providerLoaders...)
+}
+
+// NewPluginHostWithAnalyzers creates a plugin host that can also load the given analyzers. Policy packs are loaded
+// using the analyzer whose name matches the last element of the policy pack's path.
+func NewPluginHostWithAnalyzers(sink, statusSink diag.Sink, languageRuntime plugin.LanguageRuntime,
+	analyzerLoaders []*AnalyzerLoader, providerLoaders ...*ProviderLoader) plugin.Host {
+
 	return &pluginHost{
 		providerLoaders: providerLoaders,
+		analyzerLoaders: analyzerLoaders,
 		languageRuntime: languageRuntime,
 		sink:            sink,
 		statusSink:      statusSink,
 		providers:       make(map[plugin.Provider]struct{}),
+		analyzers:       make(map[tokens.QName]plugin.Analyzer),
 	}
 }
 
@@ -158,8 +185,29 @@ func (host *pluginHost) LogStatus(sev diag.Severity, urn resource.URN, msg strin
 	}
 }
 func (host *pluginHost) Analyzer(nm tokens.QName) (plugin.Analyzer, error) {
+	host.m.Lock()
+	defer host.m.Unlock()
+
+	if analyzer, has := host.analyzers[nm]; has {
+		return analyzer, nil
+	}
+	for _, l := range host.analyzerLoaders {
+		if l.name != nm {
+			continue
+		}
+
+		analyzer, err := l.load()
+		if err != nil {
+			return nil, err
+		}
+		host.analyzers[nm] = analyzer
+		return analyzer, nil
+	}
 	return nil, errors.New("unsupported")
 }
+func (host *pluginHost) PolicyAnalyzer(path string) (plugin.Analyzer, error) {
+	return host.Analyzer(tokens.QName(filepath.Base(path)))
+}
 func (host *pluginHost) CloseProvider(provider plugin.Provider) error {
 	host.m.Lock()
 	defer host.m.Unlock()
@@ -27,25 +27,58 @@ type ResourceMonitor struct {
 	resmon pulumirpc.ResourceMonitorClient
 }
 
+// ResourceOptions contains the optional settings for a resource registered via
+// ResourceMonitor.RegisterResourceWithOptions.
+type ResourceOptions struct {
+	Parent              resource.URN
+	Protect             bool
+	Dependencies        []resource.URN
+	Provider            string
+	Inputs              resource.PropertyMap
+	PropertyDeps        map[resource.PropertyKey][]resource.URN
+	DeleteBeforeReplace bool
+	Aliases             []resource.URN
+	IgnoreChanges       []string
+	CustomTimeouts      *pulumirpc.RegisterResourceRequest_CustomTimeouts
+	ImportID            resource.ID
+	RetainOnDelete      bool
+	RetryPolicy         *pulumirpc.RegisterResourceRequest_RetryPolicy
+	ReplaceOnChanges    []string
+}
+
 func (rm *ResourceMonitor) RegisterResource(t tokens.Type, name string, custom bool, parent resource.URN, protect bool,
 	dependencies []resource.URN, provider string, inputs resource.PropertyMap,
 	propertyDeps map[resource.PropertyKey][]resource.URN,
 	deleteBeforeReplace bool) (resource.URN, resource.ID, resource.PropertyMap, error) {
 
+	return rm.RegisterResourceWithOptions(t, name, custom, ResourceOptions{
+		Parent:              parent,
+		Protect:             protect,
+		Dependencies:        dependencies,
+		Provider:            provider,
+		Inputs:              inputs,
+		PropertyDeps:        propertyDeps,
+		DeleteBeforeReplace: deleteBeforeReplace,
+	})
+}
+
+func (rm *ResourceMonitor) RegisterResourceWithOptions(t tokens.Type, name string, custom bool,
+	opts ResourceOptions) (resource.URN, resource.ID, resource.PropertyMap, error) {
+
 	// marshal inputs
-	ins, err := plugin.MarshalProperties(inputs, plugin.MarshalOptions{KeepUnknowns: true})
+	ins, err := plugin.MarshalProperties(opts.Inputs, plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
 	if err != nil {
 		return "", "", nil, err
 	}
 
 	// marshal dependencies
 	deps := []string{}
-	for _, d := range dependencies {
+	for _, d := range opts.Dependencies {
 		deps = append(deps, string(d))
 	}
 
 	inputDeps := make(map[string]*pulumirpc.RegisterResourceRequest_PropertyDependencies)
-	for pk, pd := range propertyDeps {
+	for pk, pd := range opts.PropertyDeps {
 		pdeps := []string{}
 		for _, d := range pd {
 			pdeps = append(pdeps, string(d))
@@ -55,18 +88,31 @@ func (rm *ResourceMonitor) RegisterResource(t tokens.Type, name string, custom b
 		}
 	}
 
+	// marshal aliases
+	var aliases []string
+	for _, a := range opts.Aliases {
+		aliases = append(aliases, string(a))
+	}
+
 	// submit request
 	resp, err := rm.resmon.RegisterResource(context.Background(), &pulumirpc.RegisterResourceRequest{
 		Type:                 string(t),
 		Name:                 name,
 		Custom:               custom,
-		Parent:               string(parent),
-		Protect:              protect,
+		Parent:               string(opts.Parent),
+		Protect:              opts.Protect,
 		Dependencies:         deps,
-		Provider:             provider,
+		Provider:             opts.Provider,
 		Object:               ins,
 		PropertyDependencies: inputDeps,
-		DeleteBeforeReplace:  deleteBeforeReplace,
+		DeleteBeforeReplace:  opts.DeleteBeforeReplace,
+		Aliases:              aliases,
+		IgnoreChanges:        opts.IgnoreChanges,
+		CustomTimeouts:       opts.CustomTimeouts,
+		ImportId:             string(opts.ImportID),
+		RetainOnDelete:       opts.RetainOnDelete,
+		RetryPolicy:          opts.RetryPolicy,
+		ReplaceOnChanges:     opts.ReplaceOnChanges,
 	})
 	if err != nil {
 		return "", "", nil, err
@@ -85,7 +131,7 @@ func (rm *ResourceMonitor) ReadResource(t tokens.Type, name string, id resource.
 	inputs resource.PropertyMap, provider string) (resource.URN, resource.PropertyMap, error) {
 
 	// marshal inputs
-	ins, err := plugin.MarshalProperties(inputs, plugin.MarshalOptions{KeepUnknowns: true})
+	ins, err := plugin.MarshalProperties(inputs, plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
 	if err != nil {
 		return "", nil, err
 	}
@@ -39,11 +39,22 @@ type BackendClient interface {
 
 // Options controls the planning and deployment process.
 type Options struct {
-	Events            Events // an optional events callback interface.
-	Parallel          int    // the degree of parallelism for resource operations (<=1 for serial).
-	Refresh           bool   // whether or not to refresh before executing the plan.
-	RefreshOnly       bool   // whether or not to exit after refreshing.
-	TrustDependencies bool   // whether or not to trust the resource dependency graph.
+	Events            Events               // an optional events callback interface.
+	Parallel          int                  // the degree of parallelism for resource operations (<=1 for serial).
+	Refresh           bool                 // whether or not to refresh before executing the plan.
+	RefreshOnly       bool                 // whether or not to exit after refreshing.
+	TrustDependencies bool                 // whether or not to trust the resource dependency graph.
+	UpdateTargets     []resource.URN       // if non-empty, the resources to which the plan's changes are restricted.
+	TargetDependents  bool                 // whether or not to also target resources that depend upon the update targets.
+	ReplaceTargets    []resource.URN       // the set of resources that must be replaced regardless of their diffs.
+	UpdatePlan        *UpdatePlan          // if non-nil, the plan to which this plan's steps must conform.
+	RecordPlan        *UpdatePlan          // if non-nil, the plan in which to record each step that this plan applies.
+	ContinueOnError   bool                 // whether or not to continue executing independent steps after a step fails.
+	Import            bool                 // whether or not the plan's source registers existing resources to import.
+	RetryPolicy       resource.RetryPolicy // the default policy for retrying operations that fail with transient errors.
+
+	// ProviderParallel limits, by package, the number of operations that each provider may have in flight at once.
+	ProviderParallel map[tokens.Package]int
 }
 
 // DegreeOfParallelism returns the degree of parallelism that should be used during the
@@ -65,6 +76,7 @@ type Events interface {
 	OnResourceStepPre(step Step) (interface{}, error)
 	OnResourceStepPost(ctx interface{}, step Step, status resource.Status, err error) error
 	OnResourceOutputs(step Step) error
+	OnResourceStepSkipped(step Step)
 }
 
 // PlanPendingOperationsError is an error returned from `NewPlan` if there exist pending operations in the
@@ -90,6 +102,7 @@ type Plan struct {
 	source    Source                           // the source of new resources.
 	analyzers []tokens.QName                   // the analyzers to run during this plan's generation.
 	preview   bool                             // true if this plan is to be previewed rather than applied.
+	resume    bool                             // true if this plan should reconcile interrupted operations.
 	depGraph  *graph.DependencyGraph           // the dependency graph of the old snapshot
 	providers *providers.Registry              // the provider registry for this plan.
 }
@@ -171,10 +184,15 @@ func addDefaultProviders(target *Target, source Source, prev *Snapshot) error {
 // generated based on analysis of the old and new states.  If a resource exists in new, but not old, for example, it
 // results in a create; if it exists in both, but is different, it results in an update; and so on and so forth.
 //
+// If resume is true, any updates or deletes that were left pending by an interrupted update are reconciled with their
+// providers when the plan executes rather than causing NewPlan to fail with a PlanPendingOperationsError. Interrupted
+// creates have no ID with which they can be read, so NewPlan still fails with a PlanPendingOperationsError that lists
+// them if there are any.
+//
 // Note that a plan uses internal concurrency and parallelism in various ways, so it must be closed if for some reason
 // a plan isn't carried out to its final conclusion.  This will result in cancelation and reclamation of OS resources.
 func NewPlan(ctx *plugin.Context, target *Target, prev *Snapshot, source Source, analyzers []tokens.QName,
-	preview bool, backendClient BackendClient) (*Plan, error) {
+	preview, resume bool, backendClient BackendClient) (*Plan, error) {
 
 	contract.Assert(ctx != nil)
 	contract.Assert(target != nil)
@@ -197,9 +215,20 @@ func NewPlan(ctx *plugin.Context, target *Target, prev *Snapshot, source Source,
 	// planExecutor.refresh for details.
 	olds := make(map[resource.URN]*resource.State)
 	if prev != nil {
-		if prev.PendingOperations != nil && !preview {
+		if prev.PendingOperations != nil && !preview && !resume {
 			return nil, PlanPendingOperationsError{prev.PendingOperations}
 		}
+		if resume {
+			var creates []resource.Operation
+			for _, op := range prev.PendingOperations {
+				if op.Type == resource.OperationTypeCreating {
+					creates = append(creates, op)
+				}
+			}
+			if len(creates) != 0 {
+				return nil, PlanPendingOperationsError{creates}
+			}
+		}
 		oldResources = prev.Resources
 
 		for _, oldres := range oldResources {
@@ -237,6 +266,7 @@ func NewPlan(ctx *plugin.Context, target *Target, prev *Snapshot, source Source,
 		source:    source,
 		analyzers: analyzers,
 		preview:   preview,
+		resume:    resume,
 		depGraph:  depGraph,
 		providers: reg,
 	}, nil
@@ -288,6 +318,59 @@ func (p *Plan) generateEventURN(event SourceEvent) resource.URN {
 	}
 }
 
+// computeTargets computes the set of URNs to which the given options restrict this plan's changes. If the options do
+// not restrict the plan, computeTargets returns nil. If the options request that dependents of the targets are also
+// targeted, the set includes every resource in the old snapshot that transitively depends upon a target.
+func (p *Plan) computeTargets(opts Options) map[resource.URN]bool {
+	if len(opts.UpdateTargets) == 0 {
+		return nil
+	}
+
+	// Resources that are to be replaced are implicitly targeted.
+	targets := make(map[resource.URN]bool)
+	for _, urn := range opts.UpdateTargets {
+		targets[urn] = true
+	}
+	for _, urn := range opts.ReplaceTargets {
+		targets[urn] = true
+	}
+
+	// The resources in a snapshot are stored in dependency order, so a single forward pass is sufficient to pick up
+	// all transitive dependents of the targets.
+	if opts.TargetDependents && p.prev != nil {
+		for _, res := range p.prev.Resources {
+			if !targets[res.URN] && dependsOnAny(targets, res.Parent, res.Dependencies, res.Provider) != "" {
+				targets[res.URN] = true
+			}
+		}
+	}
+
+	return targets
+}
+
+// dependsOnAny returns the URN of a resource in the given set upon which a resource with the given parent,
+// dependencies, and provider reference depends, or the empty URN if there is no such resource. Children are considered
+// to depend upon their parents.
+func dependsOnAny(set map[resource.URN]bool, parent resource.URN, deps []resource.URN,
+	provider string) resource.URN {
+
+	if parent != "" && set[parent] {
+		return parent
+	}
+	for _, dep := range deps {
+		if set[dep] {
+			return dep
+		}
+	}
+	if provider != "" {
+		ref, err := providers.ParseReference(provider)
+		if err == nil && set[ref.URN()] {
+			return ref.URN()
+		}
+	}
+	return ""
+}
+
 // Execute executes a plan to completion, using the given cancellation context and running a preview
 // or update.
 func (p *Plan) Execute(ctx context.Context, opts Options, preview bool) error {
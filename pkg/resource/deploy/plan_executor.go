@@ -72,7 +72,13 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 		}
 	}()
 
-	// Before doing anything else, optionally refresh each resource in the base checkpoint.
+	// Before doing anything else, reconcile any operations left pending by an interrupted update if we are resuming,
+	// then optionally refresh each resource in the base checkpoint.
+	if pe.plan.resume {
+		if err := pe.resume(callerCtx, opts, preview); err != nil {
+			return err
+		}
+	}
 	if opts.Refresh {
 		if err := pe.refresh(callerCtx, opts, preview); err != nil {
 			return err
@@ -101,7 +107,7 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 	ctx, cancel := context.WithCancel(callerCtx)
 
 	// Set up a step generator and executor for this plan.
-	pe.stepExec = newStepExecutor(ctx, cancel, pe.plan, opts, preview, false)
+	pe.stepExec = newStepExecutor(ctx, cancel, pe.plan, opts, preview, opts.ContinueOnError)
 
 	// We iterate the source in its own goroutine because iteration is blocking and we want the main loop to be able to
 	// respond to cancellation requests promptly.
@@ -147,7 +153,27 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 				}
 
 				if event.Event == nil {
-					deleteSteps := pe.stepGen.GenerateDeletes()
+					// The program has finished, so give any analyzers a chance to inspect the stack as a whole before
+					// anything is deleted.
+					if res := pe.stepGen.AnalyzeStack(); res != nil {
+						if resErr := res.Error(); resErr != nil {
+							logging.V(4).Infof("planExecutor.Execute(...): error analyzing stack: %v", resErr)
+							pe.reportError("", resErr)
+						}
+						cancel()
+						return false, result.TODO()
+					}
+
+					deleteSteps, res := pe.stepGen.GenerateDeletes()
+					if res != nil {
+						if resErr := res.Error(); resErr != nil {
+							logging.V(4).Infof("planExecutor.Execute(...): error generating deletes: %v", resErr)
+							pe.reportError("", resErr)
+						}
+						cancel()
+						return false, result.TODO()
+					}
+
 					deletes := pe.stepGen.ScheduleDeletes(deleteSteps)
 
 					// ScheduleDeletes gives us a list of lists of steps. Each list of steps can safely be executed in
@@ -222,7 +248,7 @@ func (pe *planExecutor) handleSingleEvent(event SourceEvent) *result.Result {
 		return res
 	}
 
-	pe.stepExec.ExecuteSerial(steps)
+	pe.stepExec.ExecuteEventChain(event, steps)
 	return nil
 }
 
@@ -269,15 +295,68 @@ func (pe *planExecutor) retirePendingDeletes(callerCtx context.Context, opts Opt
 
 // refresh refreshes the state of the base checkpoint file for the current plan in memory.
 func (pe *planExecutor) refresh(callerCtx context.Context, opts Options, preview bool) error {
+	// Refresh each targeted resource in the old snapshot. Untargeted resources are left as-is.
+	targets := pe.plan.computeTargets(opts)
+	return pe.refreshResources(callerCtx, opts, preview, func(res *resource.State) bool {
+		return targets == nil || targets[res.URN]
+	})
+}
+
+// resume reconciles any operations that were left pending in the base checkpoint by an interrupted update. Each
+// resource whose update or delete was interrupted is read from its provider and the result is folded into the base
+// checkpoint in memory. Once reconciled, the pending operations are cleared from the base checkpoint. NewPlan refuses
+// to resume a plan whose base checkpoint has pending creates, as those cannot be read.
+func (pe *planExecutor) resume(callerCtx context.Context, opts Options, preview bool) error {
+	prev := pe.plan.prev
+	if prev == nil || len(prev.PendingOperations) == 0 {
+		return nil
+	}
+
+	// Updates that were journaled without an ID are matched by URN alone: a URN refers to at most one resource that
+	// is not pending deletion.
+	type physicalResource struct {
+		urn resource.URN
+		id  resource.ID
+	}
+	pending := make(map[physicalResource]bool)
+	for _, op := range prev.PendingOperations {
+		switch op.Type {
+		case resource.OperationTypeCreating:
+			contract.Failf("cannot resume the interrupted create of %v", op.Resource.URN)
+		case resource.OperationTypeUpdating, resource.OperationTypeDeleting:
+			logging.V(4).Infof("planExecutor.resume(...): reconciling interrupted %s of %v", op.Type, op.Resource.URN)
+			pending[physicalResource{op.Resource.URN, op.Resource.ID}] = true
+		}
+	}
+
+	err := pe.refreshResources(callerCtx, opts, preview, func(res *resource.State) bool {
+		return pending[physicalResource{res.URN, res.ID}] || !res.Delete && pending[physicalResource{res.URN, ""}]
+	})
+	if err != nil {
+		return err
+	}
+	prev.PendingOperations = nil
+	return nil
+}
+
+// refreshResources refreshes the state of each resource in the base checkpoint file for the current plan that
+// satisfies the given filter in memory.
+func (pe *planExecutor) refreshResources(callerCtx context.Context, opts Options, preview bool,
+	filter func(res *resource.State) bool) error {
+
 	prev := pe.plan.prev
 	if prev == nil || len(prev.Resources) == 0 {
 		return nil
 	}
 
-	// Create a refresh step for each resource in the old snapshot.
-	steps := make([]Step, len(prev.Resources))
-	for i := range prev.Resources {
-		steps[i] = NewRefreshStep(pe.plan, prev.Resources[i], nil)
+	// Create a refresh step for each resource in the old snapshot that satisfies the filter.
+	steps := make([]Step, 0, len(prev.Resources))
+	refreshes := make(map[*resource.State]Step)
+	for _, res := range prev.Resources {
+		if filter(res) {
+			step := NewRefreshStep(pe.plan, res, nil)
+			steps, refreshes[res] = append(steps, step), step
+		}
 	}
 
 	// Fire up a worker pool and issue each refresh in turn.
@@ -315,12 +394,15 @@ func (pe *planExecutor) refresh(callerCtx context.Context, opts Options, preview
 	resources := make([]*resource.State, 0, len(prev.Resources))
 	referenceable := make(map[resource.URN]bool)
 	olds := make(map[resource.URN]*resource.State)
-	for _, s := range steps {
-		new := s.New()
-		if new == nil {
-			contract.Assert(s.Old().Custom)
-			contract.Assert(!providers.IsProviderType(s.Old().Type))
-			continue
+	for _, old := range prev.Resources {
+		new := old
+		if s, ok := refreshes[old]; ok {
+			new = s.New()
+			if new == nil {
+				contract.Assert(old.Custom)
+				contract.Assert(!providers.IsProviderType(old.Type))
+				continue
+			}
 		}
 
 		// Remove any deleted resources from this resource's dependency list.
@@ -41,7 +41,7 @@ func TestPendingOperationsPlan(t *testing.T) {
 		},
 	})
 
-	_, err := NewPlan(&plugin.Context{}, &Target{}, snap, &fixedSource{}, nil, false, nil)
+	_, err := NewPlan(&plugin.Context{}, &Target{}, snap, &fixedSource{}, nil, false, false, nil)
 	if !assert.Error(t, err) {
 		t.FailNow()
 	}
@@ -55,3 +55,33 @@ func TestPendingOperationsPlan(t *testing.T) {
 	assert.Equal(t, resourceB.URN, invalidErr.Operations[0].Resource.URN)
 	assert.Equal(t, resource.OperationTypeCreating, invalidErr.Operations[0].Type)
 }
+
+func TestPendingOperationsResumePlan(t *testing.T) {
+	resourceA := newResource("a")
+	snap := newSnapshot([]*resource.State{
+		resourceA,
+	}, []resource.Operation{
+		{
+			Type:     resource.OperationTypeUpdating,
+			Resource: resourceA,
+		},
+	})
+
+	_, err := NewPlan(&plugin.Context{}, &Target{}, snap, &fixedSource{}, nil, false, true, nil)
+	assert.NoError(t, err)
+
+	// Interrupted creates cannot be resumed.
+	resourceB := newResource("b")
+	snap.PendingOperations = append(snap.PendingOperations, resource.Operation{
+		Type:     resource.OperationTypeCreating,
+		Resource: resourceB,
+	})
+
+	_, err = NewPlan(&plugin.Context{}, &Target{}, snap, &fixedSource{}, nil, false, true, nil)
+	invalidErr, ok := err.(PlanPendingOperationsError)
+	if !assert.True(t, ok) {
+		t.FailNow()
+	}
+	assert.Len(t, invalidErr.Operations, 1)
+	assert.Equal(t, resourceB.URN, invalidErr.Operations[0].Resource.URN)
+}
@@ -41,6 +41,12 @@ func IsProviderType(typ tokens.Type) bool {
 	return typ.Module() == "pulumi:providers" && typ.Name() != ""
 }
 
+// IsDefaultProvider returns true if the supplied URN refers to a default provider, i.e. a provider that the engine
+// created on behalf of resources that did not specify one explicitly.
+func IsDefaultProvider(urn resource.URN) bool {
+	return IsProviderType(urn.Type()) && urn.Name() == "default"
+}
+
 // MakeProviderType returns the provider type token for the given package.
 func MakeProviderType(pkg tokens.Package) tokens.Type {
 	return tokens.Type("pulumi:providers:" + pkg)
@@ -64,6 +64,7 @@ type Registry struct {
 	host      plugin.Host
 	isPreview bool
 	providers map[Reference]plugin.Provider
+	aliases   map[resource.URN]resource.URN
 	builtins  plugin.Provider
 	m         sync.RWMutex
 }
@@ -90,6 +91,7 @@ func NewRegistry(host plugin.Host, prev []*resource.State, isPreview bool,
 		host:      host,
 		isPreview: isPreview,
 		providers: make(map[Reference]plugin.Provider),
+		aliases:   make(map[resource.URN]resource.URN),
 		builtins:  builtins,
 	}
 
@@ -146,9 +148,28 @@ func (r *Registry) GetProvider(ref Reference) (plugin.Provider, bool) {
 	logging.V(7).Infof("GetProvider(%v)", ref)
 
 	provider, ok := r.providers[ref]
+	if !ok {
+		if alias, hasAlias := r.aliases[ref.URN()]; hasAlias {
+			provider, ok = r.providers[mustNewReference(alias, ref.ID())]
+		}
+	}
 	return provider, ok
 }
 
+// RegisterAlias records that the provider with the given URN was previously known by the given alias. References to
+// the provider under its URN will resolve to the provider registered under its alias if no provider has been
+// registered under the URN itself.
+func (r *Registry) RegisterAlias(providerURN, alias resource.URN) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	logging.V(7).Infof("RegisterAlias(%v, %v)", providerURN, alias)
+
+	if providerURN != alias {
+		r.aliases[providerURN] = alias
+	}
+}
+
 func (r *Registry) setProvider(ref Reference, provider plugin.Provider) {
 	r.m.Lock()
 	defer r.m.Unlock()
@@ -305,8 +326,8 @@ func (r *Registry) Diff(urn resource.URN, id resource.ID, olds, news resource.Pr
 // registers it under the assigned (URN, ID).
 //
 // The provider must have been loaded by a prior call to Check.
-func (r *Registry) Create(urn resource.URN,
-	news resource.PropertyMap) (resource.ID, resource.PropertyMap, resource.Status, error) {
+func (r *Registry) Create(urn resource.URN, news resource.PropertyMap,
+	timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
 
 	contract.Assert(!r.isPreview)
 
@@ -332,8 +353,8 @@ func (r *Registry) Create(urn resource.URN,
 // reference indicated by the (URN, ID) pair.
 //
 // THe provider must have been loaded by a prior call to Check.
-func (r *Registry) Update(urn resource.URN, id resource.ID, olds,
-	news resource.PropertyMap) (resource.PropertyMap, resource.Status, error) {
+func (r *Registry) Update(urn resource.URN, id resource.ID, olds, news resource.PropertyMap,
+	timeout float64) (resource.PropertyMap, resource.Status, error) {
 
 	contract.Assert(!r.isPreview)
 
@@ -355,7 +376,9 @@ func (r *Registry) Update(urn resource.URN, id resource.ID, olds,
 
 // Delete unregisters and unloads the provider with the given URN and ID. The provider must have been loaded when the
 // registry was created (i.e. it must have been present in the state handed to NewRegistry).
-func (r *Registry) Delete(urn resource.URN, id resource.ID, props resource.PropertyMap) (resource.Status, error) {
+func (r *Registry) Delete(urn resource.URN, id resource.ID, props resource.PropertyMap,
+	timeout float64) (resource.Status, error) {
+
 	contract.Assert(!r.isPreview)
 
 	ref := mustNewReference(urn, id)
@@ -256,7 +256,8 @@ func (d *defaultProviders) newRegisterDefaultProviderEvent(
 	// Create the result channel and the event.
 	done := make(chan *RegisterResult)
 	event := &registerResourceEvent{
-		goal: resource.NewGoal(providers.MakeProviderType(pkg), "default", true, inputs, "", false, nil, "", nil, nil, false),
+		goal: resource.NewGoal(providers.MakeProviderType(pkg), "default", true, inputs, "", false, nil, "", nil, nil, false,
+			nil, nil, resource.CustomTimeouts{}, "", false, resource.RetryPolicy{}, nil),
 		done: done,
 	}
 	return event, done, nil
@@ -513,6 +514,7 @@ func (rm *resmon) ReadResource(ctx context.Context,
 	props, err := plugin.UnmarshalProperties(req.GetProperties(), plugin.MarshalOptions{
 		Label:        label,
 		KeepUnknowns: true,
+		KeepSecrets:  true,
 	})
 	if err != nil {
 		return nil, err
@@ -600,12 +602,43 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 		dependencies = append(dependencies, resource.URN(dependingURN))
 	}
 
-	props, err := plugin.UnmarshalProperties(
-		req.GetObject(), plugin.MarshalOptions{Label: label, KeepUnknowns: true, ComputeAssetHashes: true})
+	props, err := plugin.UnmarshalProperties(req.GetObject(), plugin.MarshalOptions{
+		Label:              label,
+		KeepUnknowns:       true,
+		ComputeAssetHashes: true,
+		KeepSecrets:        true,
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	var aliases []resource.URN
+	for _, aliasURN := range req.GetAliases() {
+		aliases = append(aliases, resource.URN(aliasURN))
+	}
+	ignoreChanges := req.GetIgnoreChanges()
+	replaceOnChanges := req.GetReplaceOnChanges()
+	importID := resource.ID(req.GetImportId())
+	retainOnDelete := req.GetRetainOnDelete()
+
+	var customTimeouts resource.CustomTimeouts
+	if timeouts := req.GetCustomTimeouts(); timeouts != nil {
+		customTimeouts, err = resource.ParseCustomTimeouts(
+			timeouts.GetCreate(), timeouts.GetUpdate(), timeouts.GetDelete())
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid custom timeouts for resource '%v'", name)
+		}
+	}
+
+	var retryPolicy resource.RetryPolicy
+	if policy := req.GetRetryPolicy(); policy != nil {
+		retryPolicy, err = resource.ParseRetryPolicy(int(policy.GetMaxAttempts()), policy.GetDelay(),
+			policy.GetMaxDelay(), policy.GetBackoff(), policy.GetJitter())
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid retry policy for resource '%v'", name)
+		}
+	}
+
 	propertyDependencies := make(map[resource.PropertyKey][]resource.URN)
 	if len(req.GetPropertyDependencies()) == 0 {
 		// If this request did not specify property dependencies, treat each property as depending on every resource
@@ -626,13 +659,16 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 
 	logging.V(5).Infof(
 		"ResourceMonitor.RegisterResource received: t=%v, name=%v, custom=%v, #props=%v, parent=%v, protect=%v, "+
-			"provider=%v, deps=%v, deleteBeforeReplace=%v",
-		t, name, custom, len(props), parent, protect, provider, dependencies, deleteBeforeReplace)
+			"provider=%v, deps=%v, deleteBeforeReplace=%v, aliases=%v, ignoreChanges=%v, customTimeouts=%v, "+
+			"importID=%v, retainOnDelete=%v, retryPolicy=%v, replaceOnChanges=%v",
+		t, name, custom, len(props), parent, protect, provider, dependencies, deleteBeforeReplace, aliases,
+		ignoreChanges, customTimeouts, importID, retainOnDelete, retryPolicy, replaceOnChanges)
 
 	// Send the goal state to the engine.
 	step := &registerResourceEvent{
 		goal: resource.NewGoal(t, name, custom, props, parent, protect, dependencies, provider, nil,
-			propertyDependencies, deleteBeforeReplace),
+			propertyDependencies, deleteBeforeReplace, aliases, ignoreChanges,
+			customTimeouts, importID, retainOnDelete, retryPolicy, replaceOnChanges),
 		done: make(chan *RegisterResult),
 	}
 
@@ -689,8 +725,12 @@ func (rm *resmon) RegisterResourceOutputs(ctx context.Context,
 		return nil, errors.New("missing required URN")
 	}
 	label := fmt.Sprintf("ResourceMonitor.RegisterResourceOutputs(%s)", urn)
-	outs, err := plugin.UnmarshalProperties(
-		req.GetOutputs(), plugin.MarshalOptions{Label: label, KeepUnknowns: true, ComputeAssetHashes: true})
+	outs, err := plugin.UnmarshalProperties(req.GetOutputs(), plugin.MarshalOptions{
+		Label:              label,
+		KeepUnknowns:       true,
+		ComputeAssetHashes: true,
+		KeepSecrets:        true,
+	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "cannot unmarshal output properties")
 	}
@@ -61,7 +61,8 @@ func fixedProgram(steps []RegisterResourceEvent) deploytest.ProgramFunc {
 			}
 			s.Done(&RegisterResult{
 				State: resource.NewState(g.Type, urn, g.Custom, false, id, g.Properties, outs, g.Parent, g.Protect,
-					false, g.Dependencies, nil, g.Provider, g.PropertyDependencies, false),
+					false, g.Dependencies, nil, g.Provider, g.PropertyDependencies, false,
+					resource.CustomTimeouts{}, false, resource.RetryPolicy{}, nil),
 			})
 		}
 		return nil
@@ -143,16 +144,19 @@ func TestRegisterNoDefaultProviders(t *testing.T) {
 		// Register a component resource.
 		&testRegEvent{
 			goal: resource.NewGoal(componentURN.Type(), componentURN.Name(), false, resource.PropertyMap{}, "", false,
-				nil, "", []string{}, nil, false),
+				nil, "", []string{}, nil, false, nil, nil, resource.CustomTimeouts{}, "", false,
+				resource.RetryPolicy{}, nil),
 		},
 		// Register a couple resources using provider A.
 		&testRegEvent{
 			goal: resource.NewGoal("pkgA:index:typA", "res1", true, resource.PropertyMap{}, componentURN, false, nil,
-				providerARef.String(), []string{}, nil, false),
+				providerARef.String(), []string{}, nil, false, nil, nil, resource.CustomTimeouts{}, "", false,
+				resource.RetryPolicy{}, nil),
 		},
 		&testRegEvent{
 			goal: resource.NewGoal("pkgA:index:typA", "res2", true, resource.PropertyMap{}, componentURN, false, nil,
-				providerARef.String(), []string{}, nil, false),
+				providerARef.String(), []string{}, nil, false, nil, nil, resource.CustomTimeouts{}, "", false,
+				resource.RetryPolicy{}, nil),
 		},
 		// Register two more providers.
 		newProviderEvent("pkgA", "providerB", nil, ""),
@@ -160,11 +164,13 @@ func TestRegisterNoDefaultProviders(t *testing.T) {
 		// Register a few resources that use the new providers.
 		&testRegEvent{
 			goal: resource.NewGoal("pkgB:index:typB", "res3", true, resource.PropertyMap{}, "", false, nil,
-				providerBRef.String(), []string{}, nil, false),
+				providerBRef.String(), []string{}, nil, false, nil, nil, resource.CustomTimeouts{}, "", false,
+				resource.RetryPolicy{}, nil),
 		},
 		&testRegEvent{
 			goal: resource.NewGoal("pkgB:index:typC", "res4", true, resource.PropertyMap{}, "", false, nil,
-				providerCRef.String(), []string{}, nil, false),
+				providerCRef.String(), []string{}, nil, false, nil, nil, resource.CustomTimeouts{}, "", false,
+				resource.RetryPolicy{}, nil),
 		},
 	}
 
@@ -198,7 +204,7 @@ func TestRegisterNoDefaultProviders(t *testing.T) {
 		reg.Done(&RegisterResult{
 			State: resource.NewState(goal.Type, urn, goal.Custom, false, id, goal.Properties, resource.PropertyMap{},
 				goal.Parent, goal.Protect, false, goal.Dependencies, nil, goal.Provider, goal.PropertyDependencies,
-				false),
+				false, resource.CustomTimeouts{}, false, resource.RetryPolicy{}, nil),
 		})
 
 		processed++
@@ -227,25 +233,30 @@ func TestRegisterDefaultProviders(t *testing.T) {
 		// Register a component resource.
 		&testRegEvent{
 			goal: resource.NewGoal(componentURN.Type(), componentURN.Name(), false, resource.PropertyMap{}, "", false,
-				nil, "", []string{}, nil, false),
+				nil, "", []string{}, nil, false, nil, nil, resource.CustomTimeouts{}, "", false,
+				resource.RetryPolicy{}, nil),
 		},
 		// Register a couple resources from package A.
 		&testRegEvent{
 			goal: resource.NewGoal("pkgA:m:typA", "res1", true, resource.PropertyMap{},
-				componentURN, false, nil, "", []string{}, nil, false),
+				componentURN, false, nil, "", []string{}, nil, false, nil, nil, resource.CustomTimeouts{}, "", false,
+				resource.RetryPolicy{}, nil),
 		},
 		&testRegEvent{
 			goal: resource.NewGoal("pkgA:m:typA", "res2", true, resource.PropertyMap{},
-				componentURN, false, nil, "", []string{}, nil, false),
+				componentURN, false, nil, "", []string{}, nil, false, nil, nil, resource.CustomTimeouts{}, "", false,
+				resource.RetryPolicy{}, nil),
 		},
 		// Register a few resources from other packages.
 		&testRegEvent{
 			goal: resource.NewGoal("pkgB:m:typB", "res3", true, resource.PropertyMap{}, "", false,
-				nil, "", []string{}, nil, false),
+				nil, "", []string{}, nil, false, nil, nil, resource.CustomTimeouts{}, "", false,
+				resource.RetryPolicy{}, nil),
 		},
 		&testRegEvent{
 			goal: resource.NewGoal("pkgB:m:typC", "res4", true, resource.PropertyMap{}, "", false,
-				nil, "", []string{}, nil, false),
+				nil, "", []string{}, nil, false, nil, nil, resource.CustomTimeouts{}, "", false,
+				resource.RetryPolicy{}, nil),
 		},
 	}
 
@@ -290,7 +301,7 @@ func TestRegisterDefaultProviders(t *testing.T) {
 		reg.Done(&RegisterResult{
 			State: resource.NewState(goal.Type, urn, goal.Custom, false, id, goal.Properties, resource.PropertyMap{},
 				goal.Parent, goal.Protect, false, goal.Dependencies, nil, goal.Provider, goal.PropertyDependencies,
-				false),
+				false, resource.CustomTimeouts{}, false, resource.RetryPolicy{}, nil),
 		})
 
 		processed++
@@ -380,7 +391,7 @@ func TestReadInvokeNoDefaultProviders(t *testing.T) {
 		read.Done(&ReadResult{
 			State: resource.NewState(read.Type(), urn, true, false, read.ID(), read.Properties(),
 				resource.PropertyMap{}, read.Parent(), false, false, read.Dependencies(), nil, read.Provider(), nil,
-				false),
+				false, resource.CustomTimeouts{}, false, resource.RetryPolicy{}, nil),
 		})
 		reads++
 	}
@@ -465,7 +476,7 @@ func TestReadInvokeDefaultProviders(t *testing.T) {
 			e.Done(&RegisterResult{
 				State: resource.NewState(goal.Type, urn, goal.Custom, false, id, goal.Properties, resource.PropertyMap{},
 					goal.Parent, goal.Protect, false, goal.Dependencies, nil, goal.Provider, goal.PropertyDependencies,
-					false),
+					false, resource.CustomTimeouts{}, false, resource.RetryPolicy{}, nil),
 			})
 			registers++
 
@@ -473,7 +484,8 @@ func TestReadInvokeDefaultProviders(t *testing.T) {
 			urn := newURN(e.Type(), string(e.Name()), e.Parent())
 			e.Done(&ReadResult{
 				State: resource.NewState(e.Type(), urn, true, false, e.ID(), e.Properties(),
-					resource.PropertyMap{}, e.Parent(), false, false, e.Dependencies(), nil, e.Provider(), nil, false),
+					resource.PropertyMap{}, e.Parent(), false, false, e.Dependencies(), nil, e.Provider(), nil, false,
+					resource.CustomTimeouts{}, false, resource.RetryPolicy{}, nil),
 			})
 			reads++
 		}
@@ -0,0 +1,163 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+)
+
+// Import specifies an existing resource that should be adopted into a stack.
+type Import struct {
+	Type tokens.Type  // the type token for the resource.
+	Name tokens.QName // the name of the resource.
+	ID   resource.ID  // the ID of the existing resource.
+}
+
+// NewImportSource returns a planning source that registers a fixed list of existing resources to import, along with
+// the default providers that those resources require. Each resource's inputs are computed from its current state.
+func NewImportSource(proj tokens.PackageName, config plugin.ConfigSource,
+	defaultProviderVersions map[tokens.Package]*semver.Version, imports []Import) Source {
+
+	return &importSource{
+		proj:                    proj,
+		config:                  config,
+		defaultProviderVersions: defaultProviderVersions,
+		imports:                 imports,
+	}
+}
+
+// An importSource registers a fixed list of resources to import.
+type importSource struct {
+	proj                    tokens.PackageName                 // the project that owns the imported resources.
+	config                  plugin.ConfigSource                // the configuration source for default providers.
+	defaultProviderVersions map[tokens.Package]*semver.Version // the default provider versions for this source.
+	imports                 []Import                           // the resources to import.
+}
+
+func (src *importSource) Close() error                { return nil }
+func (src *importSource) Project() tokens.PackageName { return src.proj }
+func (src *importSource) Info() interface{}           { return nil }
+
+// Iterate spawns a goroutine that registers each resource to import and prepares to hand the resulting events to the
+// planner on subsequent calls to Next.
+func (src *importSource) Iterate(ctx context.Context, opts Options, providers ProviderSource) (SourceIterator, error) {
+	contract.Ignore(ctx) // TODO[pulumi/pulumi#1714]
+
+	iter := &importSourceIterator{
+		src:     src,
+		regChan: make(chan *registerResourceEvent),
+		finChan: make(chan error),
+		cancel:  make(chan bool),
+	}
+	go iter.run()
+	return iter, nil
+}
+
+type importSourceIterator struct {
+	src     *importSource               // the owning import source.
+	regChan chan *registerResourceEvent // the channel that contains resource registrations.
+	finChan chan error                  // the channel that communicates completion.
+	cancel  chan bool                   // a channel that cancels any outstanding registrations.
+	done    bool                        // set to true when all registrations have completed.
+}
+
+func (iter *importSourceIterator) Close() error {
+	close(iter.cancel)
+	return nil
+}
+
+func (iter *importSourceIterator) Next() (SourceEvent, error) {
+	// If we are done, quit.
+	if iter.done {
+		return nil, nil
+	}
+
+	select {
+	case reg := <-iter.regChan:
+		contract.Assert(reg != nil)
+		goal := reg.Goal()
+		logging.V(5).Infof("ImportSourceIterator produced a registration: t=%v,name=%v,id=%v",
+			goal.Type, goal.Name, goal.ID)
+		return reg, nil
+	case err := <-iter.finChan:
+		iter.done = true
+		if err != nil {
+			logging.V(5).Infof("ImportSourceIterator ended with an error: %v", err)
+		}
+		return nil, err
+	}
+}
+
+// run registers each resource to import and then waits for all of the registrations to complete.
+func (iter *importSourceIterator) run() {
+	register := func() error {
+		d := &defaultProviders{
+			versions:  iter.src.defaultProviderVersions,
+			providers: make(map[tokens.Package]providers.Reference),
+			config:    iter.src.config,
+			regChan:   iter.regChan,
+			cancel:    iter.cancel,
+		}
+
+		// Register each resource using its package's default provider. We do not wait for each registration to
+		// complete before sending the next so that the imports may proceed in parallel.
+		var dones []chan *RegisterResult
+		for _, imp := range iter.src.imports {
+			ref, err := d.handleRequest(imp.Type.Package())
+			if err != nil {
+				return errors.Wrapf(err, "loading default provider for resource '%v'", imp.Name)
+			}
+
+			done := make(chan *RegisterResult, 1)
+			event := &registerResourceEvent{
+				goal: resource.NewGoal(imp.Type, imp.Name, true, resource.PropertyMap{}, "", false, nil,
+					ref.String(), nil, nil, false, nil, nil, resource.CustomTimeouts{}, imp.ID, false,
+					resource.RetryPolicy{}, nil),
+				done: done,
+			}
+			select {
+			case iter.regChan <- event:
+			case <-iter.cancel:
+				return context.Canceled
+			}
+			dones = append(dones, done)
+		}
+
+		for _, done := range dones {
+			select {
+			case <-done:
+			case <-iter.cancel:
+				return context.Canceled
+			}
+		}
+		return nil
+	}
+
+	err := register()
+	select {
+	case iter.finChan <- err:
+	case <-iter.cancel:
+	}
+}
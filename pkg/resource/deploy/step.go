@@ -15,8 +15,11 @@
 package deploy
 
 import (
+	"fmt"
+
 	"github.com/pkg/errors"
 
+	"github.com/pulumi/pulumi/pkg/diag"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
@@ -52,15 +55,17 @@ type Step interface {
 
 // SameStep is a mutating step that does nothing.
 type SameStep struct {
-	plan *Plan                 // the current plan.
-	reg  RegisterResourceEvent // the registration intent to convey a URN back to.
-	old  *resource.State       // the state of the resource before this step.
-	new  *resource.State       // the state of the resource after this step.
+	plan    *Plan                 // the current plan.
+	reg     RegisterResourceEvent // the registration intent to convey a URN back to.
+	old     *resource.State       // the state of the resource before this step.
+	new     *resource.State       // the state of the resource after this step.
+	ignores []string              // an optional list of property paths whose changes were ignored.
 }
 
 var _ Step = (*SameStep)(nil)
 
-func NewSameStep(plan *Plan, reg RegisterResourceEvent, old *resource.State, new *resource.State) Step {
+func NewSameStep(plan *Plan, reg RegisterResourceEvent, old *resource.State, new *resource.State,
+	ignoreChanges []string) Step {
 	contract.Assert(old != nil)
 	contract.Assert(old.URN != "")
 	contract.Assert(old.ID != "" || !old.Custom)
@@ -71,26 +76,27 @@ func NewSameStep(plan *Plan, reg RegisterResourceEvent, old *resource.State, new
 	contract.Assert(new.ID == "")
 	contract.Assert(!new.Delete)
 	return &SameStep{
-		plan: plan,
-		reg:  reg,
-		old:  old,
-		new:  new,
+		plan:    plan,
+		reg:     reg,
+		old:     old,
+		new:     new,
+		ignores: ignoreChanges,
 	}
 }
 
-func (s *SameStep) Op() StepOp           { return OpSame }
-func (s *SameStep) Plan() *Plan          { return s.plan }
-func (s *SameStep) Type() tokens.Type    { return s.old.Type }
-func (s *SameStep) Provider() string     { return s.old.Provider }
-func (s *SameStep) URN() resource.URN    { return s.old.URN }
-func (s *SameStep) Old() *resource.State { return s.old }
-func (s *SameStep) New() *resource.State { return s.new }
-func (s *SameStep) Res() *resource.State { return s.new }
-func (s *SameStep) Logical() bool        { return true }
+func (s *SameStep) Op() StepOp              { return OpSame }
+func (s *SameStep) Plan() *Plan             { return s.plan }
+func (s *SameStep) Type() tokens.Type       { return s.old.Type }
+func (s *SameStep) Provider() string        { return s.old.Provider }
+func (s *SameStep) URN() resource.URN       { return s.new.URN }
+func (s *SameStep) Old() *resource.State    { return s.old }
+func (s *SameStep) New() *resource.State    { return s.new }
+func (s *SameStep) Res() *resource.State    { return s.new }
+func (s *SameStep) IgnoreChanges() []string { return s.ignores }
+func (s *SameStep) Logical() bool           { return true }
 
 func (s *SameStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
-	// Retain the URN, ID, and outputs:
-	s.new.URN = s.old.URN
+	// Retain the ID and outputs:
 	s.new.ID = s.old.ID
 	s.new.Outputs = s.old.Outputs
 	complete := func() { s.reg.Done(&RegisterResult{State: s.new, Stable: true}) }
@@ -99,13 +105,16 @@ func (s *SameStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error
 
 // CreateStep is a mutating step that creates an entirely new resource.
 type CreateStep struct {
-	plan          *Plan                  // the current plan.
-	reg           RegisterResourceEvent  // the registration intent to convey a URN back to.
-	old           *resource.State        // the state of the existing resource (only for replacements).
-	new           *resource.State        // the state of the resource after this step.
-	keys          []resource.PropertyKey // the keys causing replacement (only for replacements).
-	replacing     bool                   // true if this is a create due to a replacement.
-	pendingDelete bool                   // true if this replacement should create a pending delete.
+	plan          *Plan                          // the current plan.
+	reg           RegisterResourceEvent          // the registration intent to convey a URN back to.
+	old           *resource.State                // the state of the existing resource (only for replacements).
+	new           *resource.State                // the state of the resource after this step.
+	keys          []resource.PropertyKey         // the keys causing replacement (only for replacements).
+	detailedDiff  map[string]plugin.PropertyDiff // the structured property diff (only for replacements).
+	forceReplace  bool                           // true if the replacement was explicitly requested.
+	ignores       []string                       // the property paths whose changes were ignored (only for replacements).
+	replacing     bool                           // true if this is a create due to a replacement.
+	pendingDelete bool                           // true if this replacement should create a pending delete.
 }
 
 var _ Step = (*CreateStep)(nil)
@@ -126,7 +135,8 @@ func NewCreateStep(plan *Plan, reg RegisterResourceEvent, new *resource.State) S
 }
 
 func NewCreateReplacementStep(plan *Plan, reg RegisterResourceEvent,
-	old *resource.State, new *resource.State, keys []resource.PropertyKey, pendingDelete bool) Step {
+	old *resource.State, new *resource.State, keys []resource.PropertyKey,
+	detailedDiff map[string]plugin.PropertyDiff, forceReplace bool, ignoreChanges []string, pendingDelete bool) Step {
 	contract.Assert(reg != nil)
 	contract.Assert(old != nil)
 	contract.Assert(old.URN != "")
@@ -145,6 +155,9 @@ func NewCreateReplacementStep(plan *Plan, reg RegisterResourceEvent,
 		old:           old,
 		new:           new,
 		keys:          keys,
+		detailedDiff:  detailedDiff,
+		forceReplace:  forceReplace,
+		ignores:       ignoreChanges,
 		replacing:     true,
 		pendingDelete: pendingDelete,
 	}
@@ -156,15 +169,18 @@ func (s *CreateStep) Op() StepOp {
 	}
 	return OpCreate
 }
-func (s *CreateStep) Plan() *Plan                  { return s.plan }
-func (s *CreateStep) Type() tokens.Type            { return s.new.Type }
-func (s *CreateStep) Provider() string             { return s.new.Provider }
-func (s *CreateStep) URN() resource.URN            { return s.new.URN }
-func (s *CreateStep) Old() *resource.State         { return s.old }
-func (s *CreateStep) New() *resource.State         { return s.new }
-func (s *CreateStep) Res() *resource.State         { return s.new }
-func (s *CreateStep) Keys() []resource.PropertyKey { return s.keys }
-func (s *CreateStep) Logical() bool                { return !s.replacing }
+func (s *CreateStep) Plan() *Plan                                  { return s.plan }
+func (s *CreateStep) Type() tokens.Type                            { return s.new.Type }
+func (s *CreateStep) Provider() string                             { return s.new.Provider }
+func (s *CreateStep) URN() resource.URN                            { return s.new.URN }
+func (s *CreateStep) Old() *resource.State                         { return s.old }
+func (s *CreateStep) New() *resource.State                         { return s.new }
+func (s *CreateStep) Res() *resource.State                         { return s.new }
+func (s *CreateStep) Keys() []resource.PropertyKey                 { return s.keys }
+func (s *CreateStep) DetailedDiff() map[string]plugin.PropertyDiff { return s.detailedDiff }
+func (s *CreateStep) ForceReplace() bool                           { return s.forceReplace }
+func (s *CreateStep) IgnoreChanges() []string                      { return s.ignores }
+func (s *CreateStep) Logical() bool                                { return !s.replacing }
 
 func (s *CreateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	var resourceError error
@@ -176,7 +192,7 @@ func (s *CreateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 			if err != nil {
 				return resource.StatusOK, nil, err
 			}
-			id, outs, rst, err := prov.Create(s.URN(), s.new.Inputs)
+			id, outs, rst, err := prov.Create(s.URN(), s.new.Inputs, s.new.CustomTimeouts.Create)
 			if err != nil {
 				if rst != resource.StatusPartialFailure {
 					return rst, nil, err
@@ -210,12 +226,14 @@ func (s *CreateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 	return resourceStatus, complete, resourceError
 }
 
-// DeleteStep is a mutating step that deletes an existing resource. If `old` is marked "External",
-// DeleteStep is a no-op.
+// DeleteStep is a mutating step that deletes an existing resource. If `old` is marked "External" or the step is to
+// retain the resource, DeleteStep only removes the resource from the snapshot and does not ask its provider to delete
+// it.
 type DeleteStep struct {
 	plan      *Plan           // the current plan.
 	old       *resource.State // the state of the existing resource.
 	replacing bool            // true if part of a replacement.
+	retain    bool            // true if the resource is removed from the snapshot rather than deleted.
 }
 
 var _ Step = (*DeleteStep)(nil)
@@ -226,12 +244,13 @@ func NewDeleteStep(plan *Plan, old *resource.State) Step {
 	contract.Assert(old.ID != "" || !old.Custom)
 	contract.Assert(!old.Custom || old.Provider != "" || providers.IsProviderType(old.Type))
 	return &DeleteStep{
-		plan: plan,
-		old:  old,
+		plan:   plan,
+		old:    old,
+		retain: old.RetainOnDelete,
 	}
 }
 
-func NewDeleteReplacementStep(plan *Plan, old *resource.State, pendingReplace bool) Step {
+func NewDeleteReplacementStep(plan *Plan, old *resource.State, pendingReplace, retain bool) Step {
 	contract.Assert(old != nil)
 	contract.Assert(old.URN != "")
 	contract.Assert(old.ID != "" || !old.Custom)
@@ -255,6 +274,7 @@ func NewDeleteReplacementStep(plan *Plan, old *resource.State, pendingReplace bo
 		plan:      plan,
 		old:       old,
 		replacing: true,
+		retain:    retain,
 	}
 }
 
@@ -279,6 +299,7 @@ func (s *DeleteStep) Old() *resource.State { return s.old }
 func (s *DeleteStep) New() *resource.State { return nil }
 func (s *DeleteStep) Res() *resource.State { return s.old }
 func (s *DeleteStep) Logical() bool        { return !s.replacing }
+func (s *DeleteStep) RetainOnDelete() bool { return s.retain }
 
 func (s *DeleteStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	// Refuse to delete protected resources.
@@ -287,15 +308,16 @@ func (s *DeleteStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 			errors.Errorf("refusing to delete protected resource '%s'", s.old.URN)
 	}
 
-	// Deleting an External resource is a no-op, since Pulumi does not own the lifecycle.
-	if !preview && !s.old.External {
+	// Deleting an External resource is a no-op, since Pulumi does not own the lifecycle. Deleting a resource that is
+	// to be retained simply forgets it.
+	if !preview && !s.old.External && !s.retain {
 		if s.old.Custom {
 			// Invoke the Delete RPC function for this provider:
 			prov, err := getProvider(s)
 			if err != nil {
 				return resource.StatusOK, nil, err
 			}
-			if rst, err := prov.Delete(s.URN(), s.old.ID, s.old.All()); err != nil {
+			if rst, err := prov.Delete(s.URN(), s.old.ID, s.old.All(), s.old.CustomTimeouts.Delete); err != nil {
 				return rst, nil, err
 			}
 		}
@@ -336,17 +358,20 @@ func (s *RemovePendingReplaceStep) Apply(preview bool) (resource.Status, StepCom
 
 // UpdateStep is a mutating step that updates an existing resource's state.
 type UpdateStep struct {
-	plan    *Plan                  // the current plan.
-	reg     RegisterResourceEvent  // the registration intent to convey a URN back to.
-	old     *resource.State        // the state of the existing resource.
-	new     *resource.State        // the newly computed state of the resource after updating.
-	stables []resource.PropertyKey // an optional list of properties that won't change during this update.
+	plan         *Plan                          // the current plan.
+	reg          RegisterResourceEvent          // the registration intent to convey a URN back to.
+	old          *resource.State                // the state of the existing resource.
+	new          *resource.State                // the newly computed state of the resource after updating.
+	stables      []resource.PropertyKey         // an optional list of properties that won't change during this update.
+	detailedDiff map[string]plugin.PropertyDiff // the structured property diff, if reported by the provider.
+	ignores      []string                       // an optional list of property paths whose changes were ignored.
 }
 
 var _ Step = (*UpdateStep)(nil)
 
 func NewUpdateStep(plan *Plan, reg RegisterResourceEvent, old *resource.State,
-	new *resource.State, stables []resource.PropertyKey) Step {
+	new *resource.State, stables []resource.PropertyKey, detailedDiff map[string]plugin.PropertyDiff,
+	ignoreChanges []string) Step {
 	contract.Assert(old != nil)
 	contract.Assert(old.URN != "")
 	contract.Assert(old.ID != "" || !old.Custom)
@@ -360,27 +385,30 @@ func NewUpdateStep(plan *Plan, reg RegisterResourceEvent, old *resource.State,
 	contract.Assert(!new.External)
 	contract.Assert(!old.External)
 	return &UpdateStep{
-		plan:    plan,
-		reg:     reg,
-		old:     old,
-		new:     new,
-		stables: stables,
-	}
-}
-
-func (s *UpdateStep) Op() StepOp           { return OpUpdate }
-func (s *UpdateStep) Plan() *Plan          { return s.plan }
-func (s *UpdateStep) Type() tokens.Type    { return s.old.Type }
-func (s *UpdateStep) Provider() string     { return s.old.Provider }
-func (s *UpdateStep) URN() resource.URN    { return s.old.URN }
-func (s *UpdateStep) Old() *resource.State { return s.old }
-func (s *UpdateStep) New() *resource.State { return s.new }
-func (s *UpdateStep) Res() *resource.State { return s.new }
-func (s *UpdateStep) Logical() bool        { return true }
+		plan:         plan,
+		reg:          reg,
+		old:          old,
+		new:          new,
+		stables:      stables,
+		detailedDiff: detailedDiff,
+		ignores:      ignoreChanges,
+	}
+}
+
+func (s *UpdateStep) Op() StepOp                                   { return OpUpdate }
+func (s *UpdateStep) Plan() *Plan                                  { return s.plan }
+func (s *UpdateStep) Type() tokens.Type                            { return s.old.Type }
+func (s *UpdateStep) Provider() string                             { return s.old.Provider }
+func (s *UpdateStep) URN() resource.URN                            { return s.new.URN }
+func (s *UpdateStep) Old() *resource.State                         { return s.old }
+func (s *UpdateStep) New() *resource.State                         { return s.new }
+func (s *UpdateStep) Res() *resource.State                         { return s.new }
+func (s *UpdateStep) IgnoreChanges() []string                      { return s.ignores }
+func (s *UpdateStep) DetailedDiff() map[string]plugin.PropertyDiff { return s.detailedDiff }
+func (s *UpdateStep) Logical() bool                                { return true }
 
 func (s *UpdateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
-	// Always propagate the URN and ID, even in previews and refreshes.
-	s.new.URN = s.old.URN
+	// Always propagate the ID, even in previews and refreshes.
 	s.new.ID = s.old.ID
 
 	var resourceError error
@@ -394,7 +422,8 @@ func (s *UpdateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 			}
 
 			// Update to the combination of the old "all" state (including outputs), but overwritten with new inputs.
-			outs, rst, upderr := prov.Update(s.URN(), s.old.ID, s.old.All(), s.new.Inputs)
+			outs, rst, upderr := prov.Update(s.URN(), s.old.ID, s.old.All(), s.new.Inputs,
+				s.new.CustomTimeouts.Update)
 			if upderr != nil {
 				if rst != resource.StatusPartialFailure {
 					return rst, nil, upderr
@@ -425,17 +454,20 @@ func (s *UpdateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 // a creation of the new resource, any number of intervening updates of dependents to the new resource, and then
 // a deletion of the now-replaced old resource.  This logical step is primarily here for tools and visualization.
 type ReplaceStep struct {
-	plan          *Plan                  // the current plan.
-	old           *resource.State        // the state of the existing resource.
-	new           *resource.State        // the new state snapshot.
-	keys          []resource.PropertyKey // the keys causing replacement.
-	pendingDelete bool                   // true if a pending deletion should happen.
+	plan          *Plan                          // the current plan.
+	old           *resource.State                // the state of the existing resource.
+	new           *resource.State                // the new state snapshot.
+	keys          []resource.PropertyKey         // the keys causing replacement.
+	detailedDiff  map[string]plugin.PropertyDiff // the structured property diff, if reported by the provider.
+	forceReplace  bool                           // true if the replacement was explicitly requested.
+	ignores       []string                       // an optional list of property paths whose changes were ignored.
+	pendingDelete bool                           // true if a pending deletion should happen.
 }
 
 var _ Step = (*ReplaceStep)(nil)
 
-func NewReplaceStep(plan *Plan, old *resource.State, new *resource.State,
-	keys []resource.PropertyKey, pendingDelete bool) Step {
+func NewReplaceStep(plan *Plan, old *resource.State, new *resource.State, keys []resource.PropertyKey,
+	detailedDiff map[string]plugin.PropertyDiff, forceReplace bool, ignoreChanges []string, pendingDelete bool) Step {
 	contract.Assert(old != nil)
 	contract.Assert(old.URN != "")
 	contract.Assert(old.ID != "" || !old.Custom)
@@ -449,20 +481,26 @@ func NewReplaceStep(plan *Plan, old *resource.State, new *resource.State,
 		old:           old,
 		new:           new,
 		keys:          keys,
+		detailedDiff:  detailedDiff,
+		forceReplace:  forceReplace,
+		ignores:       ignoreChanges,
 		pendingDelete: pendingDelete,
 	}
 }
 
-func (s *ReplaceStep) Op() StepOp                   { return OpReplace }
-func (s *ReplaceStep) Plan() *Plan                  { return s.plan }
-func (s *ReplaceStep) Type() tokens.Type            { return s.old.Type }
-func (s *ReplaceStep) Provider() string             { return s.old.Provider }
-func (s *ReplaceStep) URN() resource.URN            { return s.old.URN }
-func (s *ReplaceStep) Old() *resource.State         { return s.old }
-func (s *ReplaceStep) New() *resource.State         { return s.new }
-func (s *ReplaceStep) Res() *resource.State         { return s.new }
-func (s *ReplaceStep) Keys() []resource.PropertyKey { return s.keys }
-func (s *ReplaceStep) Logical() bool                { return true }
+func (s *ReplaceStep) Op() StepOp                                   { return OpReplace }
+func (s *ReplaceStep) Plan() *Plan                                  { return s.plan }
+func (s *ReplaceStep) Type() tokens.Type                            { return s.old.Type }
+func (s *ReplaceStep) Provider() string                             { return s.old.Provider }
+func (s *ReplaceStep) URN() resource.URN                            { return s.new.URN }
+func (s *ReplaceStep) Old() *resource.State                         { return s.old }
+func (s *ReplaceStep) New() *resource.State                         { return s.new }
+func (s *ReplaceStep) Res() *resource.State                         { return s.new }
+func (s *ReplaceStep) Keys() []resource.PropertyKey                 { return s.keys }
+func (s *ReplaceStep) DetailedDiff() map[string]plugin.PropertyDiff { return s.detailedDiff }
+func (s *ReplaceStep) ForceReplace() bool                           { return s.forceReplace }
+func (s *ReplaceStep) IgnoreChanges() []string                      { return s.ignores }
+func (s *ReplaceStep) Logical() bool                                { return true }
 
 func (s *ReplaceStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	// If this is a pending delete, we should have marked the old resource for deletion in the CreateReplacement step.
@@ -664,7 +702,8 @@ func (s *RefreshStep) Apply(preview bool) (resource.Status, StepCompleteFunc, er
 	if refreshed != nil {
 		s.new = resource.NewState(s.old.Type, s.old.URN, s.old.Custom, s.old.Delete, s.old.ID, s.old.Inputs, refreshed,
 			s.old.Parent, s.old.Protect, s.old.External, s.old.Dependencies, initErrors, s.old.Provider,
-			s.old.PropertyDependencies, s.old.PendingReplacement)
+			s.old.PropertyDependencies, s.old.PendingReplacement, s.old.CustomTimeouts, s.old.RetainOnDelete,
+			s.old.RetryPolicy, s.old.ReplaceOnChanges)
 	} else {
 		s.new = nil
 	}
@@ -672,6 +711,104 @@ func (s *RefreshStep) Apply(preview bool) (resource.Status, StepCompleteFunc, er
 	return rst, complete, err
 }
 
+// ImportStep is a mutating step that adopts an existing resource into the stack by reading its current state from its
+// provider plugin. The resolved state is recorded as a managed, non-External resource. If the import was requested by
+// a program, the program's inputs must match the resource's current state; otherwise, the resource's inputs are
+// computed from its current state.
+type ImportStep struct {
+	plan    *Plan                 // the current plan.
+	reg     RegisterResourceEvent // the registration intent to convey a URN back to.
+	new     *resource.State       // the state of the resource after this step.
+	planned bool                  // true if the import was requested by a program.
+}
+
+var _ Step = (*ImportStep)(nil)
+
+// NewImportStep creates a new Import step. If planned is true, the inputs in the new state are the program's inputs
+// for the resource, and must match the resource's current state.
+func NewImportStep(plan *Plan, reg RegisterResourceEvent, new *resource.State, planned bool) Step {
+	contract.Assert(reg != nil)
+	contract.Assert(new != nil)
+	contract.Assert(new.URN != "")
+	contract.Assert(new.ID != "")
+	contract.Assert(new.Custom)
+	contract.Assert(new.Provider != "" || providers.IsProviderType(new.Type))
+	contract.Assert(!new.Delete)
+	contract.Assert(!new.External)
+	return &ImportStep{
+		plan:    plan,
+		reg:     reg,
+		new:     new,
+		planned: planned,
+	}
+}
+
+func (s *ImportStep) Op() StepOp           { return OpImport }
+func (s *ImportStep) Plan() *Plan          { return s.plan }
+func (s *ImportStep) Type() tokens.Type    { return s.new.Type }
+func (s *ImportStep) Provider() string     { return s.new.Provider }
+func (s *ImportStep) URN() resource.URN    { return s.new.URN }
+func (s *ImportStep) Old() *resource.State { return nil }
+func (s *ImportStep) New() *resource.State { return s.new }
+func (s *ImportStep) Res() *resource.State { return s.new }
+func (s *ImportStep) Logical() bool        { return true }
+
+func (s *ImportStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
+	// Like Read steps, Import steps run during previews so that we can report whether or not the import will succeed.
+	prov, err := getProvider(s)
+	if err != nil {
+		return resource.StatusOK, nil, err
+	}
+
+	var resourceError error
+	resourceStatus := resource.StatusOK
+	outputs, rst, err := prov.Read(s.new.URN, s.new.ID, nil)
+	if err != nil {
+		if rst != resource.StatusPartialFailure {
+			return rst, nil, err
+		}
+
+		resourceError = err
+		resourceStatus = rst
+
+		if initErr, isInitErr := err.(*plugin.InitError); isInitErr {
+			s.new.InitErrors = initErr.Reasons
+		}
+	}
+	if outputs == nil {
+		return resource.StatusOK, nil, errors.Errorf("resource '%v' does not exist", s.new.ID)
+	}
+	s.new.Outputs = outputs
+
+	if s.planned {
+		// If the program supplied inputs for this resource, ensure that they match the resource's current state.
+		diff, err := prov.Diff(s.new.URN, s.new.ID, outputs, s.new.Inputs, preview)
+		if err != nil {
+			return resource.StatusOK, nil, err
+		}
+		if diff.Changes == plugin.DiffSome {
+			return resource.StatusOK, nil, errors.New("inputs to import do not match the existing resource")
+		}
+	} else {
+		// Otherwise, compute the resource's inputs from its current state.
+		inputs, failures, err := prov.Check(s.new.URN, nil, outputs, preview)
+		if err != nil {
+			return resource.StatusOK, nil, err
+		}
+		for _, failure := range failures {
+			s.plan.Diag().Warningf(diag.RawMessage(s.new.URN,
+				fmt.Sprintf("imported property '%v' failed to validate: %v", failure.Property, failure.Reason)))
+		}
+		s.new.Inputs = inputs
+	}
+
+	complete := func() { s.reg.Done(&RegisterResult{State: s.new}) }
+	if resourceError == nil {
+		return resourceStatus, complete, nil
+	}
+	return resourceStatus, complete, resourceError
+}
+
 // StepOp represents the kind of operation performed by a step.  It evaluates to its string label.
 type StepOp string
 
@@ -689,6 +826,7 @@ const (
 	OpReadDiscard          StepOp = "discard"                // removing a resource that was read.
 	OpDiscardReplaced      StepOp = "discard-replaced"       // discarding a read resource that was replaced.
 	OpRemovePendingReplace StepOp = "remove-pending-replace" // removing a pending replace resource.
+	OpImport               StepOp = "import"                 // importing an existing resource.
 )
 
 // StepOps contains the full set of step operation types.
@@ -706,6 +844,7 @@ var StepOps = []StepOp{
 	OpReadDiscard,
 	OpDiscardReplaced,
 	OpRemovePendingReplace,
+	OpImport,
 }
 
 // Color returns a suggested color for lines of this op type.
@@ -733,6 +872,8 @@ func (op StepOp) Color() string {
 		return colors.SpecUpdate
 	case OpReadDiscard, OpDiscardReplaced:
 		return colors.SpecDelete
+	case OpImport:
+		return colors.SpecCreate
 	default:
 		contract.Failf("Unrecognized resource step op: '%v'", op)
 		return ""
@@ -771,6 +912,8 @@ func (op StepOp) RawPrefix() string {
 		return "< "
 	case OpDiscardReplaced:
 		return "<<"
+	case OpImport:
+		return "= "
 	default:
 		contract.Failf("Unrecognized resource step op: %v", op)
 		return ""
@@ -787,6 +930,8 @@ func (op StepOp) PastTense() string {
 		return "read"
 	case OpReadDiscard, OpDiscardReplaced:
 		return "discarded"
+	case OpImport:
+		return "imported"
 	default:
 		contract.Failf("Unexpected resource step op: %v", op)
 		return ""
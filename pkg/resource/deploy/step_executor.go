@@ -19,11 +19,19 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+
 	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/util/retry"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil/rpcerror"
 )
 
 const (
@@ -42,6 +50,18 @@ var (
 	errStepApplyFailed = errors.New("step application failed")
 )
 
+// StepTimeoutError is the error that is reported when a step does not complete within the custom timeout that the
+// step's resource specified for the step's operation.
+type StepTimeoutError struct {
+	URN     resource.URN  // the URN of the resource whose step timed out.
+	Op      StepOp        // the operation that timed out.
+	Timeout time.Duration // the timeout that elapsed.
+}
+
+func (e *StepTimeoutError) Error() string {
+	return fmt.Sprintf("%s of resource '%s' timed out after %v", e.Op, e.URN, e.Timeout)
+}
+
 // The step executor operates in terms of "chains" and "antichains". A chain is set of steps that are totally ordered
 // when ordered by dependency; each step in a chain depends directly on the step that comes before it. An antichain
 // is a set of steps that is completely incomparable when ordered by dependency. The step executor is aware that chains
@@ -72,8 +92,9 @@ func (c completionToken) Wait(ctx context.Context) {
 
 // incomingChain represents a request to the step executor to execute a chain.
 type incomingChain struct {
-	Chain          chain     // The chain we intend to execute
-	CompletionChan chan bool // A completion channel to be closed when the chain has completed execution
+	Chain          chain       // The chain we intend to execute
+	Event          SourceEvent // The source event that produced the chain, if any
+	CompletionChan chan bool   // A completion channel to be closed when the chain has completed execution
 }
 
 // stepExecutor is the component of the engine responsible for taking steps and executing
@@ -96,6 +117,12 @@ type stepExecutor struct {
 	ctx      context.Context    // cancellation context for the current plan.
 	cancel   context.CancelFunc // CancelFunc that cancels the above context.
 	sawError atomic.Value       // atomic boolean indicating whether or not the step excecutor saw that there was an error.
+
+	failedLock sync.Mutex            // guards failed.
+	failed     map[resource.URN]bool // resources whose steps failed or were skipped, if continuing after errors.
+
+	providerSlotsLock sync.Mutex               // guards providerSlots.
+	providerSlots     map[string]chan struct{} // per-provider semaphores, keyed by provider reference.
 }
 
 //
@@ -107,12 +134,19 @@ type stepExecutor struct {
 // Execute submits a Chain for asynchronous execution. The execution of the chain will begin as soon as there
 // is a worker available to execute it.
 func (se *stepExecutor) ExecuteSerial(chain chain) completionToken {
+	return se.ExecuteEventChain(nil, chain)
+}
+
+// ExecuteEventChain submits a Chain that was produced by the given source event for asynchronous execution. If the
+// chain fails or is skipped before the event is completed, the step executor completes the event itself so that the
+// source is not left waiting on it.
+func (se *stepExecutor) ExecuteEventChain(event SourceEvent, chain chain) completionToken {
 	// The select here is to avoid blocking on a send to se.incomingChains if a cancellation is pending.
 	// If one is pending, we should exit early - we will shortly be tearing down the engine and exiting.
 
 	completion := make(chan bool)
 	select {
-	case se.incomingChains <- incomingChain{Chain: chain, CompletionChan: completion}:
+	case se.incomingChains <- incomingChain{Chain: chain, Event: event, CompletionChan: completion}:
 	case <-se.ctx.Done():
 		close(completion)
 	}
@@ -150,6 +184,12 @@ func (se *stepExecutor) ExecuteRegisterResourceOutputs(e RegisterResourceOutputs
 	// Look up the final state in the pending registration list.
 	urn := e.URN()
 	value, has := se.pendingNews.Load(urn)
+	if !has && se.isFailed(urn) {
+		// The resource's step failed or was skipped, so there is nothing to complete.
+		se.log(synchronousWorkerID, "ignoring resource outputs for failed resource %s", urn)
+		e.Done()
+		return
+	}
 	contract.Assertf(has, "cannot complete a resource '%v' whose registration isn't pending", urn)
 	reg := value.(Step)
 	contract.Assertf(reg != nil, "expected a non-nil resource step ('%v')", urn)
@@ -207,9 +247,11 @@ func (se *stepExecutor) WaitForCompletion() {
 //
 
 // executeChain executes a chain, one step at a time. If any step in the chain fails to execute, or if the
-// context is canceled, the chain stops execution.
-func (se *stepExecutor) executeChain(workerID int, chain chain) {
-	for _, step := range chain {
+// context is canceled, the chain stops execution. If the step executor is continuing after errors, the remainder of
+// a failed chain is skipped, as is any chain whose next step depends on a resource that failed or was skipped.
+func (se *stepExecutor) executeChain(workerID int, event SourceEvent, chain chain) {
+	completed := false
+	for i, step := range chain {
 		select {
 		case <-se.ctx.Done():
 			se.log(workerID, "step %v on %v canceled", step.Op(), step.URN())
@@ -217,6 +259,15 @@ func (se *stepExecutor) executeChain(workerID int, chain chain) {
 		default:
 		}
 
+		if se.continueOnError && se.dependsOnFailure(step) {
+			se.log(workerID, "step %v on %v depends on a failed resource, skipping", step.Op(), step.URN())
+			se.skipSteps(chain[i:])
+			if !completed {
+				se.completeFailedEvent(event, chain)
+			}
+			return
+		}
+
 		if err := se.executeStep(workerID, step); err != nil {
 			se.log(workerID, "step %v on %v failed, signalling cancellation", step.Op(), step.URN())
 			se.cancelDueToError()
@@ -229,8 +280,124 @@ func (se *stepExecutor) executeChain(workerID int, chain chain) {
 				diagMsg := diag.RawMessage(step.URN(), err.Error())
 				se.plan.Diag().Errorf(diagMsg)
 			}
+			if se.continueOnError {
+				se.markFailed(step.URN())
+				se.skipSteps(chain[i+1:])
+				if !completed {
+					se.completeFailedEvent(event, chain)
+				}
+			}
 			return
 		}
+
+		if completesEvent(step) {
+			completed = true
+		}
+	}
+}
+
+// isFailed returns true if the step for the given resource failed or was skipped.
+func (se *stepExecutor) isFailed(urn resource.URN) bool {
+	se.failedLock.Lock()
+	defer se.failedLock.Unlock()
+	return se.failed[urn]
+}
+
+// markFailed records that the step for the given resource failed or was skipped. Steps that depend on the resource
+// will be skipped.
+func (se *stepExecutor) markFailed(urn resource.URN) {
+	se.failedLock.Lock()
+	defer se.failedLock.Unlock()
+	se.failed[urn] = true
+}
+
+// dependsOnFailure returns true if the given step depends on a resource whose step failed or was skipped. A step that
+// produces a new state depends on that state's parent, provider, and dependencies. A delete depends on the resources
+// that depend on the deleted resource, as recorded in the plan's dependency graph. Same and refresh steps make no
+// changes, and so are never skipped.
+func (se *stepExecutor) dependsOnFailure(step Step) bool {
+	if step.Op() == OpSame || step.Op() == OpRefresh {
+		return false
+	}
+
+	if new := step.New(); new != nil {
+		if new.Parent != "" && se.isFailed(new.Parent) {
+			return true
+		}
+		if new.Provider != "" {
+			ref, err := providers.ParseReference(new.Provider)
+			contract.Assert(err == nil)
+			if se.isFailed(ref.URN()) {
+				return true
+			}
+		}
+		for _, dep := range new.Dependencies {
+			if se.isFailed(dep) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if old := step.Old(); old != nil && se.plan.depGraph != nil {
+		for _, dependent := range se.plan.depGraph.DependingOn(old) {
+			if se.isFailed(dependent.URN) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// skipSteps records that the given steps have been skipped and informs any attached callbacks.
+func (se *stepExecutor) skipSteps(steps []Step) {
+	for _, step := range steps {
+		// A chain may contain several steps for the same resource (e.g. a replacement). Report each resource once.
+		if se.isFailed(step.URN()) {
+			continue
+		}
+		se.markFailed(step.URN())
+		if e := se.opts.Events; e != nil {
+			e.OnResourceStepSkipped(step)
+		}
+	}
+}
+
+// completeFailedEvent completes a source event whose chain failed or was skipped, using the last known state of the
+// resource that the event registered or read. This unblocks the program that is waiting on the event.
+func (se *stepExecutor) completeFailedEvent(event SourceEvent, chain chain) {
+	if event == nil {
+		return
+	}
+
+	// The event is completed by the last step in the chain that would have completed it. If that step's resource
+	// already exists and has not been deleted for replacement, its existing state is the last known state.
+	var state *resource.State
+	for _, step := range chain {
+		if completesEvent(step) {
+			state = step.New()
+			if old := step.Old(); old != nil && !old.PendingReplacement {
+				state = old
+			}
+		}
+	}
+	contract.Assertf(state != nil, "expected a step that completes the chain's event")
+
+	switch e := event.(type) {
+	case RegisterResourceEvent:
+		e.Done(&RegisterResult{State: state})
+	case ReadResourceEvent:
+		e.Done(&ReadResult{State: state})
+	}
+}
+
+// completesEvent returns true if the given step completes the source event that produced it once it is retired.
+func completesEvent(step Step) bool {
+	switch step.(type) {
+	case *SameStep, *CreateStep, *UpdateStep, *ReadStep, *ImportStep:
+		return true
+	default:
+		return false
 	}
 }
 
@@ -267,7 +434,7 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 	}
 
 	se.log(workerID, "applying step %v on %v (preview %v)", step.Op(), step.URN(), se.preview)
-	status, stepComplete, err := step.Apply(se.preview)
+	status, stepComplete, err := se.applyStepWithRetries(workerID, step)
 
 	if err == nil {
 		// If we have a state object, and this is a create or update, remember it, as we may need to update it later.
@@ -279,6 +446,10 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 
 			se.pendingNews.Store(step.URN(), step)
 		}
+
+		if se.opts.RecordPlan != nil {
+			se.opts.RecordPlan.recordStep(step)
+		}
 	}
 
 	if events != nil {
@@ -303,6 +474,199 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 	return nil
 }
 
+// applyStepWithRetries applies a single step, retrying it according to the retry policy that applies to the step if it
+// fails with a transient error. A step is only retried if its failure left the resource unchanged, i.e. if its
+// provider reported that the failed operation may be retried and the step's status is StatusOK. Each retry is
+// reported as a warning.
+func (se *stepExecutor) applyStepWithRetries(workerID int, step Step) (resource.Status, StepCompleteFunc, error) {
+	policy := se.stepRetryPolicy(step)
+	if se.preview || policy.MaxAttempts <= 1 {
+		return se.applyStep(workerID, step)
+	}
+
+	delay, backoff, maxDelay, jitter := retry.DefaultDelay, retry.DefaultBackoff, retry.DefaultMaxDelay, policy.Jitter
+	if policy.Delay != 0 {
+		delay = resource.TimeoutDuration(policy.Delay)
+	}
+	if policy.Backoff != 0 {
+		backoff = policy.Backoff
+	}
+	if policy.MaxDelay != 0 {
+		maxDelay = resource.TimeoutDuration(policy.MaxDelay)
+	}
+
+	// The retry helper multiplies the delay by the backoff before each attempt, so divide it out here in order to
+	// wait for exactly the policy's delay before the first retry.
+	delay = time.Duration(float64(delay) / backoff)
+
+	var status resource.Status
+	var complete StepCompleteFunc
+	var err error
+	_, _, retryErr := retry.Until(se.ctx, retry.Acceptor{
+		Delay:    &delay,
+		Backoff:  &backoff,
+		MaxDelay: &maxDelay,
+		Jitter:   &jitter,
+		Accept: func(try int, nextRetryTime time.Duration) (bool, interface{}, error) {
+			status, complete, err = se.applyStep(workerID, step)
+			if err == nil || status != resource.StatusOK || !rpcerror.IsRetryable(err) || try+1 >= policy.MaxAttempts {
+				return true, nil, nil
+			}
+
+			se.log(workerID, "step %v on %v failed with a retryable error, retrying in %v", step.Op(), step.URN(),
+				nextRetryTime)
+			se.plan.Diag().Warningf(diag.RawMessage(step.URN(), fmt.Sprintf(
+				"%s failed with a transient error; retrying in %v (attempt %d of %d): %v",
+				step.Op(), nextRetryTime.Round(time.Millisecond), try+2, policy.MaxAttempts, err)))
+			return false, nil, nil
+		},
+	})
+	contract.IgnoreError(retryErr)
+	return status, complete, err
+}
+
+// stepRetryPolicy returns the retry policy that applies to the given step. Only provider creates, updates, and deletes
+// are retried. The plan's default policy applies unless the step's resource overrides it.
+func (se *stepExecutor) stepRetryPolicy(step Step) resource.RetryPolicy {
+	switch step.Op() {
+	case OpCreate, OpCreateReplacement, OpUpdate:
+		return se.opts.RetryPolicy.Override(step.New().RetryPolicy)
+	case OpDelete, OpDeleteReplaced:
+		return se.opts.RetryPolicy.Override(step.Old().RetryPolicy)
+	default:
+		return resource.RetryPolicy{}
+	}
+}
+
+// applyStep applies a single step, enforcing the custom timeout, if any, that the step's resource specified for the
+// step's operation. The timeout is passed to the step's provider, but the engine does not rely on the provider to
+// honor it: in-process and builtin providers never see the deadline. If the step has not completed once the timeout
+// elapses, or if the provider reports that the deadline was exceeded, a StepTimeoutError is returned. The operation may
+// still be in flight in the provider's cloud at that point, so the step's status is reported as unknown.
+func (se *stepExecutor) applyStep(workerID int, step Step) (resource.Status, StepCompleteFunc, error) {
+	release, err := se.acquireProviderSlot(workerID, step)
+	if err != nil {
+		return resource.StatusOK, nil, err
+	}
+
+	timeout := stepTimeout(step)
+	if se.preview || timeout == 0 {
+		defer release()
+		return step.Apply(se.preview)
+	}
+
+	// Apply the step in the background so that we can stop waiting for it once its timeout elapses. The provider slot
+	// is held until the operation actually finishes, even if we have given up on it by then.
+	type applyResult struct {
+		status   resource.Status
+		complete StepCompleteFunc
+		err      error
+	}
+	done := make(chan applyResult, 1)
+	go func() {
+		defer release()
+		status, complete, err := step.Apply(se.preview)
+		done <- applyResult{status: status, complete: complete, err: err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-done:
+		if res.err == nil || !isDeadlineExceeded(res.err) {
+			return res.status, res.complete, res.err
+		}
+	case <-timer.C:
+	}
+
+	se.log(workerID, "step %v on %v timed out after %v", step.Op(), step.URN(), timeout)
+	return resource.StatusUnknown, nil, &StepTimeoutError{URN: step.URN(), Op: step.Op(), Timeout: timeout}
+}
+
+// isDeadlineExceeded returns true if the given error is an RPC error that reports that a deadline was exceeded.
+func isDeadlineExceeded(err error) bool {
+	rpcError, ok := rpcerror.FromError(errors.Cause(err))
+	return ok && rpcError != nil && rpcError.Code() == codes.DeadlineExceeded
+}
+
+// stepTimeout returns the custom timeout that applies to the given step, or zero if there is none.
+func stepTimeout(step Step) time.Duration {
+	switch step.Op() {
+	case OpCreate, OpCreateReplacement:
+		return resource.TimeoutDuration(step.New().CustomTimeouts.Create)
+	case OpUpdate:
+		return resource.TimeoutDuration(step.New().CustomTimeouts.Update)
+	case OpDelete, OpDeleteReplaced:
+		return resource.TimeoutDuration(step.Old().CustomTimeouts.Delete)
+	default:
+		return 0
+	}
+}
+
+// acquireProviderSlot blocks until the provider of the given step has a free slot for another operation, if the
+// provider's package limits the number of operations that may be in flight at once. While the step is waiting, a
+// status message is reported for its resource. It returns a function that releases the slot, or an error if the plan
+// is cancelled while waiting.
+func (se *stepExecutor) acquireProviderSlot(workerID int, step Step) (func(), error) {
+	slots, pkg := se.getProviderSlots(step)
+	if slots == nil {
+		return func() {}, nil
+	}
+	release := func() { <-slots }
+
+	select {
+	case slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	se.log(workerID, "step %v on %v waiting for a free %v provider slot", step.Op(), step.URN(), pkg)
+	se.plan.Ctx().StatusDiag.Infof(diag.RawMessage(step.URN(), fmt.Sprintf(
+		"waiting for one of %d in-flight %s operations to complete", cap(slots), pkg)))
+	select {
+	case slots <- struct{}{}:
+		// Clear the waiting message now that the step is underway.
+		se.plan.Ctx().StatusDiag.Infof(diag.RawMessage(step.URN(), ""))
+		return release, nil
+	case <-se.ctx.Done():
+		return nil, se.ctx.Err()
+	}
+}
+
+// getProviderSlots returns the semaphore that limits the number of concurrent operations performed by the provider
+// of the given step, along with the provider's package. If the step does not call its provider or its provider's
+// package is not limited, the semaphore is nil. Each provider has its own semaphore, so a limit applies separately to
+// every provider instance of a package.
+func (se *stepExecutor) getProviderSlots(step Step) (chan struct{}, tokens.Package) {
+	switch step.Op() {
+	case OpCreate, OpCreateReplacement, OpUpdate, OpDelete, OpDeleteReplaced, OpRead, OpReadReplacement,
+		OpRefresh, OpImport:
+	default:
+		return nil, ""
+	}
+	if len(se.opts.ProviderParallel) == 0 || step.Provider() == "" || providers.IsProviderType(step.Type()) {
+		return nil, ""
+	}
+	pkg := step.Type().Package()
+	limit := se.opts.ProviderParallel[pkg]
+	if limit <= 0 {
+		return nil, ""
+	}
+
+	se.providerSlotsLock.Lock()
+	defer se.providerSlotsLock.Unlock()
+	slots, ok := se.providerSlots[step.Provider()]
+	if !ok {
+		if se.providerSlots == nil {
+			se.providerSlots = make(map[string]chan struct{})
+		}
+		slots = make(chan struct{}, limit)
+		se.providerSlots[step.Provider()] = slots
+	}
+	return slots, pkg
+}
+
 // log is a simple logging helper for the step executor.
 func (se *stepExecutor) log(workerID int, msg string, args ...interface{}) {
 	if logging.V(stepExecutorLogLevel) {
@@ -345,7 +709,7 @@ func (se *stepExecutor) worker(workerID int, launchAsync bool) {
 
 			se.log(workerID, "worker received chain for execution")
 			if !launchAsync {
-				se.executeChain(workerID, request.Chain)
+				se.executeChain(workerID, request.Event, request.Chain)
 				close(request.CompletionChan)
 				continue
 			}
@@ -357,7 +721,7 @@ func (se *stepExecutor) worker(workerID int, launchAsync bool) {
 			go func() {
 				defer se.workers.Done()
 				se.log(newWorkerID, "launching oneshot worker")
-				se.executeChain(newWorkerID, request.Chain)
+				se.executeChain(newWorkerID, request.Event, request.Chain)
 				close(request.CompletionChan)
 			}()
 
@@ -379,6 +743,7 @@ func newStepExecutor(ctx context.Context, cancel context.CancelFunc, plan *Plan,
 		incomingChains:  make(chan incomingChain),
 		ctx:             ctx,
 		cancel:          cancel,
+		failed:          make(map[resource.URN]bool),
 	}
 
 	exec.sawError.Store(false)
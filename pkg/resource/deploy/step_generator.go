@@ -35,14 +35,21 @@ type stepGenerator struct {
 	plan *Plan   // the plan to which this step generator belongs
 	opts Options // options for this step generator
 
-	urns           map[resource.URN]bool    // set of URNs discovered for this plan
-	reads          map[resource.URN]bool    // set of URNs read for this plan
-	deletes        map[resource.URN]bool    // set of URNs deleted in this plan
-	replaces       map[resource.URN]bool    // set of URNs replaced in this plan
-	updates        map[resource.URN]bool    // set of URNs updated in this plan
-	creates        map[resource.URN]bool    // set of URNs created in this plan
-	sames          map[resource.URN]bool    // set of URNs that were not changed in this plan
-	pendingDeletes map[*resource.State]bool // set of resources (not URNs!) that are pending deletion
+	urns           map[resource.URN]bool         // set of URNs discovered for this plan
+	reads          map[resource.URN]bool         // set of URNs read for this plan
+	deletes        map[resource.URN]bool         // set of URNs deleted in this plan
+	replaces       map[resource.URN]bool         // set of URNs replaced in this plan
+	updates        map[resource.URN]bool         // set of URNs updated in this plan
+	creates        map[resource.URN]bool         // set of URNs created in this plan
+	imports        map[resource.URN]bool         // set of URNs imported in this plan
+	sames          map[resource.URN]bool         // set of URNs that were not changed in this plan
+	aliased        map[resource.URN]resource.URN // map from old URNs to the new URNs that alias them
+	pendingDeletes map[*resource.State]bool      // set of resources (not URNs!) that are pending deletion
+	retains        map[*resource.State]bool      // the retainOnDelete settings of resources replaced in this plan
+	targets        map[resource.URN]bool         // set of URNs targeted by this plan, or nil if all URNs are targeted
+	replaceTargets map[resource.URN]bool         // set of URNs that must be replaced by this plan
+	planChecker    *planChecker                  // checks steps against the update plan, or nil if there is none
+	news           []*resource.State             // the new states of the resources registered by this plan, in order
 
 	// a map from URN to a list of property keys that caused the replacement of a dependent resource during a
 	// delete-before-replace.
@@ -52,6 +59,14 @@ type stepGenerator struct {
 // GenerateReadSteps is responsible for producing one or more steps required to service
 // a ReadResourceEvent coming from the language host.
 func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, *result.Result) {
+	steps, res := sg.generateReadSteps(event)
+	if res != nil {
+		return nil, res
+	}
+	return steps, sg.checkPlan(steps)
+}
+
+func (sg *stepGenerator) generateReadSteps(event ReadResourceEvent) ([]Step, *result.Result) {
 	urn := sg.plan.generateURN(event.Parent(), event.Type(), event.Name())
 	newState := resource.NewState(event.Type(),
 		urn,
@@ -67,7 +82,11 @@ func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, *re
 		nil, /* initErrors */
 		event.Provider(),
 		nil, /* propertyDependencies */
-		false)
+		false,
+		resource.CustomTimeouts{},
+		false, /*retainOnDelete*/
+		resource.RetryPolicy{},
+		nil /*replaceOnChanges*/)
 	old, hasOld := sg.plan.Olds()[urn]
 
 	// If the snapshot has an old resource for this URN and it's not external, we're going
@@ -89,7 +108,7 @@ func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, *re
 		sg.replaces[urn] = true
 		return []Step{
 			NewReadReplacementStep(sg.plan, event, old, newState),
-			NewReplaceStep(sg.plan, old, newState, nil, true),
+			NewReplaceStep(sg.plan, old, newState, nil, nil, false, nil, true),
 		}, nil
 	}
 
@@ -110,6 +129,14 @@ func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, *re
 // and Check on the provider associated with that resource. If those fail, an error
 // is returned.
 func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *result.Result) {
+	steps, res := sg.generateSteps(event)
+	if res != nil {
+		return nil, res
+	}
+	return steps, sg.checkPlan(steps)
+}
+
+func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, *result.Result) {
 	var invalid bool // will be set to true if this object fails validation.
 
 	goal := event.Goal()
@@ -120,10 +147,18 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 		// TODO[pulumi/pulumi-framework#19]: improve this error message!
 		sg.plan.Diag().Errorf(diag.GetDuplicateResourceURNError(urn), urn)
 	}
+	if aliasedBy, ok := sg.aliased[urn]; ok {
+		invalid = true
+		sg.plan.Diag().Errorf(diag.GetDuplicateResourceAliasError(urn), urn, aliasedBy)
+	}
 	sg.urns[urn] = true
 
-	// Check for an old resource so that we can figure out if this is a create, delete, etc., and/or to diff.
+	// Check for an old resource so that we can figure out if this is a create, delete, etc., and/or to diff. If there
+	// is no old resource with this URN, we look for an old resource under each of the resource's aliases in turn.
 	old, hasOld := sg.plan.Olds()[urn]
+	if !hasOld {
+		old, hasOld = sg.findAliasedOld(urn, goal)
+	}
 	var oldInputs resource.PropertyMap
 	var oldOutputs resource.PropertyMap
 	if hasOld {
@@ -131,11 +166,37 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 		oldOutputs = old.Outputs
 	}
 
+	// If this plan is restricted to a set of targets that does not include this resource, carry the resource's old
+	// state forward unchanged.
+	if !sg.isTargeted(urn, goal) {
+		if invalid {
+			return nil, result.Bail()
+		}
+		return sg.generateUntargetedSteps(event, urn, old)
+	}
+
 	// Produce a new state object that we'll build up as operations are performed.  Ultimately, this is what will
 	// get serialized into the checkpoint file.
 	inputs := goal.Properties
+
+	// If the resource asked us to ignore changes to some of its properties, copy the old values for those
+	// properties into the new inputs before checking and diffing.
+	if hasOld && len(goal.IgnoreChanges) > 0 {
+		var ok bool
+		if inputs, ok = sg.processIgnoreChanges(urn, inputs, oldInputs, goal.IgnoreChanges); !ok {
+			return nil, result.Bail()
+		}
+	}
+
+	// Parse the paths of any properties whose changes should force a replacement.
+	replaceOnChanges, ok := sg.parseReplaceOnChanges(urn, goal.ReplaceOnChanges)
+	if !ok {
+		return nil, result.Bail()
+	}
+
 	new := resource.NewState(goal.Type, urn, goal.Custom, false, "", inputs, nil, goal.Parent, goal.Protect, false,
-		goal.Dependencies, goal.InitErrors, goal.Provider, goal.PropertyDependencies, false)
+		goal.Dependencies, goal.InitErrors, goal.Provider, goal.PropertyDependencies, false,
+		goal.CustomTimeouts, goal.RetainOnDelete, goal.RetryPolicy, goal.ReplaceOnChanges)
 
 	// Fetch the provider for this resource.
 	prov, err := sg.getResourceProvider(urn, goal.Custom, goal.Provider, goal.Type)
@@ -143,11 +204,27 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 		return nil, result.FromError(err)
 	}
 
+	// Resources registered by an import source rather than by a program carry no inputs of their own: their inputs are
+	// computed from their current state by the import step, so there is nothing to check or diff here.
+	if sg.opts.Import && goal.ID != "" {
+		if hasOld {
+			sg.plan.Diag().Errorf(diag.GetImportResourceExistsError(urn), urn)
+			invalid = true
+		}
+		if invalid {
+			return nil, result.Bail()
+		}
+		sg.imports[urn] = true
+		new.ID = goal.ID
+		logging.V(7).Infof("Planner decided to import '%v' (id=%v)", urn, goal.ID)
+		return []Step{NewImportStep(sg.plan, event, new, false)}, nil
+	}
+
 	// We only allow unknown property values to be exposed to the provider if we are performing an update preview.
 	allowUnknowns := sg.plan.preview
 
 	// We may be re-creating this resource if it got deleted earlier in the execution of this plan.
-	_, recreating := sg.deletes[urn]
+	recreating := hasOld && sg.deletes[old.URN]
 
 	// We may be creating this resource if it previously existed in the snapshot as an External resource
 	wasExternal := hasOld && old.External
@@ -188,9 +265,12 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 			return nil, result.FromError(err)
 		}
 		for _, failure := range failures {
-			invalid = true
-			sg.plan.Diag().Errorf(
-				diag.GetAnalyzeResourceFailureError(urn), a, urn, failure.Property, failure.Reason)
+			if failure.URN == "" {
+				failure.URN = urn
+			}
+			if sg.issueAnalyzeFailure(a, failure) {
+				invalid = true
+			}
 		}
 	}
 
@@ -198,6 +278,7 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 	if invalid {
 		return nil, result.Bail()
 	}
+	sg.news = append(sg.news, new)
 
 	// There are four cases we need to consider when figuring out what to do with this resource.
 	//
@@ -218,12 +299,12 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 		logging.V(7).Infof("Planner decided to re-create replaced resource '%v' deleted due to dependent DBR", urn)
 
 		// Unmark this resource as deleted, we now know it's being replaced instead.
-		delete(sg.deletes, urn)
+		delete(sg.deletes, old.URN)
 		sg.replaces[urn] = true
-		keys := sg.dependentReplaceKeys[urn]
+		keys := sg.dependentReplaceKeys[old.URN]
 		return []Step{
-			NewReplaceStep(sg.plan, old, new, nil, false),
-			NewCreateReplacementStep(sg.plan, event, old, new, keys, false),
+			NewReplaceStep(sg.plan, old, new, nil, nil, false, nil, false),
+			NewCreateReplacementStep(sg.plan, event, old, new, keys, nil, false, nil, false),
 		}, nil
 	}
 
@@ -243,8 +324,8 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 		}
 
 		return []Step{
-			NewCreateReplacementStep(sg.plan, event, old, new, nil, true),
-			NewReplaceStep(sg.plan, old, new, nil, true),
+			NewCreateReplacementStep(sg.plan, event, old, new, nil, nil, false, nil, true),
+			NewReplaceStep(sg.plan, old, new, nil, nil, false, nil, true),
 		}, nil
 	}
 
@@ -261,16 +342,17 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 		contract.Assert(old != nil && old.Type == new.Type)
 
 		var diff plugin.DiffResult
-		if old.Provider != new.Provider {
+		if sg.providerChanged(old, new) {
 			diff = plugin.DiffResult{Changes: plugin.DiffSome, ReplaceKeys: []resource.PropertyKey{"provider"}}
 		} else {
 			// Determine whether the change resulted in a diff.
-			d, diffErr := sg.diff(urn, old.ID, oldInputs, oldOutputs, inputs, prov, allowUnknowns)
+			d, diffErr := sg.diff(urn, old.ID, oldInputs, oldOutputs, inputs, prov, allowUnknowns, replaceOnChanges)
 			if diffErr != nil {
 				// If the plugin indicated that the diff is unavailable, assume that the resource will be updated and
 				// report the message contained in the error.
 				if _, ok := diffErr.(plugin.DiffUnavailableError); ok {
-					d = plugin.DiffResult{Changes: plugin.DiffSome}
+					d = applyReplaceOnChanges(plugin.DiffResult{Changes: plugin.DiffSome}, oldInputs, inputs,
+						replaceOnChanges)
 					sg.plan.ctx.Diag.Warningf(diag.RawMessage(urn, diffErr.Error()))
 				} else {
 					return nil, result.FromError(diffErr)
@@ -285,9 +367,17 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 				"unrecognized diff state for %s: %d", urn, diff.Changes)
 		}
 
+		// If the resource was explicitly marked for replacement, replace it regardless of its diff. Any replacement
+		// keys or delete-before-replace request returned by the provider are preserved.
+		forceReplace := sg.replaceTargets[urn]
+		if forceReplace {
+			logging.V(7).Infof("Planner decided to force replacement of '%v'", urn)
+			diff.Changes = plugin.DiffSome
+		}
+
 		// If there were changes, check for a replacement vs. an in-place update.
 		if diff.Changes == plugin.DiffSome {
-			if diff.Replace() {
+			if diff.Replace() || forceReplace {
 				sg.replaces[urn] = true
 
 				// If we are going to perform a replacement, we need to recompute the default values.  The above logic
@@ -308,6 +398,10 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 						urn, oldInputs, new.Inputs)
 				}
 
+				// Whether or not the replaced resource is deleted by its provider is governed by the program's current
+				// retainOnDelete setting rather than the setting with which the resource was last deployed.
+				sg.retains[old] = new.RetainOnDelete
+
 				// We have two approaches to performing replacements:
 				//
 				//     * CreateBeforeDelete: the default mode first creates a new instance of the resource, then
@@ -349,12 +443,21 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 								continue
 							}
 
+							// If this plan is restricted to a set of targets, we must not replace any resource that
+							// was not targeted.
+							if sg.targets != nil && !sg.targets[dependentResource.URN] {
+								sg.plan.Diag().Errorf(diag.GetResourceWillBeReplacedButWasNotSpecifiedInTargetList(
+									dependentResource.URN), dependentResource.URN, urn)
+								return nil, result.Bail()
+							}
+
 							sg.dependentReplaceKeys[dependentResource.URN] = toReplace[i].keys
 
 							logging.V(7).Infof("Planner decided to delete '%v' due to dependence on condemned resource '%v'",
 								dependentResource.URN, urn)
 
-							steps = append(steps, NewDeleteReplacementStep(sg.plan, dependentResource, true))
+							steps = append(steps, NewDeleteReplacementStep(sg.plan, dependentResource, true,
+								sg.retainOnDelete(dependentResource)))
 							// Mark the condemned resource as deleted. We won't know until later in the plan whether
 							// or not we're going to be replacing this resource.
 							sg.deletes[dependentResource.URN] = true
@@ -362,15 +465,19 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 					}
 
 					return append(steps,
-						NewDeleteReplacementStep(sg.plan, old, true),
-						NewReplaceStep(sg.plan, old, new, diff.ReplaceKeys, false),
-						NewCreateReplacementStep(sg.plan, event, old, new, diff.ReplaceKeys, false),
+						NewDeleteReplacementStep(sg.plan, old, true, sg.retainOnDelete(old)),
+						NewReplaceStep(sg.plan, old, new, diff.ReplaceKeys, diff.DetailedDiff, forceReplace,
+							goal.IgnoreChanges, false),
+						NewCreateReplacementStep(sg.plan, event, old, new, diff.ReplaceKeys, diff.DetailedDiff,
+							forceReplace, goal.IgnoreChanges, false),
 					), nil
 				}
 
 				return []Step{
-					NewCreateReplacementStep(sg.plan, event, old, new, diff.ReplaceKeys, true),
-					NewReplaceStep(sg.plan, old, new, diff.ReplaceKeys, true),
+					NewCreateReplacementStep(sg.plan, event, old, new, diff.ReplaceKeys, diff.DetailedDiff,
+						forceReplace, goal.IgnoreChanges, true),
+					NewReplaceStep(sg.plan, old, new, diff.ReplaceKeys, diff.DetailedDiff, forceReplace,
+						goal.IgnoreChanges, true),
 					// note that the delete step is generated "later" on, after all creates/updates finish.
 				}, nil
 			}
@@ -380,14 +487,14 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 			if logging.V(7) {
 				logging.V(7).Infof("Planner decided to update '%v' (oldprops=%v inputs=%v", urn, oldInputs, new.Inputs)
 			}
-			return []Step{NewUpdateStep(sg.plan, event, old, new, diff.StableKeys)}, nil
+			return []Step{NewUpdateStep(sg.plan, event, old, new, diff.StableKeys, diff.DetailedDiff, goal.IgnoreChanges)}, nil
 		}
 
 		// If resource was unchanged, but there were initialization errors, generate an empty update
 		// step to attempt to "continue" awaiting initialization.
 		if len(old.InitErrors) > 0 {
 			sg.updates[urn] = true
-			return []Step{NewUpdateStep(sg.plan, event, old, new, diff.StableKeys)}, nil
+			return []Step{NewUpdateStep(sg.plan, event, old, new, diff.StableKeys, diff.DetailedDiff, goal.IgnoreChanges)}, nil
 		}
 
 		// No need to update anything, the properties didn't change.
@@ -395,18 +502,119 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 		if logging.V(7) {
 			logging.V(7).Infof("Planner decided not to update '%v' (same) (inputs=%v)", urn, new.Inputs)
 		}
-		return []Step{NewSameStep(sg.plan, event, old, new)}, nil
+		return []Step{NewSameStep(sg.plan, event, old, new, goal.IgnoreChanges)}, nil
 	}
 
 	// Case 4: Not Case 1, 2, or 3
 	//  If a resource isn't being recreated and it's not being updated or replaced,
-	//  it's just being created.
+	//  it's either being imported (if the program supplied the ID of an existing
+	//  resource) or it's just being created.
+	if goal.Custom && goal.ID != "" {
+		sg.imports[urn] = true
+		new.ID = goal.ID
+		logging.V(7).Infof("Planner decided to import '%v' (id=%v, inputs=%v)", urn, goal.ID, new.Inputs)
+		return []Step{NewImportStep(sg.plan, event, new, true)}, nil
+	}
+
 	sg.creates[urn] = true
 	logging.V(7).Infof("Planner decided to create '%v' (inputs=%v)", urn, new.Inputs)
 	return []Step{NewCreateStep(sg.plan, event, new)}, nil
 }
 
-func (sg *stepGenerator) GenerateDeletes() []Step {
+// findAliasedOld looks for an old resource that is registered under one of the given goal's aliases. An old resource
+// may only be claimed by a single alias, and an alias may not refer to a URN that has already been registered.
+func (sg *stepGenerator) findAliasedOld(urn resource.URN, goal *resource.Goal) (*resource.State, bool) {
+	for _, alias := range goal.Aliases {
+		if alias == urn || sg.urns[alias] {
+			continue
+		}
+		if _, claimed := sg.aliased[alias]; claimed {
+			continue
+		}
+		if old, ok := sg.plan.Olds()[alias]; ok {
+			logging.V(7).Infof("Planner matched '%v' to old resource '%v' via alias", urn, alias)
+			sg.aliased[alias] = urn
+
+			// If this resource is a provider, references to the provider under its new URN must resolve to the
+			// provider that was loaded under its old URN.
+			if providers.IsProviderType(goal.Type) {
+				sg.plan.providers.RegisterAlias(urn, alias)
+			}
+			return old, true
+		}
+	}
+	return nil, false
+}
+
+// providerChanged returns true if the provider reference of the given new resource state differs from that of the
+// given old state. References that differ only in that the old reference names a provider that has been aliased by the
+// new reference's provider are considered identical.
+func (sg *stepGenerator) providerChanged(old, new *resource.State) bool {
+	if old.Provider == new.Provider {
+		return false
+	}
+	if old.Provider == "" || new.Provider == "" {
+		return true
+	}
+
+	oldRef, err := providers.ParseReference(old.Provider)
+	if err != nil {
+		return true
+	}
+	newRef, err := providers.ParseReference(new.Provider)
+	if err != nil {
+		return true
+	}
+	return oldRef.ID() != newRef.ID() || sg.aliased[oldRef.URN()] != newRef.URN()
+}
+
+// isTargeted returns true if the resource with the given URN and goal state is targeted by this plan. Default
+// providers are always targeted, as are new resources that depend upon a target if the plan targets dependents.
+func (sg *stepGenerator) isTargeted(urn resource.URN, goal *resource.Goal) bool {
+	if sg.targets == nil || sg.targets[urn] || providers.IsDefaultProvider(urn) {
+		return true
+	}
+	if !sg.opts.TargetDependents || dependsOnAny(sg.targets, goal.Parent, goal.Dependencies, goal.Provider) == "" {
+		return false
+	}
+	sg.targets[urn] = true
+	return true
+}
+
+// generateUntargetedSteps produces the steps for a resource that was registered by the program but is not targeted by
+// this plan. Such a resource must already exist, and its old state is carried forward into the new snapshot as-is.
+func (sg *stepGenerator) generateUntargetedSteps(event RegisterResourceEvent, urn resource.URN,
+	old *resource.State) ([]Step, *result.Result) {
+
+	// We cannot create an untargeted resource, nor can we take ownership of an untargeted external resource.
+	if old == nil || old.External {
+		sg.plan.Diag().Errorf(diag.GetResourceWillBeCreatedButWasNotSpecifiedInTargetList(urn), urn)
+		return nil, result.Bail()
+	}
+
+	// If the resource's provider has been replaced by this plan, the resource must be replaced as well.
+	if old.Provider != "" {
+		ref, err := providers.ParseReference(old.Provider)
+		if err != nil {
+			return nil, result.FromError(err)
+		}
+		if sg.replaces[ref.URN()] || sg.deletes[ref.URN()] {
+			sg.plan.Diag().Errorf(diag.GetResourceWillBeReplacedButWasNotSpecifiedInTargetList(urn), urn, ref.URN())
+			return nil, result.Bail()
+		}
+	}
+
+	sg.sames[urn] = true
+	logging.V(7).Infof("Planner decided not to update '%v' (not targeted)", urn)
+	new := resource.NewState(old.Type, urn, old.Custom, false, "", old.Inputs, nil, old.Parent, old.Protect, false,
+		old.Dependencies, old.InitErrors, old.Provider, old.PropertyDependencies, false,
+		old.CustomTimeouts, old.RetainOnDelete, old.RetryPolicy, old.ReplaceOnChanges)
+	return []Step{NewSameStep(sg.plan, event, old, new, nil)}, nil
+}
+
+// GenerateDeletes produces the delete steps for all resources in the old snapshot that were not seen during this plan
+// or that are pending deletion. If this plan is restricted to a set of targets, only targeted resources are deleted.
+func (sg *stepGenerator) GenerateDeletes() ([]Step, *result.Result) {
 	// To compute the deletion list, we must walk the list of old resources *backwards*.  This is because the list is
 	// stored in dependency order, and earlier elements are possibly leaf nodes for later elements.  We must not delete
 	// dependencies prior to their dependent nodes.
@@ -445,8 +653,15 @@ func (sg *stepGenerator) GenerateDeletes() []Step {
 
 				logging.V(7).Infof("Planner decided to delete '%v' due to replacement", res.URN)
 				sg.deletes[res.URN] = true
-				dels = append(dels, NewDeleteReplacementStep(sg.plan, res, false))
-			} else if !sg.sames[res.URN] && !sg.updates[res.URN] && !sg.replaces[res.URN] && !sg.reads[res.URN] {
+				dels = append(dels, NewDeleteReplacementStep(sg.plan, res, false, sg.retainOnDelete(res)))
+			} else if !sg.sames[res.URN] && !sg.updates[res.URN] && !sg.replaces[res.URN] && !sg.reads[res.URN] &&
+				sg.aliased[res.URN] == "" {
+				// If this plan is restricted to a set of targets that does not include this resource, leave it be.
+				if sg.targets != nil && !sg.targets[res.URN] {
+					logging.V(7).Infof("Planner decided not to delete '%v' (not targeted)", res.URN)
+					continue
+				}
+
 				// NOTE: we deliberately do not check sg.deletes here, as it is possible for us to issue multiple
 				// delete steps for the same URN if the old checkpoint contained pending deletes.
 				logging.V(7).Infof("Planner decided to delete '%v'", res.URN)
@@ -459,7 +674,70 @@ func (sg *stepGenerator) GenerateDeletes() []Step {
 			}
 		}
 	}
-	return dels
+
+	if sg.targets != nil {
+		if res := sg.checkTargetedDeletes(dels); res != nil {
+			return nil, res
+		}
+	}
+	if res := sg.checkPlan(dels); res != nil {
+		return nil, res
+	}
+	return dels, nil
+}
+
+// checkPlan ensures that each of the given steps is part of the plan to which this update must conform, if any. Every
+// step that is passed is checked. Deletions of resources that were pending deletion before the update began are never
+// passed: they are produced by GeneratePendingDeletes, which does not check them, as they merely finish the work of a
+// previous update.
+func (sg *stepGenerator) checkPlan(steps []Step) *result.Result {
+	if sg.planChecker == nil {
+		return nil
+	}
+
+	var res *result.Result
+	for _, step := range steps {
+		if err := sg.planChecker.checkStep(step); err != nil {
+			sg.plan.Diag().Errorf(diag.GetResourceViolatesPlanError(step.URN()), step.URN(), err)
+			res = result.Bail()
+		}
+	}
+	return res
+}
+
+// checkTargetedDeletes ensures that no resource that survives a targeted plan depends upon a resource that is deleted
+// by the plan. Such a resource would otherwise be left referring to a resource that no longer exists.
+func (sg *stepGenerator) checkTargetedDeletes(dels []Step) *result.Result {
+	deleted := make(map[resource.URN]bool)
+	for _, step := range dels {
+		if step.Op() == OpDelete {
+			deleted[step.URN()] = true
+		}
+	}
+	if len(deleted) == 0 {
+		return nil
+	}
+
+	var res *result.Result
+	for _, old := range sg.plan.prev.Resources {
+		if old.Delete || deleted[old.URN] || sg.targets[old.URN] {
+			continue
+		}
+		if dep := dependsOnAny(deleted, old.Parent, old.Dependencies, old.Provider); dep != "" {
+			sg.plan.Diag().Errorf(diag.GetResourceWillBeOrphanedButWasNotSpecifiedInTargetList(old.URN), old.URN, dep)
+			res = result.Bail()
+		}
+	}
+	return res
+}
+
+// retainOnDelete returns true if the given resource should be removed from the stack, rather than deleted by its
+// provider, when it is deleted. A resource that is replaced in this plan follows the program's current setting.
+func (sg *stepGenerator) retainOnDelete(old *resource.State) bool {
+	if retain, has := sg.retains[old]; has {
+		return retain
+	}
+	return old.RetainOnDelete
 }
 
 // GeneratePendingDeletes generates delete steps for all resources that are pending deletion. This function should be
@@ -493,14 +771,14 @@ func (sg *stepGenerator) GeneratePendingDeletes() []Step {
 //
 // The algorithm for decomposing a poset into antichains is:
 //  1. While there exist elements in the poset,
-//    1a. There must exist at least one "maximal" element of the poset. Let E_max be those elements.
-//    2a. Remove all elements E_max from the poset. E_max is an antichain.
-//    3a. Goto 1.
+//     1a. There must exist at least one "maximal" element of the poset. Let E_max be those elements.
+//     2a. Remove all elements E_max from the poset. E_max is an antichain.
+//     3a. Goto 1.
 //
 // Translated to our dependency graph:
 //  1. While the set of condemned resources is not empty:
-//    1a. Remove all resources with no outgoing edges from the graph and add them to the current antichain.
-//    2a. Goto 1.
+//     1a. Remove all resources with no outgoing edges from the graph and add them to the current antichain.
+//     2a. Goto 1.
 //
 // The resulting list of antichains is a list of list of steps that can be safely executed in parallel. Since we must
 // process deletes in reverse (so we don't delete resources upon which other resources depend), we reverse the list and
@@ -570,7 +848,7 @@ func (sg *stepGenerator) ScheduleDeletes(deleteSteps []Step) []antichain {
 
 // diff returns a DiffResult for the given resource.
 func (sg *stepGenerator) diff(urn resource.URN, id resource.ID, oldInputs, oldOutputs, newInputs resource.PropertyMap,
-	prov plugin.Provider, allowUnknowns bool) (plugin.DiffResult, error) {
+	prov plugin.Provider, allowUnknowns bool, replaceOnChanges []resource.PropertyPath) (plugin.DiffResult, error) {
 
 	// Workaround #1251: unexpected replaces.
 	//
@@ -586,7 +864,8 @@ func (sg *stepGenerator) diff(urn resource.URN, id resource.ID, oldInputs, oldOu
 
 	// If there is no provider for this resource, simply return a "diffs exist" result.
 	if prov == nil {
-		return plugin.DiffResult{Changes: plugin.DiffSome}, nil
+		return applyReplaceOnChanges(plugin.DiffResult{Changes: plugin.DiffSome}, oldInputs, newInputs,
+			replaceOnChanges), nil
 	}
 
 	// Grab the diff from the provider. At this point we know that there were changes to the Pulumi inputs, so if the
@@ -598,7 +877,106 @@ func (sg *stepGenerator) diff(urn resource.URN, id resource.ID, oldInputs, oldOu
 	if diff.Changes == plugin.DiffUnknown {
 		diff.Changes = plugin.DiffSome
 	}
-	return diff, nil
+	return applyReplaceOnChanges(diff, oldInputs, newInputs, replaceOnChanges), nil
+}
+
+// parseReplaceOnChanges parses the given list of property paths whose changes should force a replacement. Each path
+// must begin with a property name. If any path is invalid, an error is issued and false is returned.
+func (sg *stepGenerator) parseReplaceOnChanges(urn resource.URN,
+	replaceOnChanges []string) ([]resource.PropertyPath, bool) {
+
+	paths := make([]resource.PropertyPath, 0, len(replaceOnChanges))
+	ok := true
+	for _, replaceOnChange := range replaceOnChanges {
+		path, err := parseReplaceOnChange(replaceOnChange)
+		if err != nil {
+			sg.plan.Diag().Errorf(diag.GetInvalidReplaceOnChangesPathError(urn), replaceOnChange, err)
+			ok = false
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths, ok
+}
+
+// parseReplaceOnChange parses a single property path whose changes should force a replacement.
+func parseReplaceOnChange(replaceOnChange string) (resource.PropertyPath, error) {
+	path, err := resource.ParsePropertyPath(replaceOnChange)
+	if err != nil {
+		return nil, err
+	}
+	if len(path) == 0 {
+		return nil, errors.New("the path must begin with a property name")
+	}
+	if _, isKey := path[0].(string); !isKey {
+		return nil, errors.New("the path must begin with a property name")
+	}
+	return path, nil
+}
+
+// applyReplaceOnChanges upgrades the given diff to a replacement if the value at any of the given property paths
+// differs between the old and new inputs. The top-level property of each such path is added to the diff's replace
+// keys, and the path's detailed diff, if any, is upgraded to its replacing equivalent. A diff that reports no changes
+// is returned as-is.
+func applyReplaceOnChanges(diff plugin.DiffResult, oldInputs, newInputs resource.PropertyMap,
+	replaceOnChanges []resource.PropertyPath) plugin.DiffResult {
+
+	if diff.Changes == plugin.DiffNone {
+		return diff
+	}
+
+	for _, path := range replaceOnChanges {
+		oldValue, _ := path.Get(resource.NewObjectProperty(oldInputs))
+		newValue, _ := path.Get(resource.NewObjectProperty(newInputs))
+		if oldValue.DeepEquals(newValue) {
+			continue
+		}
+
+		key, hasKey := resource.PropertyKey(path[0].(string)), false
+		for _, k := range diff.ReplaceKeys {
+			hasKey = hasKey || k == key
+		}
+		if !hasKey {
+			diff.ReplaceKeys = append(diff.ReplaceKeys, key)
+		}
+
+		if d, has := diff.DetailedDiff[path.String()]; has {
+			switch d.Kind {
+			case plugin.DiffAdd:
+				d.Kind = plugin.DiffAddReplace
+			case plugin.DiffDelete:
+				d.Kind = plugin.DiffDeleteReplace
+			case plugin.DiffUpdate:
+				d.Kind = plugin.DiffUpdateReplace
+			}
+			diff.DetailedDiff[path.String()] = d
+		}
+	}
+	return diff
+}
+
+// processIgnoreChanges returns a copy of the given inputs in which the value at each of the given property paths has
+// been reset to its value in the old inputs. If a path is invalid or cannot be reset, an error is issued and false is
+// returned.
+func (sg *stepGenerator) processIgnoreChanges(urn resource.URN, inputs, oldInputs resource.PropertyMap,
+	ignoreChanges []string) (resource.PropertyMap, bool) {
+
+	ignored := inputs.Copy()
+	ok := true
+	for _, ignoreChange := range ignoreChanges {
+		path, err := resource.ParsePropertyPath(ignoreChange)
+		if err != nil {
+			sg.plan.Diag().Errorf(diag.GetInvalidIgnoreChangesPathError(urn), ignoreChange, err)
+			ok = false
+			continue
+		}
+		if !path.Reset(oldInputs, ignored) {
+			sg.plan.Diag().Errorf(diag.GetInvalidIgnoreChangesPathError(urn), ignoreChange,
+				"one or more elements of the path are missing")
+			ok = false
+		}
+	}
+	return ignored, ok
 }
 
 // issueCheckErrors prints any check errors to the diagnostics sink.
@@ -620,6 +998,69 @@ func (sg *stepGenerator) issueCheckErrors(new *resource.State, urn resource.URN,
 	return true
 }
 
+// issueAnalyzeFailure prints the given analyzer failure to the diagnostics sink according to its enforcement level,
+// and returns true if the failure is mandatory.
+func (sg *stepGenerator) issueAnalyzeFailure(a tokens.QName, failure plugin.AnalyzeFailure) bool {
+	switch failure.EnforcementLevel {
+	case plugin.Disabled:
+		return false
+	case plugin.Advisory:
+		sg.plan.Diag().Warningf(diag.GetAnalyzeResourceFailureWarning(failure.URN),
+			a, failure.URN, failure.Property, failure.Reason)
+		return false
+	default:
+		sg.plan.Diag().Errorf(diag.GetAnalyzeResourceFailureError(failure.URN),
+			a, failure.URN, failure.Property, failure.Reason)
+		return true
+	}
+}
+
+// AnalyzeStack gives each analyzer -- if any -- a chance to inspect all of the resources registered by this plan once
+// the program has finished. Mandatory failures are reported as errors and cause the plan to fail. Because the steps for
+// each resource are executed as the resource is registered, a failure here cannot undo any creates or updates that
+// have already been applied; it only prevents the plan's deletes.
+func (sg *stepGenerator) AnalyzeStack() *result.Result {
+	if len(sg.plan.analyzers) == 0 {
+		return nil
+	}
+
+	resources := make([]plugin.AnalyzerResource, len(sg.news))
+	for i, new := range sg.news {
+		resources[i] = plugin.AnalyzerResource{
+			URN:          new.URN,
+			Type:         new.Type,
+			Properties:   new.Inputs,
+			Parent:       new.Parent,
+			Dependencies: new.Dependencies,
+			Provider:     new.Provider,
+		}
+	}
+
+	invalid := false
+	for _, a := range sg.plan.analyzers {
+		analyzer, err := sg.plan.ctx.Host.Analyzer(a)
+		if err != nil {
+			return result.FromError(err)
+		} else if analyzer == nil {
+			return result.Errorf("analyzer '%v' could not be loaded from your $PATH", a)
+		}
+		failures, err := analyzer.AnalyzeStack(resources)
+		if err != nil {
+			return result.FromError(err)
+		}
+		for _, failure := range failures {
+			if sg.issueAnalyzeFailure(a, failure) {
+				invalid = true
+			}
+		}
+	}
+
+	if invalid {
+		return result.Bail()
+	}
+	return nil
+}
+
 func (sg *stepGenerator) getResourceProvider(
 	urn resource.URN, custom bool, provider string, typ tokens.Type) (plugin.Provider, error) {
 
@@ -719,11 +1160,19 @@ func (sg *stepGenerator) calculateDependentReplacements(root *resource.State) ([
 		}
 		contract.Assert(prov != nil)
 
-		// Call the provider's `Diff` method and return.
+		// Call the provider's `Diff` method and return. Any changes to properties whose changes force a replacement
+		// require this resource to be replaced regardless of the provider's response.
 		diff, err := prov.Diff(r.URN, r.ID, r.Outputs, inputsForDiff, true)
 		if err != nil {
 			return false, nil, err
 		}
+		var replaceOnChanges []resource.PropertyPath
+		for _, p := range r.ReplaceOnChanges {
+			if path, err := parseReplaceOnChange(p); err == nil {
+				replaceOnChanges = append(replaceOnChanges, path)
+			}
+		}
+		diff = applyReplaceOnChanges(diff, r.Inputs, inputsForDiff, replaceOnChanges)
 		return diff.Replace(), diff.ReplaceKeys, nil
 	}
 
@@ -744,17 +1193,33 @@ func (sg *stepGenerator) calculateDependentReplacements(root *resource.State) ([
 
 // newStepGenerator creates a new step generator that operates on the given plan.
 func newStepGenerator(plan *Plan, opts Options) *stepGenerator {
+	replaceTargets := make(map[resource.URN]bool)
+	for _, urn := range opts.ReplaceTargets {
+		replaceTargets[urn] = true
+	}
+
+	var checker *planChecker
+	if opts.UpdatePlan != nil {
+		checker = newPlanChecker(opts.UpdatePlan)
+	}
+
 	return &stepGenerator{
 		plan:                 plan,
 		opts:                 opts,
 		urns:                 make(map[resource.URN]bool),
 		reads:                make(map[resource.URN]bool),
 		creates:              make(map[resource.URN]bool),
+		imports:              make(map[resource.URN]bool),
 		sames:                make(map[resource.URN]bool),
+		aliased:              make(map[resource.URN]resource.URN),
 		replaces:             make(map[resource.URN]bool),
 		updates:              make(map[resource.URN]bool),
 		deletes:              make(map[resource.URN]bool),
 		pendingDeletes:       make(map[*resource.State]bool),
+		retains:              make(map[*resource.State]bool),
+		targets:              plan.computeTargets(opts),
+		replaceTargets:       replaceTargets,
+		planChecker:          checker,
 		dependentReplaceKeys: make(map[resource.URN][]resource.PropertyKey),
 	}
 }
@@ -27,11 +27,16 @@ type Target struct {
 	Snapshot  *Snapshot        // the last snapshot deployed to the target.
 }
 
+// ProviderParallelKey is the name of the configuration key that limits the number of operations that each provider of
+// a package may have in flight at once (e.g. `aws:parallel`). It configures the engine rather than the provider, so it
+// is not passed to providers.
+const ProviderParallelKey = "parallel"
+
 // GetPackageConfig returns the set of configuration parameters for the indicated package, if any.
 func (t *Target) GetPackageConfig(pkg tokens.Package) (map[config.Key]string, error) {
 	var result map[config.Key]string
 	for k, c := range t.Config {
-		if tokens.Package(k.Namespace()) != pkg {
+		if tokens.Package(k.Namespace()) != pkg || k.Name() == ProviderParallelKey {
 			continue
 		}
 		v, err := c.Value(t.Decrypter)
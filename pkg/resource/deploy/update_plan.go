@@ -0,0 +1,166 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+// An UpdatePlan records the steps that an update is expected to perform, along with the inputs and outputs that each
+// resource is expected to have once those steps have completed. A plan is typically recorded by a preview and later
+// used to ensure that an update performs exactly the changes that the preview reported.
+type UpdatePlan struct {
+	ResourcePlans map[resource.URN]*ResourcePlan // the plans for each resource, keyed by URN.
+
+	lock sync.Mutex // guards ResourcePlans while steps are being recorded.
+}
+
+// A ResourcePlan records the steps that an update is expected to perform on a single resource.
+type ResourcePlan struct {
+	Ops     []StepOp             // the steps that are expected to be performed on the resource, in order.
+	Inputs  resource.PropertyMap // the resource's expected inputs; unknown values match any value.
+	Outputs resource.PropertyMap // the resource's outputs, as of the end of the step that recorded them.
+}
+
+// NewUpdatePlan creates a new, empty update plan.
+func NewUpdatePlan() *UpdatePlan {
+	return &UpdatePlan{ResourcePlans: make(map[resource.URN]*ResourcePlan)}
+}
+
+// recordStep records a step that was successfully applied, along with the resulting inputs and outputs of its
+// resource, in the plan.
+func (p *UpdatePlan) recordStep(step Step) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	rp, ok := p.ResourcePlans[step.URN()]
+	if !ok {
+		rp = &ResourcePlan{}
+		p.ResourcePlans[step.URN()] = rp
+	}
+	rp.Ops = append(rp.Ops, step.Op())
+	if new := step.New(); new != nil {
+		rp.Inputs, rp.Outputs = new.Inputs, new.Outputs
+	}
+}
+
+// planChecker ensures that the steps produced by a step generator conform to an update plan. Each step in the plan
+// may be matched by at most one generated step. Same steps never change a resource, and so always conform.
+type planChecker struct {
+	plan      *UpdatePlan               // the plan to which the steps must conform.
+	remaining map[resource.URN][]StepOp // the planned steps that have not yet been matched, keyed by URN.
+}
+
+func newPlanChecker(plan *UpdatePlan) *planChecker {
+	remaining := make(map[resource.URN][]StepOp)
+	for urn, rp := range plan.ResourcePlans {
+		remaining[urn] = append([]StepOp(nil), rp.Ops...)
+	}
+	return &planChecker{plan: plan, remaining: remaining}
+}
+
+// checkStep returns an error that describes how the given step violates the plan, if it does.
+func (c *planChecker) checkStep(step Step) error {
+	op := step.Op()
+	if op == OpSame {
+		return nil
+	}
+
+	ops, found := c.remaining[step.URN()], false
+	for i, planned := range ops {
+		if planned == op {
+			c.remaining[step.URN()], found = append(ops[:i:i], ops[i+1:]...), true
+			break
+		}
+	}
+	if !found {
+		return errors.Errorf("the plan does not include a %s step", op)
+	}
+
+	// If the step produces a new state, its inputs must match those in the plan.
+	rp := c.plan.ResourcePlans[step.URN()]
+	if new := step.New(); new != nil && rp.Inputs != nil {
+		if key, ok := planPropertiesMatch(rp.Inputs, new.Inputs); !ok {
+			return errors.Errorf("the value of input property '%v' does not match the plan", key)
+		}
+	}
+	return nil
+}
+
+// planPropertiesMatch returns true if the given property map matches the property map recorded in a plan. If the maps
+// do not match, the first key whose values differ is also returned.
+func planPropertiesMatch(planned, actual resource.PropertyMap) (resource.PropertyKey, bool) {
+	keys := make(map[resource.PropertyKey]bool)
+	for k := range planned {
+		keys[k] = true
+	}
+	for k := range actual {
+		keys[k] = true
+	}
+	sorted := make([]resource.PropertyKey, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	for _, k := range sorted {
+		// Missing values are treated as null, as nulls are not preserved when a plan is serialized.
+		p, ok := planned[k]
+		if !ok {
+			p = resource.NewNullProperty()
+		}
+		a, ok := actual[k]
+		if !ok {
+			a = resource.NewNullProperty()
+		}
+		if !planValueMatches(p, a) {
+			return k, false
+		}
+	}
+	return "", true
+}
+
+// planValueMatches returns true if the given value matches the value recorded in a plan. Unknown values on either side
+// match any value: the former were not known when the plan was recorded, and the latter will be checked once they are
+// known.
+func planValueMatches(planned, actual resource.PropertyValue) bool {
+	switch {
+	case planned.IsComputed() || planned.IsOutput() || actual.IsComputed() || actual.IsOutput():
+		return true
+	case planned.IsSecret() && actual.IsSecret():
+		return planValueMatches(planned.SecretValue().Element, actual.SecretValue().Element)
+	case planned.IsArray() && actual.IsArray():
+		p, a := planned.ArrayValue(), actual.ArrayValue()
+		if len(p) != len(a) {
+			return false
+		}
+		for i := range p {
+			if !planValueMatches(p[i], a[i]) {
+				return false
+			}
+		}
+		return true
+	case planned.IsObject() && actual.IsObject():
+		_, ok := planPropertiesMatch(planned.ObjectValue(), actual.ObjectValue())
+		return ok
+	default:
+		return planned.DeepEquals(actual)
+	}
+}
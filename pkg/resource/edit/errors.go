@@ -39,3 +39,13 @@ type ResourceProtectedError struct {
 func (ResourceProtectedError) Error() string {
 	return "Can't delete protected resource"
 }
+
+// OperationMissingIDError is returned by RecoverOperation if a pending create cannot be recovered because the ID of the
+// created resource is unknown.
+type OperationMissingIDError struct {
+	Operation resource.Operation
+}
+
+func (e OperationMissingIDError) Error() string {
+	return fmt.Sprintf("the ID of resource %q is unknown", e.Operation.Resource.URN)
+}
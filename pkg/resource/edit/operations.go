@@ -15,6 +15,8 @@
 package edit
 
 import (
+	"github.com/pkg/errors"
+
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/resource/graph"
@@ -88,3 +90,189 @@ func LocateResource(snap *deploy.Snapshot, urn resource.URN) []*resource.State {
 
 	return resources
 }
+
+// RecoverStrategy describes how RecoverOperation resolves an operation that was left pending by an interrupted update.
+type RecoverStrategy string
+
+const (
+	// RecoverRefresh reads the resource's actual state from its provider and records the result.
+	RecoverRefresh RecoverStrategy = "refresh"
+	// RecoverAccept assumes that the operation completed successfully.
+	RecoverAccept RecoverStrategy = "accept"
+	// RecoverDiscard assumes that the operation never took place, leaving the resource's last-known state as-is.
+	RecoverDiscard RecoverStrategy = "discard"
+)
+
+// RecoverStrategies lists all of the supported recovery strategies.
+var RecoverStrategies = []RecoverStrategy{RecoverRefresh, RecoverAccept, RecoverDiscard}
+
+// ReadFunc reads the current outputs of a resource from its provider. If the resource no longer exists, the returned
+// outputs are nil.
+type ReadFunc func(res *resource.State) (resource.PropertyMap, error)
+
+// RecoverOperation resolves a pending operation in the given snapshot using the given strategy, then removes the
+// operation from the snapshot's list of pending operations. The read function is used by RecoverRefresh, and by
+// RecoverAccept to fetch the outputs of a custom resource whose update is accepted.
+//
+// Accepting a pending create records the created resource, which must have an ID if it is a custom resource. Accepting
+// a pending update records the inputs that the update was applying along with the resource's actual outputs, as the
+// outputs that the update produced were never recorded. Accepting a pending delete removes the resource. Refreshing a
+// pending operation records the resource's actual outputs, or removes the resource if it does not exist.
+func RecoverOperation(snap *deploy.Snapshot, op resource.Operation, strategy RecoverStrategy, read ReadFunc) error {
+	contract.Require(snap != nil, "snap")
+	contract.Require(op.Resource != nil, "op.Resource")
+
+	var err error
+	switch strategy {
+	case RecoverRefresh:
+		contract.Require(read != nil, "read")
+		err = refreshOperation(snap, op, read)
+	case RecoverAccept:
+		err = acceptOperation(snap, op, read)
+	case RecoverDiscard:
+		// Nothing to do but remove the operation.
+	default:
+		return errors.Errorf("unknown recovery strategy '%s'", strategy)
+	}
+	if err != nil {
+		return err
+	}
+
+	ops := make([]resource.Operation, 0, len(snap.PendingOperations))
+	for _, pending := range snap.PendingOperations {
+		if pending != op {
+			ops = append(ops, pending)
+		}
+	}
+	snap.PendingOperations = ops
+	return nil
+}
+
+// acceptOperation records the result of a pending operation as if it had completed successfully.
+func acceptOperation(snap *deploy.Snapshot, op resource.Operation, read ReadFunc) error {
+	switch op.Type {
+	case resource.OperationTypeCreating:
+		return recordCreatedResource(snap, op)
+	case resource.OperationTypeUpdating:
+		res, err := locateOperationResource(snap, op)
+		if err != nil {
+			return err
+		}
+		if res.Custom {
+			contract.Require(read != nil, "read")
+			outputs, err := read(res)
+			if err != nil {
+				return errors.Wrapf(err, "reading resource '%v'", res.URN)
+			} else if outputs == nil {
+				return errors.Errorf("resource '%v' no longer exists, so its update cannot be accepted", res.URN)
+			}
+			res.Outputs = outputs
+		}
+		res.Inputs = op.Resource.Inputs
+		return nil
+	case resource.OperationTypeDeleting:
+		res, err := locateOperationResource(snap, op)
+		if err != nil {
+			return err
+		}
+		return removeResource(snap, res)
+	default:
+		return errors.Errorf("unknown operation type '%s'", op.Type)
+	}
+}
+
+// refreshOperation records the actual state of the resource affected by a pending operation.
+func refreshOperation(snap *deploy.Snapshot, op resource.Operation, read ReadFunc) error {
+	res := op.Resource
+	if op.Type != resource.OperationTypeCreating {
+		located, err := locateOperationResource(snap, op)
+		if err != nil {
+			return err
+		}
+		res = located
+	} else if res.Custom && res.ID == "" {
+		return OperationMissingIDError{Operation: op}
+	}
+
+	// Component resources have no provider state to read.
+	if !res.Custom {
+		if op.Type == resource.OperationTypeCreating {
+			return recordCreatedResource(snap, op)
+		}
+		return nil
+	}
+
+	outputs, err := read(res)
+	if err != nil {
+		return errors.Wrapf(err, "reading resource '%v'", res.URN)
+	}
+	switch {
+	case outputs == nil && op.Type == resource.OperationTypeCreating:
+		return nil
+	case outputs == nil:
+		return removeResource(snap, res)
+	default:
+		res.Outputs = outputs
+		if op.Type == resource.OperationTypeCreating {
+			return recordCreatedResource(snap, op)
+		}
+		return nil
+	}
+}
+
+// recordCreatedResource adds the resource created by a pending create operation to the snapshot. If the snapshot
+// already contains resources with the same URN, the create was a replacement: the existing resources are marked for
+// deletion and the new resource is placed before them, as a live resource must precede any resources with the same
+// URN that are pending deletion.
+func recordCreatedResource(snap *deploy.Snapshot, op resource.Operation) error {
+	if op.Resource.Custom && op.Resource.ID == "" {
+		return OperationMissingIDError{Operation: op}
+	}
+
+	inserted := false
+	resources := make([]*resource.State, 0, len(snap.Resources)+1)
+	for _, res := range snap.Resources {
+		if res.URN == op.Resource.URN {
+			if !inserted {
+				resources, inserted = append(resources, op.Resource), true
+			}
+			res.Delete = true
+		}
+		resources = append(resources, res)
+	}
+	if !inserted {
+		resources = append(resources, op.Resource)
+	}
+	snap.Resources = resources
+	return nil
+}
+
+// locateOperationResource returns the resource in the snapshot that is affected by a pending update or delete. A
+// pending update that was recorded without an ID affects the single live resource with its URN.
+func locateOperationResource(snap *deploy.Snapshot, op resource.Operation) (*resource.State, error) {
+	matchLive := op.Resource.ID == "" && op.Type == resource.OperationTypeUpdating
+	for _, res := range LocateResource(snap, op.Resource.URN) {
+		if matchLive && !res.Delete || res.ID == op.Resource.ID {
+			return res, nil
+		}
+	}
+	return nil, errors.Errorf("no resource in the current state matches the pending %s of '%v'", op.Type, op.Resource.URN)
+}
+
+// removeResource removes a resource whose deletion completed from the snapshot. If another resource shares its URN,
+// such as its replacement, any references to the URN remain valid and the resource is simply dropped; otherwise the
+// removal is subject to the same restrictions as DeleteResource.
+func removeResource(snap *deploy.Snapshot, condemnedRes *resource.State) error {
+	if len(LocateResource(snap, condemnedRes.URN)) == 1 {
+		return DeleteResource(snap, condemnedRes)
+	}
+
+	resources := make([]*resource.State, 0, len(snap.Resources)-1)
+	for _, res := range snap.Resources {
+		if res != condemnedRes {
+			resources = append(resources, res)
+		}
+	}
+	snap.Resources = resources
+	return nil
+}
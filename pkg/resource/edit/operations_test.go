@@ -258,3 +258,125 @@ func TestLocateResourceExact(t *testing.T) {
 	assert.Len(t, resList, 1)
 	assert.Contains(t, resList, a)
 }
+
+func NewCustomResource(name, id string, provider *resource.State, deps ...resource.URN) *resource.State {
+	res := NewResource(name, provider, deps...)
+	res.Custom, res.ID = true, resource.ID(id)
+	return res
+}
+
+func TestRecoverAcceptOperations(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewCustomResource("a", "a0", pA)
+	b := NewCustomResource("b", "b0", pA)
+	aNew := NewCustomResource("a", "a1", pA)
+	bNew := NewCustomResource("b", "", pA)
+	bNew.Inputs["key"] = resource.NewStringProperty("new")
+	c := NewCustomResource("c", "c0", pA)
+	snap := NewSnapshot([]*resource.State{
+		pA,
+		a,
+		b,
+		c,
+	})
+	snap.PendingOperations = []resource.Operation{
+		resource.NewOperation(aNew, resource.OperationTypeCreating),
+		resource.NewOperation(bNew, resource.OperationTypeUpdating),
+		resource.NewOperation(c, resource.OperationTypeDeleting),
+	}
+
+	// Only the updated resource should be read.
+	var reads []resource.URN
+	read := func(res *resource.State) (resource.PropertyMap, error) {
+		reads = append(reads, res.URN)
+		return resource.PropertyMap{"actual": resource.NewStringProperty("read")}, nil
+	}
+	for _, op := range append([]resource.Operation(nil), snap.PendingOperations...) {
+		err := RecoverOperation(snap, op, RecoverAccept, read)
+		assert.NoError(t, err)
+	}
+
+	// The create replaced a, the update applied b's new inputs and recorded its actual outputs, and the delete
+	// removed c.
+	assert.Equal(t, []resource.URN{b.URN}, reads)
+	assert.Len(t, snap.PendingOperations, 0)
+	assert.Equal(t, []*resource.State{pA, aNew, a, b}, snap.Resources)
+	assert.True(t, a.Delete)
+	assert.Equal(t, resource.NewStringProperty("new"), b.Inputs["key"])
+	assert.Equal(t, resource.NewStringProperty("read"), b.Outputs["actual"])
+	assert.NoError(t, snap.VerifyIntegrity())
+}
+
+func TestRecoverAcceptCreateMissingID(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewCustomResource("a", "", pA)
+	snap := NewSnapshot([]*resource.State{pA})
+	snap.PendingOperations = []resource.Operation{resource.NewOperation(a, resource.OperationTypeCreating)}
+
+	err := RecoverOperation(snap, snap.PendingOperations[0], RecoverAccept, nil)
+	assert.IsType(t, OperationMissingIDError{}, err)
+	assert.Len(t, snap.PendingOperations, 1)
+	assert.Equal(t, []*resource.State{pA}, snap.Resources)
+}
+
+func TestRecoverRefreshOperations(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewCustomResource("a", "a0", pA)
+	b := NewCustomResource("b", "b0", pA, a.URN)
+	c := NewCustomResource("c", "c0", pA)
+	snap := NewSnapshot([]*resource.State{
+		pA,
+		a,
+		b,
+		c,
+	})
+	snap.PendingOperations = []resource.Operation{
+		resource.NewOperation(NewCustomResource("a", "a0", pA), resource.OperationTypeUpdating),
+		resource.NewOperation(c, resource.OperationTypeDeleting),
+	}
+
+	var reads []resource.URN
+	read := func(res *resource.State) (resource.PropertyMap, error) {
+		reads = append(reads, res.URN)
+		if res == c {
+			return nil, nil
+		}
+		return resource.PropertyMap{"actual": resource.NewStringProperty("read")}, nil
+	}
+	for _, op := range append([]resource.Operation(nil), snap.PendingOperations...) {
+		err := RecoverOperation(snap, op, RecoverRefresh, read)
+		assert.NoError(t, err)
+	}
+
+	// a should have been refreshed in place, and c should have been removed as it no longer exists.
+	assert.Equal(t, []resource.URN{a.URN, c.URN}, reads)
+	assert.Len(t, snap.PendingOperations, 0)
+	assert.Equal(t, []*resource.State{pA, a, b}, snap.Resources)
+	assert.Equal(t, resource.NewStringProperty("read"), a.Outputs["actual"])
+	assert.NoError(t, snap.VerifyIntegrity())
+}
+
+func TestRecoverDiscardOperations(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewCustomResource("a", "a0", pA)
+	aNew := NewCustomResource("a", "", pA)
+	aNew.Inputs["key"] = resource.NewStringProperty("new")
+	b := NewCustomResource("b", "", pA)
+	snap := NewSnapshot([]*resource.State{
+		pA,
+		a,
+	})
+	snap.PendingOperations = []resource.Operation{
+		resource.NewOperation(aNew, resource.OperationTypeUpdating),
+		resource.NewOperation(b, resource.OperationTypeCreating),
+	}
+
+	for _, op := range append([]resource.Operation(nil), snap.PendingOperations...) {
+		err := RecoverOperation(snap, op, RecoverDiscard, nil)
+		assert.NoError(t, err)
+	}
+
+	assert.Len(t, snap.PendingOperations, 0)
+	assert.Equal(t, []*resource.State{pA, a}, snap.Resources)
+	assert.NotContains(t, a.Inputs, resource.PropertyKey("key"))
+}
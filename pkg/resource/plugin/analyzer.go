@@ -32,12 +32,39 @@ type Analyzer interface {
 	Name() tokens.QName
 	// Analyze analyzes a single resource object, and returns any errors that it finds.
 	Analyze(t tokens.Type, props resource.PropertyMap) ([]AnalyzeFailure, error)
+	// AnalyzeStack analyzes all resources in a stack once its program has finished, and returns any errors that it
+	// finds. During an update, the resources have already been created or updated by then.
+	AnalyzeStack(resources []AnalyzerResource) ([]AnalyzeFailure, error)
 	// GetPluginInfo returns this plugin's information.
 	GetPluginInfo() (workspace.PluginInfo, error)
 }
 
+// AnalyzerResource is a resource that is passed to an analyzer as part of a stack.
+type AnalyzerResource struct {
+	URN          resource.URN         // the resource's URN.
+	Type         tokens.Type          // the resource's type.
+	Properties   resource.PropertyMap // the resource's input properties.
+	Parent       resource.URN         // the URN of the resource's parent, if any.
+	Dependencies []resource.URN       // the URNs of the resources on which the resource depends.
+	Provider     string               // the reference of the resource's provider, if any.
+}
+
+// EnforcementLevel indicates how a failure reported by an analyzer is to be treated.
+type EnforcementLevel string
+
+const (
+	// Mandatory failures are reported as errors and fail the preview or update. This is the default.
+	Mandatory EnforcementLevel = "mandatory"
+	// Advisory failures are reported as warnings, but do not fail the preview or update.
+	Advisory EnforcementLevel = "advisory"
+	// Disabled failures are ignored.
+	Disabled EnforcementLevel = "disabled"
+)
+
 // AnalyzeFailure indicates that resource analysis failed; it contains the property and reason for the failure.
 type AnalyzeFailure struct {
-	Property resource.PropertyKey // the property that failed the analysis.
-	Reason   string               // the reason the property failed the analysis.
+	URN              resource.URN         // the resource that failed the analysis, if any.
+	Property         resource.PropertyKey // the property that failed the analysis.
+	Reason           string               // the reason the property failed the analysis.
+	EnforcementLevel EnforcementLevel     // the enforcement level of the failure.
 }
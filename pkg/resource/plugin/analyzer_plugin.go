@@ -16,10 +16,14 @@ package plugin
 
 import (
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"github.com/blang/semver"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
 
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/tokens"
@@ -53,6 +57,31 @@ func NewAnalyzer(host Host, ctx *Context, name tokens.QName) (Analyzer, error) {
 		})
 	}
 
+	return newAnalyzer(host, ctx, name, path)
+}
+
+// getPolicyPackPlugin returns the name and path of the analyzer plugin within the policy pack in the given directory.
+// A policy pack is a directory that contains an analyzer plugin executable, named `pulumi-analyzer-<name>`; the
+// analyzer's name is taken from the executable's name.
+func getPolicyPackPlugin(dir string) (tokens.QName, string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", "", err
+	}
+
+	prefix := fmt.Sprintf("pulumi-%s-", workspace.AnalyzerPlugin)
+	for _, file := range files {
+		if file.IsDir() || !strings.HasPrefix(file.Name(), prefix) {
+			continue
+		}
+		name := strings.TrimPrefix(file.Name(), prefix)
+		name = strings.TrimSuffix(strings.TrimSuffix(name, ".exe"), ".cmd")
+		return tokens.QName(name), filepath.Join(dir, file.Name()), nil
+	}
+	return "", "", errors.Errorf("no analyzer plugin (%s<name>) found in %s", prefix, dir)
+}
+
+func newAnalyzer(host Host, ctx *Context, name tokens.QName, path string) (Analyzer, error) {
 	plug, err := newPlugin(ctx, path, fmt.Sprintf("%v (analyzer)", name), []string{host.ServerAddr()})
 	if err != nil {
 		return nil, err
@@ -93,17 +122,79 @@ func (a *analyzer) Analyze(t tokens.Type, props resource.PropertyMap) ([]Analyze
 		return nil, rpcError
 	}
 
-	var failures []AnalyzeFailure
-	for _, failure := range resp.GetFailures() {
-		failures = append(failures, AnalyzeFailure{
-			Property: resource.PropertyKey(failure.Property),
-			Reason:   failure.Reason,
+	failures := unmarshalAnalyzeFailures(resp.GetFailures())
+	logging.V(7).Infof("%s success: failures=#%d", label, len(failures))
+	return failures, nil
+}
+
+// AnalyzeStack analyzes all resources in a stack once its program has finished, and returns any errors that it finds.
+// During an update, the resources have already been created or updated by then.
+func (a *analyzer) AnalyzeStack(resources []AnalyzerResource) ([]AnalyzeFailure, error) {
+	label := fmt.Sprintf("%s.AnalyzeStack()", a.label())
+	logging.V(7).Infof("%s executing (#resources=%d)", label, len(resources))
+
+	var mresources []*pulumirpc.AnalyzerResource
+	for _, r := range resources {
+		mprops, err := MarshalProperties(r.Properties, MarshalOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		var deps []string
+		for _, dep := range r.Dependencies {
+			deps = append(deps, string(dep))
+		}
+
+		mresources = append(mresources, &pulumirpc.AnalyzerResource{
+			Type:         string(r.Type),
+			Properties:   mprops,
+			Urn:          string(r.URN),
+			Parent:       string(r.Parent),
+			Dependencies: deps,
+			Provider:     r.Provider,
 		})
 	}
+
+	resp, err := a.client.AnalyzeStack(a.ctx.Request(), &pulumirpc.AnalyzeStackRequest{
+		Resources: mresources,
+	})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("%s failed: err=%v", label, rpcError)
+
+		// Analyzers that predate stack analysis do not implement it, in which case there is nothing to report.
+		if rpcError.Code() == codes.Unimplemented {
+			return nil, nil
+		}
+		return nil, rpcError
+	}
+
+	failures := unmarshalAnalyzeFailures(resp.GetFailures())
 	logging.V(7).Infof("%s success: failures=#%d", label, len(failures))
 	return failures, nil
 }
 
+func unmarshalAnalyzeFailures(mfailures []*pulumirpc.AnalyzeFailure) []AnalyzeFailure {
+	var failures []AnalyzeFailure
+	for _, failure := range mfailures {
+		level := Mandatory
+		switch failure.GetEnforcementLevel() {
+		case pulumirpc.EnforcementLevel_ADVISORY:
+			level = Advisory
+		case pulumirpc.EnforcementLevel_DISABLED:
+			level = Disabled
+		}
+
+		failures = append(failures, AnalyzeFailure{
+			URN:              resource.URN(failure.GetUrn()),
+			Property:         resource.PropertyKey(failure.GetProperty()),
+			Reason:           failure.GetReason(),
+			EnforcementLevel: level,
+		})
+	}
+	return failures
+}
+
 // GetPluginInfo returns this plugin's information.
 func (a *analyzer) GetPluginInfo() (workspace.PluginInfo, error) {
 	label := fmt.Sprintf("%s.GetPluginInfo()", a.label())
@@ -0,0 +1,294 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// IsRulesAnalyzer returns true if the analyzer with the given name is a YAML rules file rather than a plugin.
+func IsRulesAnalyzer(name tokens.QName) bool {
+	ext := filepath.Ext(string(name))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// rulesFile is the format of a YAML rules file. For example:
+//
+//	rules:
+//	  - name: no-public-buckets
+//	    type: aws:s3/bucket:Bucket
+//	    property: acl
+//	    notIn: [public-read, public-read-write]
+//	    message: S3 buckets must not be publicly readable
+//	  - type: aws:ec2/instance:Instance
+//	    property: instanceType
+//	    in: [t2.micro, t2.small]
+//	    message: instances must be t2.micro or t2.small
+//	    enforcementLevel: advisory
+type rulesFile struct {
+	Rules []ruleSpec `yaml:"rules"`
+}
+
+// ruleSpec is a single rule within a rules file. A rule applies to resources whose type matches its type token, or, if
+// the token ends in `*`, to resources whose type begins with the token's prefix. The rule's predicates constrain the
+// value of the property at the rule's property path; a resource whose property does not satisfy all of them fails the
+// rule. Properties whose values are unknown are not checked.
+type ruleSpec struct {
+	Name             string           `yaml:"name,omitempty"`             // an optional name for the rule.
+	Type             string           `yaml:"type"`                       // the type token of the resources to check.
+	Property         string           `yaml:"property"`                   // the path of the property to check.
+	Required         bool             `yaml:"required,omitempty"`         // true if the property must be present.
+	In               []interface{}    `yaml:"in,omitempty"`               // values that the property may have.
+	NotIn            []interface{}    `yaml:"notIn,omitempty"`            // values that the property must not have.
+	Pattern          string           `yaml:"pattern,omitempty"`          // a regexp that the property must match.
+	Message          string           `yaml:"message"`                    // the message to report on failure.
+	EnforcementLevel EnforcementLevel `yaml:"enforcementLevel,omitempty"` // the enforcement level of failures.
+}
+
+// rule is a rule that has been validated and prepared for evaluation.
+type rule struct {
+	spec    ruleSpec
+	path    resource.PropertyPath
+	in      []resource.PropertyValue
+	notIn   []resource.PropertyValue
+	pattern *regexp.Regexp
+}
+
+// rulesAnalyzer is an analyzer that runs in-process and checks resources against the rules in a YAML rules file.
+type rulesAnalyzer struct {
+	name  tokens.QName
+	path  string
+	rules []rule
+}
+
+// NewRulesAnalyzer loads the rules in the YAML rules file at the given path and returns an analyzer that checks each
+// resource against them.
+func NewRulesAnalyzer(path string) (Analyzer, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading rules file %s", path)
+	}
+	var file rulesFile
+	if err = yaml.Unmarshal(b, &file); err != nil {
+		return nil, errors.Wrapf(err, "parsing rules file %s", path)
+	}
+
+	rules := make([]rule, len(file.Rules))
+	for i, spec := range file.Rules {
+		r, err := newRule(spec)
+		if err != nil {
+			name := spec.Name
+			if name == "" {
+				name = fmt.Sprintf("#%d", i+1)
+			}
+			return nil, errors.Wrapf(err, "rules file %s: rule %s", path, name)
+		}
+		rules[i] = r
+	}
+
+	// Name the analyzer after its rules file, so that its failures are attributed to e.g. `policies` rather than to the
+	// file's full path.
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return &rulesAnalyzer{name: tokens.QName(name), path: path, rules: rules}, nil
+}
+
+// newRule validates the given rule and prepares it for evaluation.
+func newRule(spec ruleSpec) (rule, error) {
+	switch {
+	case spec.Type == "":
+		return rule{}, errors.New("missing type")
+	case spec.Property == "":
+		return rule{}, errors.New("missing property")
+	case spec.Message == "":
+		return rule{}, errors.New("missing message")
+	case !spec.Required && len(spec.In) == 0 && len(spec.NotIn) == 0 && spec.Pattern == "":
+		return rule{}, errors.New("one of required, in, notIn or pattern must be specified")
+	}
+
+	switch spec.EnforcementLevel {
+	case "":
+		spec.EnforcementLevel = Mandatory
+	case Mandatory, Advisory, Disabled:
+	default:
+		return rule{}, errors.Errorf("unknown enforcement level '%s'", spec.EnforcementLevel)
+	}
+
+	path, err := resource.ParsePropertyPath(spec.Property)
+	if err != nil {
+		return rule{}, errors.Wrap(err, "invalid property")
+	}
+
+	r := rule{spec: spec, path: path}
+	if spec.Pattern != "" {
+		if r.pattern, err = regexp.Compile(spec.Pattern); err != nil {
+			return rule{}, errors.Wrap(err, "invalid pattern")
+		}
+	}
+	if r.in, err = newRuleValues(spec.In); err != nil {
+		return rule{}, errors.Wrap(err, "invalid in")
+	}
+	if r.notIn, err = newRuleValues(spec.NotIn); err != nil {
+		return rule{}, errors.Wrap(err, "invalid notIn")
+	}
+	return r, nil
+}
+
+// newRuleValues converts the given values, as decoded from YAML, into property values.
+func newRuleValues(values []interface{}) ([]resource.PropertyValue, error) {
+	var result []resource.PropertyValue
+	for _, v := range values {
+		v, err := stringKeyed(v)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resource.NewPropertyValue(v))
+	}
+	return result, nil
+}
+
+// stringKeyed replaces each map within the given YAML value with an equivalent map[string]interface{}, since the YAML
+// decoder produces map[interface{}]interface{} values that cannot be turned into property values. It is an error for
+// a map to have a key that is not a string.
+func stringKeyed(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			key, ok := k.(string)
+			if !ok {
+				return nil, errors.Errorf("map key %v is not a string", k)
+			}
+			e, err := stringKeyed(e)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = e
+		}
+		return m, nil
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			e, err := stringKeyed(e)
+			if err != nil {
+				return nil, err
+			}
+			a[i] = e
+		}
+		return a, nil
+	default:
+		return v, nil
+	}
+}
+
+// appliesTo returns true if the rule applies to resources of the given type.
+func (r *rule) appliesTo(t tokens.Type) bool {
+	if prefix := strings.TrimSuffix(r.spec.Type, "*"); prefix != r.spec.Type {
+		return strings.HasPrefix(string(t), prefix)
+	}
+	return string(t) == r.spec.Type
+}
+
+// check returns true if the given properties satisfy the rule.
+func (r *rule) check(props resource.PropertyMap) bool {
+	root := resource.NewObjectProperty(props)
+	v, ok := r.path.Get(root)
+	if !ok {
+		// If an object or array that would contain the property is unknown, the property's value is unknown too.
+		for i := len(r.path) - 1; i > 0; i-- {
+			if container, has := r.path[:i].Get(root); has && (container.IsComputed() || container.IsOutput()) {
+				return true
+			}
+		}
+	}
+	if ok && v.IsSecret() {
+		v = v.SecretValue().Element
+	}
+	if !ok || v.IsNull() {
+		return !r.spec.Required
+	}
+	if v.ContainsUnknowns() {
+		return true
+	}
+
+	if len(r.in) > 0 && !containsValue(r.in, v) {
+		return false
+	}
+	if containsValue(r.notIn, v) {
+		return false
+	}
+	if r.pattern != nil && (!v.IsString() || !r.pattern.MatchString(v.StringValue())) {
+		return false
+	}
+	return true
+}
+
+func containsValue(values []resource.PropertyValue, v resource.PropertyValue) bool {
+	for _, value := range values {
+		if value.DeepEquals(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *rulesAnalyzer) Name() tokens.QName { return a.name }
+
+// Analyze checks a single resource against each rule that applies to its type.
+func (a *rulesAnalyzer) Analyze(t tokens.Type, props resource.PropertyMap) ([]AnalyzeFailure, error) {
+	var failures []AnalyzeFailure
+	for _, r := range a.rules {
+		if r.appliesTo(t) && !r.check(props) {
+			failures = append(failures, AnalyzeFailure{
+				Property:         resource.PropertyKey(r.spec.Property),
+				Reason:           r.spec.Message,
+				EnforcementLevel: r.spec.EnforcementLevel,
+			})
+		}
+	}
+	logging.V(7).Infof("Analyzer[%s].Analyze(%s) success: failures=#%d", a.name, t, len(failures))
+	return failures, nil
+}
+
+// AnalyzeStack does nothing, since each rule applies to a single resource and resources are checked as they are
+// registered.
+func (a *rulesAnalyzer) AnalyzeStack(resources []AnalyzerResource) ([]AnalyzeFailure, error) {
+	return nil, nil
+}
+
+// GetPluginInfo returns this analyzer's information.
+func (a *rulesAnalyzer) GetPluginInfo() (workspace.PluginInfo, error) {
+	return workspace.PluginInfo{
+		Name: string(a.name),
+		Path: a.path,
+		Kind: workspace.AnalyzerPlugin,
+	}, nil
+}
+
+// Close does nothing, since the analyzer runs in-process.
+func (a *rulesAnalyzer) Close() error {
+	return nil
+}
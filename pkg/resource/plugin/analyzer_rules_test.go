@@ -0,0 +1,148 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+const testRules = `
+rules:
+  - name: no-public-buckets
+    type: aws:s3/bucket:Bucket
+    property: acl
+    notIn: [public-read, public-read-write]
+    message: buckets must not be public
+  - type: aws:ec2/instance:Instance
+    property: instanceType
+    in: [t2.micro, t2.small]
+    message: instances must be small
+    enforcementLevel: advisory
+  - type: aws:*
+    property: tags.owner
+    required: true
+    pattern: "^[a-z]+$"
+    message: resources must have an owner
+  - type: aws:lb/listener:Listener
+    property: defaultAction
+    notIn: [{type: forward, ports: [80]}]
+    message: listeners must not forward plain HTTP
+`
+
+func writeRules(t *testing.T, rules string) (string, func()) {
+	dir, err := ioutil.TempDir("", "rules")
+	assert.NoError(t, err)
+	path := filepath.Join(dir, "rules.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(rules), 0600))
+	return path, func() { contract.IgnoreError(os.RemoveAll(dir)) }
+}
+
+func TestRulesAnalyzer(t *testing.T) {
+	path, cleanup := writeRules(t, testRules)
+	defer cleanup()
+
+	assert.True(t, IsRulesAnalyzer(tokens.QName(path)))
+	assert.False(t, IsRulesAnalyzer("policies"))
+
+	analyzer, err := NewRulesAnalyzer(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, tokens.QName("rules"), analyzer.Name())
+
+	tags := resource.NewObjectProperty(resource.PropertyMap{"owner": resource.NewStringProperty("alice")})
+	reasons := func(typ tokens.Type, props resource.PropertyMap) []string {
+		failures, err := analyzer.Analyze(typ, props)
+		assert.NoError(t, err)
+		var reasons []string
+		for _, f := range failures {
+			reasons = append(reasons, string(f.EnforcementLevel)+": "+f.Reason)
+		}
+		return reasons
+	}
+
+	// Resources that satisfy every rule that applies to them pass.
+	assert.Empty(t, reasons("aws:s3/bucket:Bucket", resource.PropertyMap{
+		"acl":  resource.NewStringProperty("private"),
+		"tags": tags,
+	}))
+	assert.Empty(t, reasons("aws:ec2/instance:Instance", resource.PropertyMap{
+		"instanceType": resource.MakeSecret(resource.NewStringProperty("t2.micro")),
+		"tags":         tags,
+	}))
+	assert.Empty(t, reasons("gcp:storage/bucket:Bucket", resource.PropertyMap{}))
+
+	// Unknown values are not checked.
+	assert.Empty(t, reasons("aws:s3/bucket:Bucket", resource.PropertyMap{
+		"acl":  resource.MakeComputed(resource.NewStringProperty("")),
+		"tags": resource.MakeComputed(resource.NewStringProperty("")),
+	}))
+
+	// Each failed rule is reported with its enforcement level.
+	assert.Equal(t, []string{
+		"mandatory: buckets must not be public",
+		"mandatory: resources must have an owner",
+	}, reasons("aws:s3/bucket:Bucket", resource.PropertyMap{"acl": resource.NewStringProperty("public-read")}))
+	assert.Equal(t, []string{
+		"advisory: instances must be small",
+		"mandatory: resources must have an owner",
+	}, reasons("aws:ec2/instance:Instance", resource.PropertyMap{
+		"instanceType": resource.NewStringProperty("m5.large"),
+		"tags": resource.NewObjectProperty(resource.PropertyMap{
+			"owner": resource.NewStringProperty("Alice"),
+		}),
+	}))
+
+	// Values may be objects and arrays.
+	action := func(port float64) resource.PropertyMap {
+		return resource.PropertyMap{
+			"tags": tags,
+			"defaultAction": resource.NewObjectProperty(resource.PropertyMap{
+				"type":  resource.NewStringProperty("forward"),
+				"ports": resource.NewArrayProperty([]resource.PropertyValue{resource.NewNumberProperty(port)}),
+			}),
+		}
+	}
+	assert.Empty(t, reasons("aws:lb/listener:Listener", action(443)))
+	assert.Equal(t, []string{
+		"mandatory: listeners must not forward plain HTTP",
+	}, reasons("aws:lb/listener:Listener", action(80)))
+}
+
+func TestRulesAnalyzerInvalidRules(t *testing.T) {
+	for _, rules := range []string{
+		"rules:\n  - property: acl\n    required: true\n    message: m",
+		"rules:\n  - type: a:b:c\n    required: true\n    message: m",
+		"rules:\n  - type: a:b:c\n    property: acl\n    required: true",
+		"rules:\n  - type: a:b:c\n    property: acl\n    message: m",
+		"rules:\n  - type: a:b:c\n    property: acl\n    pattern: '['\n    message: m",
+		"rules:\n  - type: a:b:c\n    property: acl\n    required: true\n    message: m\n    enforcementLevel: x",
+		"rules:\n  - type: a:b:c\n    property: acl\n    in: [{1: a}]\n    message: m",
+	} {
+		path, cleanup := writeRules(t, rules)
+		_, err := NewRulesAnalyzer(path)
+		assert.Error(t, err, rules)
+		cleanup()
+	}
+}
@@ -47,6 +47,9 @@ type Host interface {
 	// Analyzer fetches the analyzer with a given name, possibly lazily allocating the plugins for it.  If an analyzer
 	// could not be found, or an error occurred while creating it, a non-nil error is returned.
 	Analyzer(nm tokens.QName) (Analyzer, error)
+	// PolicyAnalyzer loads the analyzer plugin within the policy pack in the given directory. Once loaded, the
+	// analyzer may also be fetched by name using Analyzer.
+	PolicyAnalyzer(path string) (Analyzer, error)
 	// Provider loads a new copy of the provider for a given package.  If a provider for this package could not be
 	// found, or an error occurs while creating it, a non-nil error is returned.
 	Provider(pkg tokens.Package, version *semver.Version) (Provider, error)
@@ -188,24 +191,43 @@ func (host *defaultHost) Analyzer(name tokens.QName) (Analyzer, error) {
 			return plug.Plugin, nil
 		}
 
-		// If not, try to load and bind to a plugin.
-		plug, err := NewAnalyzer(host, host.ctx, name)
+		// If not, try to load and bind to a plugin. Rules files are instead loaded by an in-process analyzer.
+		var plug Analyzer
+		var err error
+		if IsRulesAnalyzer(name) {
+			plug, err = NewRulesAnalyzer(string(name))
+		} else {
+			plug, err = NewAnalyzer(host, host.ctx, name)
+		}
 		if err == nil && plug != nil {
-			info, infoerr := plug.GetPluginInfo()
-			if infoerr != nil {
-				return nil, infoerr
-			}
+			err = host.registerAnalyzer(name, plug)
+		}
+		return plug, err
+	})
+	if plugin == nil || err != nil {
+		return nil, err
+	}
+	return plugin.(Analyzer), nil
+}
 
-			// Memoize the result.
-			host.plugins = append(host.plugins, info)
-			host.analyzerPlugins[name] = &analyzerPlugin{Plugin: plug, Info: info}
-			if host.events != nil {
-				if eventerr := host.events.OnPluginLoad(info); eventerr != nil {
-					return nil, errors.Wrapf(eventerr, "failed to perform plugin load callback")
-				}
-			}
+func (host *defaultHost) PolicyAnalyzer(path string) (Analyzer, error) {
+	plugin, err := host.loadPlugin(func() (interface{}, error) {
+		name, bin, err := getPolicyPackPlugin(path)
+		if err != nil {
+			return nil, err
+		}
+
+		// First see if we already loaded this plugin.
+		if plug, has := host.analyzerPlugins[name]; has {
+			contract.Assert(plug != nil)
+			return plug.Plugin, nil
 		}
 
+		// If not, try to load and bind to the policy pack's plugin.
+		plug, err := newAnalyzer(host, host.ctx, name, bin)
+		if err == nil && plug != nil {
+			err = host.registerAnalyzer(name, plug)
+		}
 		return plug, err
 	})
 	if plugin == nil || err != nil {
@@ -214,6 +236,23 @@ func (host *defaultHost) Analyzer(name tokens.QName) (Analyzer, error) {
 	return plugin.(Analyzer), nil
 }
 
+// registerAnalyzer memoizes a newly loaded analyzer plugin under the given name.
+func (host *defaultHost) registerAnalyzer(name tokens.QName, plug Analyzer) error {
+	info, err := plug.GetPluginInfo()
+	if err != nil {
+		return err
+	}
+
+	host.plugins = append(host.plugins, info)
+	host.analyzerPlugins[name] = &analyzerPlugin{Plugin: plug, Info: info}
+	if host.events != nil {
+		if eventerr := host.events.OnPluginLoad(info); eventerr != nil {
+			return errors.Wrapf(eventerr, "failed to perform plugin load callback")
+		}
+	}
+	return nil
+}
+
 func (host *defaultHost) Provider(pkg tokens.Package, version *semver.Version) (Provider, error) {
 	plugin, err := host.loadPlugin(func() (interface{}, error) {
 		// Try to load and bind to a plugin.
@@ -377,6 +416,9 @@ func (host *defaultHost) GetRequiredPlugins(info ProgInfo, kinds Flags) ([]works
 	// TODO: these are currently not versioned.  We probably need to let folks specify versions in Pulumi.yaml.
 	if info.Proj.Analyzers != nil && kinds&AnalyzerPlugins != 0 {
 		for _, analyzer := range *info.Proj.Analyzers {
+			if IsRulesAnalyzer(analyzer) {
+				continue
+			}
 			plugins = append(plugins, workspace.PluginInfo{
 				Name: string(analyzer),
 				Kind: workspace.AnalyzerPlugin,
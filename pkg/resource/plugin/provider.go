@@ -15,6 +15,7 @@
 package plugin
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/pulumi/pulumi/pkg/resource"
@@ -52,18 +53,22 @@ type Provider interface {
 	// Diff checks what impacts a hypothetical update will have on the resource's properties.
 	Diff(urn resource.URN, id resource.ID, olds resource.PropertyMap, news resource.PropertyMap,
 		allowUnknowns bool) (DiffResult, error)
-	// Create allocates a new instance of the provided resource and returns its unique resource.ID.
-	Create(urn resource.URN, news resource.PropertyMap) (resource.ID, resource.PropertyMap, resource.Status, error)
+	// Create allocates a new instance of the provided resource and returns its unique resource.ID. The timeout, if
+	// non-zero, is the number of seconds the provider should allow for the operation to complete.
+	Create(urn resource.URN, news resource.PropertyMap,
+		timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error)
 	// Read the current live state associated with a resource.  Enough state must be include in the inputs to uniquely
 	// identify the resource; this is typically just the resource ID, but may also include some properties.  If the
 	// resource is missing (for instance, because it has been deleted), the resulting property map will be nil.
 	Read(urn resource.URN, id resource.ID,
 		props resource.PropertyMap) (resource.PropertyMap, resource.Status, error)
-	// Update updates an existing resource with new values.
-	Update(urn resource.URN, id resource.ID,
-		olds resource.PropertyMap, news resource.PropertyMap) (resource.PropertyMap, resource.Status, error)
-	// Delete tears down an existing resource.
-	Delete(urn resource.URN, id resource.ID, props resource.PropertyMap) (resource.Status, error)
+	// Update updates an existing resource with new values. The timeout, if non-zero, is the number of seconds the
+	// provider should allow for the operation to complete.
+	Update(urn resource.URN, id resource.ID, olds resource.PropertyMap, news resource.PropertyMap,
+		timeout float64) (resource.PropertyMap, resource.Status, error)
+	// Delete tears down an existing resource. The timeout, if non-zero, is the number of seconds the provider should
+	// allow for the operation to complete.
+	Delete(urn resource.URN, id resource.ID, props resource.PropertyMap, timeout float64) (resource.Status, error)
 	// Invoke dynamically executes a built-in function in the provider.
 	Invoke(tok tokens.ModuleMember, args resource.PropertyMap) (resource.PropertyMap, []CheckFailure, error)
 	// GetPluginInfo returns this plugin's information.
@@ -95,12 +100,74 @@ const (
 	DiffSome DiffChanges = 2
 )
 
+// DiffKind represents the kind of diff that applies to a particular property.
+type DiffKind int
+
+const (
+	// DiffAdd indicates that the property was added.
+	DiffAdd DiffKind = 0
+	// DiffAddReplace indicates that the property was added and requires that the resource be replaced.
+	DiffAddReplace DiffKind = 1
+	// DiffDelete indicates that the property was deleted.
+	DiffDelete DiffKind = 2
+	// DiffDeleteReplace indicates that the property was deleted and requires that the resource be replaced.
+	DiffDeleteReplace DiffKind = 3
+	// DiffUpdate indicates that the property was updated.
+	DiffUpdate DiffKind = 4
+	// DiffUpdateReplace indicates that the property was updated and requires that the resource be replaced.
+	DiffUpdateReplace DiffKind = 5
+)
+
+// String returns the string representation of the given DiffKind.
+func (d DiffKind) String() string {
+	switch d {
+	case DiffAdd:
+		return "add"
+	case DiffAddReplace:
+		return "add-replace"
+	case DiffDelete:
+		return "delete"
+	case DiffDeleteReplace:
+		return "delete-replace"
+	case DiffUpdate:
+		return "update"
+	case DiffUpdateReplace:
+		return "update-replace"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(d))
+	}
+}
+
+// IsValid returns true if the given DiffKind is one of the known kinds of diff.
+func (d DiffKind) IsValid() bool {
+	return d >= DiffAdd && d <= DiffUpdateReplace
+}
+
+// IsReplace returns true if the given DiffKind requires that the resource be replaced.
+func (d DiffKind) IsReplace() bool {
+	switch d {
+	case DiffAddReplace, DiffDeleteReplace, DiffUpdateReplace:
+		return true
+	default:
+		return false
+	}
+}
+
+// PropertyDiff describes the difference between a single property's old and new values.
+type PropertyDiff struct {
+	Kind      DiffKind // The kind of diff.
+	InputDiff bool     // True if this is a diff between old and new inputs rather than old state and new inputs.
+}
+
 // DiffResult indicates whether an operation should replace or update an existing resource.
 type DiffResult struct {
 	Changes             DiffChanges            // true if this diff represents a changed resource.
 	ReplaceKeys         []resource.PropertyKey // an optional list of replacement keys.
 	StableKeys          []resource.PropertyKey // an optional list of property keys that are stable.
 	DeleteBeforeReplace bool                   // if true, this resource must be deleted before recreating it.
+	// DetailedDiff is an optional map from property paths to the differences reported by the provider for those
+	// properties. If nil, the provider did not report a detailed diff.
+	DetailedDiff map[string]PropertyDiff
 }
 
 // Replace returns true if this diff represents a replacement.
@@ -15,8 +15,10 @@
 package plugin
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/blang/semver"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
@@ -230,6 +232,9 @@ func (p *provider) Check(urn resource.URN,
 		}
 	}
 
+	// Secrets are sent to the provider as plain values, so re-mark any inputs that were secret on the way in.
+	annotateSecrets(inputs, news)
+
 	// And now any properties that failed verification.
 	var failures []CheckFailure
 	for _, failure := range resp.GetFailures() {
@@ -302,16 +307,49 @@ func (p *provider) Diff(urn resource.URN, id resource.ID,
 	deleteBeforeReplace := resp.GetDeleteBeforeReplace()
 	logging.V(7).Infof("%s success: changes=%d #replaces=%v #stables=%v delbefrepl=%v",
 		label, changes, replaces, stables, deleteBeforeReplace)
+
+	// Only record a detailed diff if the provider explicitly reported one: an empty detailed diff is meaningful, as it
+	// indicates that no properties have changed.
+	var detailedDiff map[string]PropertyDiff
+	if resp.GetHasDetailedDiff() {
+		detailedDiff = make(map[string]PropertyDiff)
+		for path, pd := range resp.GetDetailedDiff() {
+			// A newer provider may report a kind of diff that we do not know about. Treat such diffs as updates: whether
+			// or not the resource must be replaced is decided by the provider's replace keys.
+			kind := DiffKind(pd.GetKind())
+			if !kind.IsValid() {
+				logging.V(7).Infof("%s: unknown diff kind %d for property %s; treating it as an update",
+					label, pd.GetKind(), path)
+				kind = DiffUpdate
+			}
+			detailedDiff[path] = PropertyDiff{
+				Kind:      kind,
+				InputDiff: pd.GetInputDiff(),
+			}
+		}
+	}
+
 	return DiffResult{
 		Changes:             DiffChanges(changes),
 		ReplaceKeys:         replaces,
 		StableKeys:          stables,
 		DeleteBeforeReplace: deleteBeforeReplace,
+		DetailedDiff:        detailedDiff,
 	}, nil
 }
 
+// requestWithTimeout allocates a request sub-context for an operation with the given timeout, in seconds. If the
+// timeout is non-zero, the request is cancelled once it elapses, so that a provider that ignores the timeout cannot
+// keep the operation in flight indefinitely.
+func (p *provider) requestWithTimeout(timeout float64) (context.Context, context.CancelFunc) {
+	if timeout == 0 {
+		return context.WithCancel(p.ctx.Request())
+	}
+	return context.WithTimeout(p.ctx.Request(), time.Duration(timeout*float64(time.Second)))
+}
+
 // Create allocates a new instance of the provided resource and assigns its unique resource.ID and outputs afterwards.
-func (p *provider) Create(urn resource.URN, props resource.PropertyMap) (resource.ID,
+func (p *provider) Create(urn resource.URN, props resource.PropertyMap, timeout float64) (resource.ID,
 	resource.PropertyMap, resource.Status, error) {
 	contract.Assert(urn != "")
 	contract.Assert(props != nil)
@@ -337,9 +375,12 @@ func (p *provider) Create(urn resource.URN, props resource.PropertyMap) (resourc
 	var liveObject *_struct.Struct
 	var resourceError error
 	var resourceStatus = resource.StatusOK
-	resp, err := client.Create(p.ctx.Request(), &pulumirpc.CreateRequest{
+	ctx, cancel := p.requestWithTimeout(timeout)
+	defer cancel()
+	resp, err := client.Create(ctx, &pulumirpc.CreateRequest{
 		Urn:        string(urn),
 		Properties: mprops,
+		Timeout:    timeout,
 	})
 	if err != nil {
 		resourceStatus, id, liveObject, resourceError = parseError(err)
@@ -364,6 +405,7 @@ func (p *provider) Create(urn resource.URN, props resource.PropertyMap) (resourc
 	if err != nil {
 		return "", nil, resourceStatus, err
 	}
+	annotateSecrets(outs, props)
 
 	logging.V(7).Infof("%s success: id=%s; #outs=%d", label, id, len(outs))
 	if resourceError == nil {
@@ -437,14 +479,15 @@ func (p *provider) Read(
 	if err != nil {
 		return nil, resourceStatus, err
 	}
+	annotateSecrets(results, props)
 
 	logging.V(7).Infof("%s success; #outs=%d", label, len(results))
 	return results, resourceStatus, resourceError
 }
 
 // Update updates an existing resource with new values.
-func (p *provider) Update(urn resource.URN, id resource.ID,
-	olds resource.PropertyMap, news resource.PropertyMap) (resource.PropertyMap, resource.Status, error) {
+func (p *provider) Update(urn resource.URN, id resource.ID, olds resource.PropertyMap, news resource.PropertyMap,
+	timeout float64) (resource.PropertyMap, resource.Status, error) {
 	contract.Assert(urn != "")
 	contract.Assert(id != "")
 	contract.Assert(news != nil)
@@ -475,11 +518,14 @@ func (p *provider) Update(urn resource.URN, id resource.ID,
 	var liveObject *_struct.Struct
 	var resourceError error
 	var resourceStatus = resource.StatusOK
-	resp, err := client.Update(p.ctx.Request(), &pulumirpc.UpdateRequest{
-		Id:   string(id),
-		Urn:  string(urn),
-		Olds: molds,
-		News: mnews,
+	ctx, cancel := p.requestWithTimeout(timeout)
+	defer cancel()
+	resp, err := client.Update(ctx, &pulumirpc.UpdateRequest{
+		Id:      string(id),
+		Urn:     string(urn),
+		Olds:    molds,
+		News:    mnews,
+		Timeout: timeout,
 	})
 	if err != nil {
 		resourceStatus, _, liveObject, resourceError = parseError(err)
@@ -498,6 +544,7 @@ func (p *provider) Update(urn resource.URN, id resource.ID,
 	if err != nil {
 		return nil, resourceStatus, err
 	}
+	annotateSecrets(outs, news)
 
 	logging.V(7).Infof("%s success; #outs=%d", label, len(outs))
 	if resourceError == nil {
@@ -507,7 +554,9 @@ func (p *provider) Update(urn resource.URN, id resource.ID,
 }
 
 // Delete tears down an existing resource.
-func (p *provider) Delete(urn resource.URN, id resource.ID, props resource.PropertyMap) (resource.Status, error) {
+func (p *provider) Delete(urn resource.URN, id resource.ID, props resource.PropertyMap,
+	timeout float64) (resource.Status, error) {
+
 	contract.Assert(urn != "")
 	contract.Assert(id != "")
 
@@ -528,10 +577,13 @@ func (p *provider) Delete(urn resource.URN, id resource.ID, props resource.Prope
 	// We should only be calling {Create,Update,Delete} if the provider is fully configured.
 	contract.Assert(p.cfgknown)
 
-	if _, err := client.Delete(p.ctx.Request(), &pulumirpc.DeleteRequest{
+	ctx, cancel := p.requestWithTimeout(timeout)
+	defer cancel()
+	if _, err := client.Delete(ctx, &pulumirpc.DeleteRequest{
 		Id:         string(id),
 		Urn:        string(urn),
 		Properties: mprops,
+		Timeout:    timeout,
 	}); err != nil {
 		resourceStatus, rpcErr := resourceStateAndError(err)
 		logging.V(7).Infof("%s failed: %v", label, rpcErr)
@@ -668,6 +720,36 @@ func createConfigureError(rpcerr *rpcerror.Error) error {
 	return rpcerr
 }
 
+// annotateSecrets marks any property in outs as secret if the property at the same path in ins is secret. Providers
+// receive and return secrets as plain values, so this is how secretness is carried from inputs through to outputs.
+// Secrets nested inside objects and arrays are marked as well.
+func annotateSecrets(outs, ins resource.PropertyMap) {
+	for k, v := range outs {
+		if in, has := ins[k]; has {
+			outs[k] = annotateSecretValue(v, in)
+		}
+	}
+}
+
+// annotateSecretValue returns the given output value, marked as secret if the corresponding input value is secret.
+// If both values are objects or arrays, their elements are annotated in place.
+func annotateSecretValue(out, in resource.PropertyValue) resource.PropertyValue {
+	switch {
+	case out.IsSecret():
+		return out
+	case in.IsSecret():
+		return resource.MakeSecret(out)
+	case out.IsObject() && in.IsObject():
+		annotateSecrets(out.ObjectValue(), in.ObjectValue())
+	case out.IsArray() && in.IsArray():
+		outArr, inArr := out.ArrayValue(), in.ArrayValue()
+		for i := 0; i < len(outArr) && i < len(inArr); i++ {
+			outArr[i] = annotateSecretValue(outArr[i], inArr[i])
+		}
+	}
+	return out
+}
+
 // resourceStateAndError interprets an error obtained from a gRPC endpoint.
 //
 // gRPC gives us a `status.Status` structure as an `error` whenever our
@@ -677,12 +759,17 @@ func createConfigureError(rpcerr *rpcerror.Error) error {
 //
 // In general, our resource state is only really unknown if the server
 // had an internal error, in which case it will serve one of `codes.Internal`,
-// `codes.DataLoss`, or `codes.Unknown` to us.
+// `codes.DataLoss`, or `codes.Unknown` to us. An error that the server has
+// marked as retryable never leaves the resource's state unknown.
 func resourceStateAndError(err error) (resource.Status, *rpcerror.Error) {
 	rpcError := rpcerror.Convert(err)
 	logging.V(8).Infof("provider received rpc error `%s`: `%s`", rpcError.Code(), rpcError.Message())
+	if rpcError.Retryable() {
+		logging.V(8).Infof("rpc error kind `%s` is retryable", rpcError.Code())
+		return resource.StatusOK, rpcError
+	}
 	switch rpcError.Code() {
-	case codes.Internal, codes.DataLoss, codes.Unknown:
+	case codes.Internal, codes.DataLoss, codes.Unknown, codes.DeadlineExceeded:
 		logging.V(8).Infof("rpc error kind `%s` may not be recoverable", rpcError.Code())
 		return resource.StatusUnknown, rpcError
 	}
@@ -0,0 +1,75 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+func TestAnnotateSecrets(t *testing.T) {
+	secret := func(v string) resource.PropertyValue {
+		return resource.MakeSecret(resource.NewStringProperty(v))
+	}
+
+	ins := resource.PropertyMap{
+		"top":   secret("a"),
+		"plain": resource.NewStringProperty("b"),
+		"nested": resource.NewObjectProperty(resource.PropertyMap{
+			"password": secret("c"),
+			"user":     resource.NewStringProperty("d"),
+		}),
+		"list": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("e"), secret("f")}),
+	}
+
+	// Providers return secret inputs as plain values, possibly alongside outputs that have no corresponding input.
+	outs := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"top":   "a",
+		"plain": "b",
+		"nested": map[string]interface{}{
+			"password": "c",
+			"user":     "d",
+			"id":       "g",
+		},
+		"list":  []interface{}{"e", "f", "h"},
+		"extra": "i",
+	})
+	annotateSecrets(outs, ins)
+
+	assert.Equal(t, secret("a"), outs["top"])
+	assert.Equal(t, resource.NewStringProperty("b"), outs["plain"])
+	nested := outs["nested"].ObjectValue()
+	assert.Equal(t, secret("c"), nested["password"])
+	assert.Equal(t, resource.NewStringProperty("d"), nested["user"])
+	assert.Equal(t, resource.NewStringProperty("g"), nested["id"])
+	list := outs["list"].ArrayValue()
+	assert.Equal(t, resource.NewStringProperty("e"), list[0])
+	assert.Equal(t, secret("f"), list[1])
+	assert.Equal(t, resource.NewStringProperty("h"), list[2])
+	assert.Equal(t, resource.NewStringProperty("i"), outs["extra"])
+}
+
+func TestDiffKind(t *testing.T) {
+	assert.True(t, DiffUpdateReplace.IsValid())
+	assert.Equal(t, "update-replace", DiffUpdateReplace.String())
+
+	// Kinds reported by newer providers are invalid, but may still be displayed.
+	unknown := DiffKind(42)
+	assert.False(t, unknown.IsValid())
+	assert.Equal(t, "unknown(42)", unknown.String())
+}
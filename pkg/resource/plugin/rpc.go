@@ -34,6 +34,7 @@ type MarshalOptions struct {
 	RejectUnknowns     bool   // true if we should return errors on unknown values. Takes precedence over KeepUnknowns.
 	ElideAssetContents bool   // true if we are eliding the contents of assets.
 	ComputeAssetHashes bool   // true if we are computing missing asset hashes on the fly.
+	KeepSecrets        bool   // true if we are keeping secrets (otherwise we replace them with their underlying value).
 }
 
 const (
@@ -140,6 +141,16 @@ func MarshalPropertyValue(v resource.PropertyValue, opts MarshalOptions) (*struc
 			return marshalUnknownProperty(v.OutputValue().Element, opts), nil
 		}
 		return nil, nil // return nil and the caller will ignore it.
+	} else if v.IsSecret() {
+		if !opts.KeepSecrets {
+			logging.V(5).Infof("marshalling secret value as raw value as opts.KeepSecrets is false")
+			return MarshalPropertyValue(v.SecretValue().Element, opts)
+		}
+		secret := resource.NewObjectProperty(resource.PropertyMap{
+			resource.SigKey: resource.NewStringProperty(resource.SecretSig),
+			"value":         v.SecretValue().Element,
+		})
+		return MarshalPropertyValue(secret, opts)
 	}
 
 	contract.Failf("Unrecognized property value in RPC[%s]: %v (type=%v)", opts.Label, v.V, reflect.TypeOf(v.V))
@@ -170,6 +181,8 @@ func marshalUnknownProperty(elem resource.PropertyValue, opts MarshalOptions) *s
 		return marshalUnknownProperty(elem.Input().Element, opts)
 	} else if elem.IsOutput() {
 		return marshalUnknownProperty(elem.OutputValue().Element, opts)
+	} else if elem.IsSecret() {
+		return marshalUnknownProperty(elem.SecretValue().Element, opts)
 	}
 
 	// Finally, if a null, we can guess its value!  (the one and only...)
@@ -296,7 +309,14 @@ func UnmarshalPropertyValue(v *structpb.Value, opts MarshalOptions) (*resource.P
 				m := resource.NewArchiveProperty(archive)
 				return &m, nil
 			case resource.SecretSig:
-				return nil, errors.New("this version of the Pulumi SDK does not support first-class secrets")
+				// Note that a missing value (e.g. a null that was skipped during marshaling) is treated as null.
+				value := obj["value"]
+				if !opts.KeepSecrets {
+					logging.V(5).Infof("unmarshalling secret as raw value, as opts.KeepSecrets is false")
+					return &value, nil
+				}
+				m := resource.MakeSecret(value)
+				return &m, nil
 			default:
 				return nil, errors.Errorf("unrecognized signature '%v' in property map", sig)
 			}
@@ -135,14 +135,28 @@ func TestComputedReject(t *testing.T) {
 	}
 }
 
-func TestUnsupportedSecret(t *testing.T) {
-	rawProp := resource.NewObjectProperty(resource.NewPropertyMapFromMap(map[string]interface{}{
-		resource.SigKey: resource.SecretSig,
-	}))
-	prop, err := MarshalPropertyValue(rawProp, MarshalOptions{})
+func TestSecretMarshaling(t *testing.T) {
+	secret := resource.MakeSecret(resource.NewStringProperty("hunter2"))
+
+	// When secrets are kept, they round trip as secrets.
+	prop, err := MarshalPropertyValue(secret, MarshalOptions{KeepSecrets: true})
 	assert.Nil(t, err)
-	_, err = UnmarshalPropertyValue(prop, MarshalOptions{})
-	assert.Error(t, err)
+	assert.Equal(t, resource.SecretSig,
+		prop.GetStructValue().Fields[resource.SigKey].GetStringValue())
+	secretU, err := UnmarshalPropertyValue(prop, MarshalOptions{KeepSecrets: true})
+	assert.Nil(t, err)
+	assert.True(t, secretU.IsSecret())
+	assert.Equal(t, "hunter2", secretU.SecretValue().Element.StringValue())
+
+	// Otherwise, they are replaced with their underlying values on both ends of the wire.
+	secretU, err = UnmarshalPropertyValue(prop, MarshalOptions{})
+	assert.Nil(t, err)
+	assert.True(t, secretU.IsString())
+	assert.Equal(t, "hunter2", secretU.StringValue())
+
+	prop, err = MarshalPropertyValue(secret, MarshalOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "hunter2", prop.GetStringValue())
 }
 
 func TestUnknownSig(t *testing.T) {
@@ -86,6 +86,12 @@ type Output struct {
 	Element PropertyValue // the eventual value (type) of the output property.
 }
 
+// Secret indicates that the underlying value should be persisted securely.  Secret values are encrypted when they are
+// written to a checkpoint and are masked when they are displayed.
+type Secret struct {
+	Element PropertyValue // the underlying value of the secret property.
+}
+
 type ReqError struct {
 	K PropertyKey
 }
@@ -117,6 +123,16 @@ func (m PropertyMap) ContainsUnknowns() bool {
 	return false
 }
 
+// ContainsSecrets returns true if the property map contains at least one secret value.
+func (m PropertyMap) ContainsSecrets() bool {
+	for _, v := range m {
+		if v.ContainsSecrets() {
+			return true
+		}
+	}
+	return false
+}
+
 // Mappable returns a mapper-compatible object map, suitable for deserialization into structures.
 func (m PropertyMap) Mappable() map[string]interface{} {
 	return m.MapRepl(nil, nil)
@@ -148,6 +164,19 @@ func (m PropertyMap) Copy() PropertyMap {
 	return new
 }
 
+// MapSecrets returns a copy of the map in which each secret value (deeply) has been replaced with the result of calling
+// the given function on it.
+func (m PropertyMap) MapSecrets(f func(s *Secret) PropertyValue) PropertyMap {
+	if m == nil {
+		return nil
+	}
+	new := make(PropertyMap)
+	for k, v := range m {
+		new[k] = v.MapSecrets(f)
+	}
+	return new
+}
+
 // Merge simply merges in another map atop another, and returns the result.
 func (m PropertyMap) Merge(other PropertyMap) PropertyMap {
 	new := m.Copy()
@@ -182,6 +211,7 @@ func NewArchiveProperty(v *Archive) PropertyValue      { return PropertyValue{v}
 func NewObjectProperty(v PropertyMap) PropertyValue    { return PropertyValue{v} }
 func NewComputedProperty(v Computed) PropertyValue     { return PropertyValue{v} }
 func NewOutputProperty(v Output) PropertyValue         { return PropertyValue{v} }
+func NewSecretProperty(v *Secret) PropertyValue        { return PropertyValue{v} }
 
 func MakeComputed(v PropertyValue) PropertyValue {
 	return NewComputedProperty(Computed{Element: v})
@@ -191,6 +221,10 @@ func MakeOutput(v PropertyValue) PropertyValue {
 	return NewOutputProperty(Output{Element: v})
 }
 
+func MakeSecret(v PropertyValue) PropertyValue {
+	return NewSecretProperty(&Secret{Element: v})
+}
+
 // NewPropertyValue turns a value into a property value, provided it is of a legal "JSON-like" kind.
 func NewPropertyValue(v interface{}) PropertyValue {
 	return NewPropertyValueRepl(v, nil, nil)
@@ -242,6 +276,8 @@ func NewPropertyValueRepl(v interface{},
 		return NewComputedProperty(t)
 	case Output:
 		return NewOutputProperty(t)
+	case *Secret:
+		return NewSecretProperty(t)
 	}
 
 	// Next, see if it's an array, slice, pointer or struct, and handle each accordingly.
@@ -313,6 +349,28 @@ func (v PropertyValue) ContainsUnknowns() bool {
 		}
 	} else if v.IsObject() {
 		return v.ObjectValue().ContainsUnknowns()
+	} else if v.IsSecret() {
+		return v.SecretValue().Element.ContainsUnknowns()
+	}
+	return false
+}
+
+// ContainsSecrets returns true if the property value contains at least one secret (deeply).
+func (v PropertyValue) ContainsSecrets() bool {
+	if v.IsSecret() {
+		return true
+	} else if v.IsComputed() {
+		return v.Input().Element.ContainsSecrets()
+	} else if v.IsOutput() {
+		return v.OutputValue().Element.ContainsSecrets()
+	} else if v.IsArray() {
+		for _, e := range v.ArrayValue() {
+			if e.ContainsSecrets() {
+				return true
+			}
+		}
+	} else if v.IsObject() {
+		return v.ObjectValue().ContainsSecrets()
 	}
 	return false
 }
@@ -344,6 +402,9 @@ func (v PropertyValue) Input() Computed { return v.V.(Computed) }
 // OutputValue fetches the underlying output value (panicking if it isn't a output).
 func (v PropertyValue) OutputValue() Output { return v.V.(Output) }
 
+// SecretValue fetches the underlying secret value (panicking if it isn't a secret).
+func (v PropertyValue) SecretValue() *Secret { return v.V.(*Secret) }
+
 // IsNull returns true if the underlying value is a null.
 func (v PropertyValue) IsNull() bool {
 	return v.V == nil
@@ -403,6 +464,12 @@ func (v PropertyValue) IsOutput() bool {
 	return is
 }
 
+// IsSecret returns true if the underlying value is a secret value.
+func (v PropertyValue) IsSecret() bool {
+	_, is := v.V.(*Secret)
+	return is
+}
+
 // TypeString returns a type representation of the property value's holder type.
 func (v PropertyValue) TypeString() string {
 	if v.IsNull() {
@@ -425,11 +492,36 @@ func (v PropertyValue) TypeString() string {
 		return "output<" + v.Input().Element.TypeString() + ">"
 	} else if v.IsOutput() {
 		return "output<" + v.OutputValue().Element.TypeString() + ">"
+	} else if v.IsSecret() {
+		return "secret<" + v.SecretValue().Element.TypeString() + ">"
 	}
 	contract.Failf("Unrecognized PropertyValue type")
 	return ""
 }
 
+// MapSecrets returns a copy of the value in which each secret value (deeply) has been replaced with the result of
+// calling the given function on it.
+func (v PropertyValue) MapSecrets(f func(s *Secret) PropertyValue) PropertyValue {
+	switch {
+	case v.IsSecret():
+		return f(v.SecretValue())
+	case v.IsArray():
+		arr := make([]PropertyValue, len(v.ArrayValue()))
+		for i, e := range v.ArrayValue() {
+			arr[i] = e.MapSecrets(f)
+		}
+		return NewArrayProperty(arr)
+	case v.IsObject():
+		return NewObjectProperty(v.ObjectValue().MapSecrets(f))
+	case v.IsComputed():
+		return MakeComputed(v.Input().Element.MapSecrets(f))
+	case v.IsOutput():
+		return MakeOutput(v.OutputValue().Element.MapSecrets(f))
+	default:
+		return v
+	}
+}
+
 // Mappable returns a mapper-compatible value, suitable for deserialization into structures.
 func (v PropertyValue) Mappable() interface{} {
 	return v.MapRepl(nil, nil)
@@ -466,6 +558,8 @@ func (v PropertyValue) MapRepl(replk func(string) (string, bool),
 		return v.Input()
 	} else if v.IsOutput() {
 		return v.OutputValue()
+	} else if v.IsSecret() {
+		return v.SecretValue()
 	}
 	contract.Assertf(v.IsObject(), "v is not Object '%v' instead", v.TypeString())
 	return v.ObjectValue().MapRepl(replk, replv)
@@ -512,6 +606,9 @@ func (v PropertyValue) String() string {
 	if v.IsComputed() || v.IsOutput() {
 		// For computed and output properties, show their type followed by an empty object string.
 		return fmt.Sprintf("%v{}", v.TypeString())
+	} else if v.IsSecret() {
+		// For secret properties, never show the underlying value.
+		return "[secret]"
 	}
 	// For all others, just display the underlying property value.
 	return fmt.Sprintf("{%v}", v.V)
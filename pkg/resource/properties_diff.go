@@ -218,6 +218,15 @@ func (v PropertyValue) Diff(other PropertyValue) *ValueDiff {
 		return nil
 	}
 
+	// Secrets are diffed by their underlying values. The diff itself does not describe the changes to those values
+	// so that they are not accidentally revealed.
+	if v.IsSecret() && other.IsSecret() {
+		if v.SecretValue().Element.Diff(other.SecretValue().Element) == nil {
+			return nil
+		}
+		return &ValueDiff{Old: v, New: other}
+	}
+
 	// If we got here, either the values are primitives, or they weren't the same type; do a simple diff.
 	if v.DeepEquals(other) {
 		return nil
@@ -292,6 +301,14 @@ func (v PropertyValue) DeepEquals(other PropertyValue) bool {
 		return vo.DeepEquals(oa)
 	}
 
+	// Secret values are equal if their underlying values are deeply equal.
+	if v.IsSecret() {
+		if !other.IsSecret() {
+			return false
+		}
+		return v.SecretValue().Element.DeepEquals(other.SecretValue().Element)
+	}
+
 	// For all other cases, primitives are equal if their values are equal.
 	return v.V == other.V
 }
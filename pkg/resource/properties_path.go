@@ -0,0 +1,308 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// PropertyPath represents a path to a nested property. The path may be composed of strings (which access properties
+// in ObjectProperty values) and integers (which access elements of ArrayProperty values).
+type PropertyPath []interface{}
+
+// ParsePropertyPath parses a property path into a PropertyPath value.
+//
+// A property path string is essentially a Javascript property access expression in which all elements are literals.
+// Valid property paths obey the following EBNF-ish grammar:
+//
+//	propertyName := [a-zA-Z_$] { [a-zA-Z0-9_$] }
+//	quotedPropertyName := '"' ( '\' '"' | [^"] ) { ( '\' '"' | [^"] ) } '"'
+//	arrayIndex := { [0-9] }
+//
+//	propertyIndex := '[' ( quotedPropertyName | arrayIndex ) ']'
+//	rootProperty := ( propertyName | propertyIndex )
+//	propertyAccessor := ( ( '.' propertyName ) |  propertyIndex )
+//	path := rootProperty { propertyAccessor }
+//
+// Examples of valid paths:
+// - root
+// - root.nested
+// - root["nested"]
+// - root.double.nest
+// - root["double"].nest
+// - root["double"]["nest"]
+// - root.array[0]
+// - root.array[100]
+// - root.array[0].nested
+// - root.array[0][1].nested
+// - root.nested.array[0].double[1]
+// - root["key with \"escaped\" quotes"]
+// - root["key with a ."]
+// - ["root key with \"escaped\" quotes"].nested
+// - ["root key with a ."][100]
+func ParsePropertyPath(path string) (PropertyPath, error) {
+	// We interpret the grammar above a little loosely in order to keep things simple. Specifically, we will accept
+	// something close to the following:
+	// pathElement := { '.' } ( '[' ( [0-9]+ | '"' ('\' '"' | [^"] )+ '"' ']' | [a-zA-Z_$][a-zA-Z0-9_$] )
+	// path := { pathElement }
+	var elements []interface{}
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+		case '[':
+			// If the character following the '[' is a '"', parse a string key.
+			var pathElement interface{}
+			if len(path) > 1 && path[1] == '"' {
+				var propertyKey []byte
+				var i int
+				for i = 2; ; {
+					if i >= len(path) {
+						return nil, errors.New("missing closing quote in property name")
+					} else if path[i] == '"' {
+						i++
+						break
+					} else if path[i] == '\\' && i+1 < len(path) && path[i+1] == '"' {
+						propertyKey = append(propertyKey, '"')
+						i += 2
+					} else {
+						propertyKey = append(propertyKey, path[i])
+						i++
+					}
+				}
+				if i >= len(path) || path[i] != ']' {
+					return nil, errors.New("missing closing bracket in property access")
+				}
+				pathElement, path = string(propertyKey), path[i:]
+			} else {
+				// Look for a closing ']'
+				rbracket := strings.IndexRune(path, ']')
+				if rbracket == -1 {
+					return nil, errors.New("missing closing bracket in array index")
+				}
+
+				index, err := strconv.ParseUint(path[1:rbracket], 10, 31)
+				if err != nil {
+					return nil, errors.Wrap(err, "invalid array index")
+				}
+				pathElement, path = int(index), path[rbracket:]
+			}
+			elements, path = append(elements, pathElement), path[1:]
+		default:
+			for i := 0; ; i++ {
+				if i == len(path) || path[i] == '.' || path[i] == '[' {
+					elements, path = append(elements, path[:i]), path[i:]
+					break
+				}
+			}
+		}
+	}
+	if len(elements) == 0 {
+		return nil, errors.New("empty property path")
+	}
+	return PropertyPath(elements), nil
+}
+
+// String returns the string representation of the path, suitable for parsing with ParsePropertyPath.
+func (p PropertyPath) String() string {
+	var b strings.Builder
+	for i, element := range p {
+		switch element := element.(type) {
+		case int:
+			b.WriteString("[" + strconv.Itoa(element) + "]")
+		case string:
+			if isSimplePropertyName(element) {
+				if i > 0 {
+					b.WriteRune('.')
+				}
+				b.WriteString(element)
+			} else {
+				b.WriteString(`["` + strings.Replace(element, `"`, `\"`, -1) + `"]`)
+			}
+		default:
+			contract.Failf("unexpected property path element of type %T", element)
+		}
+	}
+	return b.String()
+}
+
+// isSimplePropertyName returns true if the given property name does not need to be quoted in a property path.
+func isSimplePropertyName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		isAlpha := c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !isAlpha && (i == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Get attempts to get the value located by the PropertyPath inside the given PropertyValue. If any component of the
+// path does not exist, this function will return (NewNullProperty(), false).
+func (p PropertyPath) Get(v PropertyValue) (PropertyValue, bool) {
+	for _, key := range p {
+		switch {
+		case v.IsArray():
+			index, ok := key.(int)
+			if !ok || index < 0 || index >= len(v.ArrayValue()) {
+				return NewNullProperty(), false
+			}
+			v = v.ArrayValue()[index]
+		case v.IsObject():
+			k, ok := key.(string)
+			if !ok {
+				return NewNullProperty(), false
+			}
+			v, ok = v.ObjectValue()[PropertyKey(k)]
+			if !ok {
+				return NewNullProperty(), false
+			}
+		default:
+			return NewNullProperty(), false
+		}
+	}
+	return v, true
+}
+
+// Set attempts to set the location inside a PropertyValue indicated by the PropertyPath to the given value. Any
+// objects or arrays along the path are copied rather than modified in place, so values that share structure with
+// dest are left untouched. If any component of the path besides the last component does not exist, this function
+// will return false.
+func (p PropertyPath) Set(dest, v PropertyValue) (PropertyValue, bool) {
+	return p.update(dest, func(container PropertyValue, key interface{}) (PropertyValue, bool) {
+		switch key := key.(type) {
+		case int:
+			arr := container.ArrayValue()
+			if key >= len(arr) {
+				return container, false
+			}
+			arr[key] = v
+			return NewArrayProperty(arr), true
+		case string:
+			container.ObjectValue()[PropertyKey(key)] = v
+			return container, true
+		default:
+			return container, false
+		}
+	})
+}
+
+// Delete attempts to delete the value located by the PropertyPath inside the given PropertyValue. Deleting an element
+// of an array removes it from the array. As with Set, any objects or arrays along the path are copied rather than
+// modified in place. If any component of the path does not exist, this function will return false.
+func (p PropertyPath) Delete(dest PropertyValue) (PropertyValue, bool) {
+	return p.update(dest, func(container PropertyValue, key interface{}) (PropertyValue, bool) {
+		switch key := key.(type) {
+		case int:
+			arr := container.ArrayValue()
+			if key >= len(arr) {
+				return container, false
+			}
+			return NewArrayProperty(append(arr[:key], arr[key+1:]...)), true
+		case string:
+			obj := container.ObjectValue()
+			if _, has := obj[PropertyKey(key)]; !has {
+				return container, false
+			}
+			delete(obj, PropertyKey(key))
+			return container, true
+		default:
+			return container, false
+		}
+	})
+}
+
+// Reset attempts to reset the value located by the PropertyPath inside the new map to its value in the old map. If
+// the path does not exist in the old map, the value is deleted from the new map. Only the top-level keys of the new
+// map are modified in place. If any component of the path besides the last component does not exist in the new map,
+// this function will return false.
+func (p PropertyPath) Reset(old, new PropertyMap) bool {
+	var result PropertyValue
+	var ok bool
+	if oldValue, has := p.Get(NewObjectProperty(old)); has {
+		result, ok = p.Set(NewObjectProperty(new), oldValue)
+	} else {
+		// If the value is missing from both maps, there is nothing to do.
+		if _, has = p.Get(NewObjectProperty(new)); !has {
+			return true
+		}
+		result, ok = p.Delete(NewObjectProperty(new))
+	}
+	if !ok {
+		return false
+	}
+
+	// Copy the updated top-level keys back into the new map.
+	updated := result.ObjectValue()
+	for k := range new {
+		if _, has := updated[k]; !has {
+			delete(new, k)
+		}
+	}
+	for k, v := range updated {
+		new[k] = v
+	}
+	return true
+}
+
+// update walks to the container that holds the last component of the path, copying each container along the way,
+// and then invokes the given function to modify that container. The (possibly new) root value is returned.
+func (p PropertyPath) update(dest PropertyValue,
+	leaf func(container PropertyValue, key interface{}) (PropertyValue, bool)) (PropertyValue, bool) {
+
+	if len(p) == 0 {
+		return dest, false
+	}
+
+	// Copy the container so that we do not modify any structure that we share with other values.
+	var container PropertyValue
+	switch key := p[0].(type) {
+	case int:
+		if !dest.IsArray() || key < 0 {
+			return dest, false
+		}
+		container = NewArrayProperty(append([]PropertyValue(nil), dest.ArrayValue()...))
+	case string:
+		if !dest.IsObject() {
+			return dest, false
+		}
+		container = NewObjectProperty(dest.ObjectValue().Copy())
+	default:
+		return dest, false
+	}
+
+	if len(p) == 1 {
+		return leaf(container, p[0])
+	}
+
+	// Recurse into the child and store the result in our copy of the container.
+	child, ok := PropertyPath{p[0]}.Get(container)
+	if !ok {
+		return dest, false
+	}
+	newChild, ok := p[1:].update(child, leaf)
+	if !ok {
+		return dest, false
+	}
+	return PropertyPath{p[0]}.Set(container, newChild)
+}
@@ -0,0 +1,163 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropertyPath(t *testing.T) {
+	cases := []struct {
+		path   string
+		parsed PropertyPath
+	}{
+		{"root", PropertyPath{"root"}},
+		{"root.nested", PropertyPath{"root", "nested"}},
+		{`root["nested"]`, PropertyPath{"root", "nested"}},
+		{"root.double.nest", PropertyPath{"root", "double", "nest"}},
+		{`root["double"].nest`, PropertyPath{"root", "double", "nest"}},
+		{`root["double"]["nest"]`, PropertyPath{"root", "double", "nest"}},
+		{"root.array[0]", PropertyPath{"root", "array", 0}},
+		{"root.array[100]", PropertyPath{"root", "array", 100}},
+		{"root.array[0].nested", PropertyPath{"root", "array", 0, "nested"}},
+		{"root.array[0][1].nested", PropertyPath{"root", "array", 0, 1, "nested"}},
+		{"root.nested.array[0].double[1]", PropertyPath{"root", "nested", "array", 0, "double", 1}},
+		{`root["key with \"escaped\" quotes"]`, PropertyPath{"root", `key with "escaped" quotes`}},
+		{`root["key with a ."]`, PropertyPath{"root", "key with a ."}},
+		{`["root key with \"escaped\" quotes"].nested`, PropertyPath{`root key with "escaped" quotes`, "nested"}},
+		{`["root key with a ."][100]`, PropertyPath{"root key with a .", 100}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			parsed, err := ParsePropertyPath(c.path)
+			assert.NoError(t, err)
+			assert.Equal(t, c.parsed, parsed)
+
+			reparsed, err := ParsePropertyPath(parsed.String())
+			assert.NoError(t, err)
+			assert.Equal(t, parsed, reparsed)
+		})
+	}
+
+	negativeCases := []string{
+		"",
+		`root["nested`,
+		`root["nested"`,
+		"root[0",
+		"root[-1]",
+		"root[abc]",
+	}
+
+	for _, c := range negativeCases {
+		t.Run(c, func(t *testing.T) {
+			_, err := ParsePropertyPath(c)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPropertyPathGetSetDelete(t *testing.T) {
+	value := NewObjectProperty(NewPropertyMapFromMap(map[string]interface{}{
+		"tags": map[string]interface{}{
+			"owner": "alice",
+			"team":  "infra",
+		},
+		"ports": []interface{}{80, 443},
+	}))
+
+	path, err := ParsePropertyPath("tags.owner")
+	assert.NoError(t, err)
+
+	v, ok := path.Get(value)
+	assert.True(t, ok)
+	assert.Equal(t, NewStringProperty("alice"), v)
+
+	// Setting a value must not modify the original.
+	updated, ok := path.Set(value, NewStringProperty("bob"))
+	assert.True(t, ok)
+	v, _ = path.Get(updated)
+	assert.Equal(t, NewStringProperty("bob"), v)
+	v, _ = path.Get(value)
+	assert.Equal(t, NewStringProperty("alice"), v)
+
+	// Deleting a value must not modify the original.
+	updated, ok = path.Delete(value)
+	assert.True(t, ok)
+	_, ok = path.Get(updated)
+	assert.False(t, ok)
+	_, ok = path.Get(value)
+	assert.True(t, ok)
+
+	index, err := ParsePropertyPath("ports[1]")
+	assert.NoError(t, err)
+
+	v, ok = index.Get(value)
+	assert.True(t, ok)
+	assert.Equal(t, NewNumberProperty(443), v)
+
+	updated, ok = index.Set(value, NewNumberProperty(8443))
+	assert.True(t, ok)
+	v, _ = index.Get(updated)
+	assert.Equal(t, NewNumberProperty(8443), v)
+	v, _ = index.Get(value)
+	assert.Equal(t, NewNumberProperty(443), v)
+
+	// Missing intermediate elements cause Set to fail.
+	missing, err := ParsePropertyPath("labels.owner")
+	assert.NoError(t, err)
+	_, ok = missing.Set(value, NewStringProperty("bob"))
+	assert.False(t, ok)
+
+	// Out-of-range indices cause Get and Set to fail.
+	outOfRange, err := ParsePropertyPath("ports[2]")
+	assert.NoError(t, err)
+	_, ok = outOfRange.Get(value)
+	assert.False(t, ok)
+	_, ok = outOfRange.Set(value, NewNumberProperty(22))
+	assert.False(t, ok)
+}
+
+func TestPropertyPathReset(t *testing.T) {
+	old := NewPropertyMapFromMap(map[string]interface{}{
+		"tags": map[string]interface{}{
+			"owner": "alice",
+		},
+		"count": 3,
+	})
+	new := NewPropertyMapFromMap(map[string]interface{}{
+		"tags": map[string]interface{}{
+			"owner": "bob",
+			"team":  "infra",
+		},
+		"count": 5,
+		"extra": true,
+	})
+	original := new.Copy()
+
+	for _, p := range []string{"tags.owner", "tags.team", "count", "extra", "missing"} {
+		path, err := ParsePropertyPath(p)
+		assert.NoError(t, err)
+		assert.True(t, path.Reset(old, new))
+	}
+
+	assert.Equal(t, old, new)
+
+	// The nested values in the original map must be untouched.
+	assert.Equal(t, NewStringProperty("bob"), original["tags"].ObjectValue()["owner"])
+	assert.Equal(t, NewStringProperty("infra"), original["tags"].ObjectValue()["team"])
+}
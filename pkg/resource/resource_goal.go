@@ -32,12 +32,21 @@ type Goal struct {
 	InitErrors           []string              // errors encountered as we attempted to initialize the resource.
 	PropertyDependencies map[PropertyKey][]URN // the set of dependencies that affect each property.
 	DeleteBeforeReplace  bool                  // true if this resource should be deleted prior to replacement.
+	Aliases              []URN                 // additional URNs that should be considered the same as this resource.
+	IgnoreChanges        []string              // a list of property paths to ignore when diffing.
+	CustomTimeouts       CustomTimeouts        // timeouts for the resource's create, update, and delete operations.
+	ID                   ID                    // the ID of an existing resource to import, if any.
+	RetainOnDelete       bool                  // true if this resource should be retained rather than deleted.
+	RetryPolicy          RetryPolicy           // the policy for retrying the resource's operations, if any.
+	ReplaceOnChanges     []string              // a list of property paths whose changes force a replacement.
 }
 
 // NewGoal allocates a new resource goal state.
 func NewGoal(t tokens.Type, name tokens.QName, custom bool, props PropertyMap,
 	parent URN, protect bool, dependencies []URN, provider string, initErrors []string,
-	propertyDependencies map[PropertyKey][]URN, deleteBeforeReplace bool) *Goal {
+	propertyDependencies map[PropertyKey][]URN, deleteBeforeReplace bool, aliases []URN,
+	ignoreChanges []string, customTimeouts CustomTimeouts, id ID, retainOnDelete bool,
+	retryPolicy RetryPolicy, replaceOnChanges []string) *Goal {
 
 	return &Goal{
 		Type:                 t,
@@ -51,5 +60,12 @@ func NewGoal(t tokens.Type, name tokens.QName, custom bool, props PropertyMap,
 		InitErrors:           initErrors,
 		PropertyDependencies: propertyDependencies,
 		DeleteBeforeReplace:  deleteBeforeReplace,
+		Aliases:              aliases,
+		IgnoreChanges:        ignoreChanges,
+		CustomTimeouts:       customTimeouts,
+		ID:                   id,
+		RetainOnDelete:       retainOnDelete,
+		RetryPolicy:          retryPolicy,
+		ReplaceOnChanges:     replaceOnChanges,
 	}
 }
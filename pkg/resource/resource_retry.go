@@ -0,0 +1,86 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RetryPolicy describes how a resource's create, update, and delete operations are retried when they fail with a
+// transient error. Delays are in seconds. A zero value for any field means that the field is unset, in which case a
+// default applies; a policy whose MaxAttempts is unset or one does not retry at all.
+type RetryPolicy struct {
+	MaxAttempts int     `json:"maxAttempts,omitempty" yaml:"maxAttempts,omitempty"` // the maximum number of attempts.
+	Delay       float64 `json:"delay,omitempty" yaml:"delay,omitempty"`             // the delay before the first retry.
+	MaxDelay    float64 `json:"maxDelay,omitempty" yaml:"maxDelay,omitempty"`       // the maximum delay between retries.
+	Backoff     float64 `json:"backoff,omitempty" yaml:"backoff,omitempty"`         // the multiplier for each delay.
+	Jitter      float64 `json:"jitter,omitempty" yaml:"jitter,omitempty"`           // the fraction by which delays vary.
+}
+
+// ParseRetryPolicy parses a retry policy. The delay and maximum delay must each either be empty or a string that is
+// accepted by time.ParseDuration (e.g. "500ms" or "1m"). The backoff multiplier must be either zero or at least one,
+// and the jitter fraction must be between zero and one.
+func ParseRetryPolicy(maxAttempts int, delay, maxDelay string, backoff, jitter float64) (RetryPolicy, error) {
+	if maxAttempts < 0 {
+		return RetryPolicy{}, errors.Errorf("maximum attempts %d must not be negative", maxAttempts)
+	}
+	if backoff != 0 && backoff < 1 {
+		return RetryPolicy{}, errors.Errorf("backoff %v must be at least 1", backoff)
+	}
+	if jitter < 0 || jitter > 1 {
+		return RetryPolicy{}, errors.Errorf("jitter %v must be between 0 and 1", jitter)
+	}
+
+	policy := RetryPolicy{MaxAttempts: maxAttempts, Backoff: backoff, Jitter: jitter}
+	for _, d := range []struct {
+		value string
+		dest  *float64
+	}{{delay, &policy.Delay}, {maxDelay, &policy.MaxDelay}} {
+		if d.value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(d.value)
+		if err != nil {
+			return RetryPolicy{}, err
+		} else if duration < 0 {
+			return RetryPolicy{}, errors.Errorf("delay %q must not be negative", d.value)
+		}
+		*d.dest = duration.Seconds()
+	}
+	return policy, nil
+}
+
+// Override returns a copy of this policy in which each field that is set in the given policy replaces the
+// corresponding field of this policy.
+func (p RetryPolicy) Override(o RetryPolicy) RetryPolicy {
+	if o.MaxAttempts != 0 {
+		p.MaxAttempts = o.MaxAttempts
+	}
+	if o.Delay != 0 {
+		p.Delay = o.Delay
+	}
+	if o.MaxDelay != 0 {
+		p.MaxDelay = o.MaxDelay
+	}
+	if o.Backoff != 0 {
+		p.Backoff = o.Backoff
+	}
+	if o.Jitter != 0 {
+		p.Jitter = o.Jitter
+	}
+	return p
+}
@@ -39,13 +39,18 @@ type State struct {
 	Provider             string                // the provider to use for this resource.
 	PropertyDependencies map[PropertyKey][]URN // the set of dependencies that affect each property.
 	PendingReplacement   bool                  // true if this resource was deleted and is awaiting replacement.
+	CustomTimeouts       CustomTimeouts        // the resource's timeouts for create, update, and delete operations.
+	RetainOnDelete       bool                  // true if this resource should be retained rather than deleted.
+	RetryPolicy          RetryPolicy           // the policy for retrying the resource's operations, if any.
+	ReplaceOnChanges     []string              // a list of property paths whose changes force a replacement.
 }
 
 // NewState creates a new resource value from existing resource state information.
 func NewState(t tokens.Type, urn URN, custom bool, del bool, id ID,
 	inputs PropertyMap, outputs PropertyMap, parent URN, protect bool,
 	external bool, dependencies []URN, initErrors []string, provider string,
-	propertyDependencies map[PropertyKey][]URN, pendingReplacement bool) *State {
+	propertyDependencies map[PropertyKey][]URN, pendingReplacement bool, customTimeouts CustomTimeouts,
+	retainOnDelete bool, retryPolicy RetryPolicy, replaceOnChanges []string) *State {
 
 	contract.Assertf(t != "", "type was empty")
 	contract.Assertf(custom || id == "", "is custom or had empty ID")
@@ -66,6 +71,10 @@ func NewState(t tokens.Type, urn URN, custom bool, del bool, id ID,
 		Provider:             provider,
 		PropertyDependencies: propertyDependencies,
 		PendingReplacement:   pendingReplacement,
+		CustomTimeouts:       customTimeouts,
+		RetainOnDelete:       retainOnDelete,
+		RetryPolicy:          retryPolicy,
+		ReplaceOnChanges:     replaceOnChanges,
 	}
 }
 
@@ -0,0 +1,56 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CustomTimeouts holds the timeouts, in seconds, that apply to a resource's create, update, and delete operations. A
+// timeout of zero means that the corresponding operation is not subject to a timeout.
+type CustomTimeouts struct {
+	Create float64 `json:"create,omitempty" yaml:"create,omitempty"`
+	Update float64 `json:"update,omitempty" yaml:"update,omitempty"`
+	Delete float64 `json:"delete,omitempty" yaml:"delete,omitempty"`
+}
+
+// ParseCustomTimeouts parses the given create, update, and delete timeouts, each of which must either be empty or a
+// string that is accepted by time.ParseDuration (e.g. "5m" or "1h30m").
+func ParseCustomTimeouts(create, update, delete string) (CustomTimeouts, error) {
+	var timeouts CustomTimeouts
+	for _, t := range []struct {
+		value string
+		dest  *float64
+	}{{create, &timeouts.Create}, {update, &timeouts.Update}, {delete, &timeouts.Delete}} {
+		if t.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(t.value)
+		if err != nil {
+			return CustomTimeouts{}, err
+		} else if d < 0 {
+			return CustomTimeouts{}, errors.Errorf("timeout %q must not be negative", t.value)
+		}
+		*t.dest = d.Seconds()
+	}
+	return timeouts, nil
+}
+
+// TimeoutDuration converts a timeout in seconds into a time.Duration.
+func TimeoutDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
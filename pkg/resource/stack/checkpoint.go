@@ -79,19 +79,34 @@ func UnmarshalVersionedCheckpointToLatestCheckpoint(bytes []byte) (*apitype.Chec
 	}
 }
 
-// SerializeCheckpoint turns a snapshot into a data structure suitable for serialization.
-func SerializeCheckpoint(stack tokens.QName, config config.Map, snap *deploy.Snapshot) *apitype.VersionedCheckpoint {
+// SerializeCheckpoint turns a snapshot into a data structure suitable for serialization. Any secret values in the
+// snapshot are encrypted using the given encrypter.
+func SerializeCheckpoint(stack tokens.QName, config config.Map, tags map[apitype.StackTagName]string,
+	snap *deploy.Snapshot, enc config.Encrypter) (*apitype.VersionedCheckpoint, error) {
+
 	// If snap is nil, that's okay, we will just create an empty deployment; otherwise, serialize the whole snapshot.
 	var latest *apitype.DeploymentV3
 	if snap != nil {
-		latest = SerializeDeployment(snap)
+		dep, err := SerializeDeployment(snap, enc)
+		if err != nil {
+			return nil, errors.Wrap(err, "serializing deployment")
+		}
+		latest = dep
 	}
 
-	b, err := json.Marshal(apitype.CheckpointV3{
+	return VersionCheckpoint(&apitype.CheckpointV3{
 		Stack:  stack,
 		Config: config,
 		Latest: latest,
-	})
+		Tags:   tags,
+	}), nil
+}
+
+// VersionCheckpoint wraps the given checkpoint in a versioned checkpoint at the current schema version.
+func VersionCheckpoint(chk *apitype.CheckpointV3) *apitype.VersionedCheckpoint {
+	contract.Require(chk != nil, "chk")
+
+	b, err := json.Marshal(chk)
 	contract.AssertNoError(err)
 
 	return &apitype.VersionedCheckpoint{
@@ -101,23 +116,39 @@ func SerializeCheckpoint(stack tokens.QName, config config.Map, snap *deploy.Sna
 }
 
 // DeserializeCheckpoint takes a serialized deployment record and returns its associated snapshot. Returns nil
-// if there have been no deployments performed on this checkpoint.
-func DeserializeCheckpoint(chkpoint *apitype.CheckpointV3) (*deploy.Snapshot, error) {
+// if there have been no deployments performed on this checkpoint. Any secret values in the checkpoint are decrypted
+// using the given decrypter.
+func DeserializeCheckpoint(chkpoint *apitype.CheckpointV3, dec config.Decrypter) (*deploy.Snapshot, error) {
 	contract.Require(chkpoint != nil, "chkpoint")
 	if chkpoint.Latest != nil {
-		return DeserializeDeploymentV3(*chkpoint.Latest)
+		return DeserializeDeploymentV3(*chkpoint.Latest, dec)
 	}
 
 	return nil, nil
 }
 
 // GetRootStackResource returns the root stack resource from a given snapshot, or nil if not found.  If the stack
-// exists, its output properties, if any, are also returned in the resulting map.
-func GetRootStackResource(snap *deploy.Snapshot) (*resource.State, map[string]interface{}) {
+// exists, its output properties, if any, are also returned in the resulting map.  Secret outputs are replaced with
+// "[secret]" unless showSecrets is true.
+func GetRootStackResource(snap *deploy.Snapshot, showSecrets bool) (*resource.State, map[string]interface{}) {
 	if snap != nil {
 		for _, res := range snap.Resources {
 			if res.Type == resource.RootStackType {
-				return res, SerializeResource(res).Outputs
+				if res.Outputs == nil {
+					return res, nil
+				}
+
+				outputs := res.Outputs.MapSecrets(func(s *resource.Secret) resource.PropertyValue {
+					if showSecrets {
+						return s.Element
+					}
+					return resource.NewStringProperty("[secret]")
+				})
+
+				// All secrets have been replaced above, so nothing will need to be encrypted.
+				serialized, err := SerializeProperties(outputs, config.NewPanicCrypter())
+				contract.AssertNoError(err)
+				return res, serialized
 			}
 		}
 	}
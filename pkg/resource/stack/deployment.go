@@ -24,6 +24,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/apitype/migrate"
 	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/workspace"
@@ -47,51 +48,81 @@ var (
 	ErrDeploymentSchemaVersionTooNew = fmt.Errorf("this stack's deployment version is too new")
 )
 
-// SerializeDeployment serializes an entire snapshot as a deploy record.
-func SerializeDeployment(snap *deploy.Snapshot) *apitype.DeploymentV3 {
+// SerializeDeployment serializes an entire snapshot as a deploy record. Any secret values in the snapshot are
+// encrypted using the given encrypter.
+func SerializeDeployment(snap *deploy.Snapshot, enc config.Encrypter) (*apitype.DeploymentV3, error) {
 	contract.Require(snap != nil, "snap")
 
 	// Capture the version information into a manifest.
-	manifest := apitype.ManifestV1{
-		Time:    snap.Manifest.Time,
-		Magic:   snap.Manifest.Magic,
-		Version: snap.Manifest.Version,
-	}
-	for _, plug := range snap.Manifest.Plugins {
-		var version string
-		if plug.Version != nil {
-			version = plug.Version.String()
-		}
-		manifest.Plugins = append(manifest.Plugins, apitype.PluginInfoV1{
-			Name:    plug.Name,
-			Path:    plug.Path,
-			Type:    plug.Kind,
-			Version: version,
-		})
-	}
+	manifest := serializeManifest(snap.Manifest)
 
 	// Serialize all vertices and only include a vertex section if non-empty.
 	var resources []apitype.ResourceV3
 	for _, res := range snap.Resources {
-		resources = append(resources, SerializeResource(res))
+		sres, err := SerializeResource(res, enc)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, sres)
 	}
 
 	var operations []apitype.OperationV2
 	for _, op := range snap.PendingOperations {
-		operations = append(operations, SerializeOperation(op))
+		sop, err := SerializeOperation(op, enc)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, sop)
 	}
 
 	return &apitype.DeploymentV3{
 		Manifest:          manifest,
 		Resources:         resources,
 		PendingOperations: operations,
+	}, nil
+}
+
+// serializeManifest turns a snapshot's manifest into a structure suitable for serialization.
+func serializeManifest(m deploy.Manifest) apitype.ManifestV1 {
+	manifest := apitype.ManifestV1{
+		Time:    m.Time,
+		Magic:   m.Magic,
+		Version: m.Version,
+	}
+	for _, plug := range m.Plugins {
+		var version string
+		if plug.Version != nil {
+			version = plug.Version.String()
+		}
+		manifest.Plugins = append(manifest.Plugins, apitype.PluginInfoV1{
+			Name:    plug.Name,
+			Path:    plug.Path,
+			Type:    plug.Kind,
+			Version: version,
+		})
 	}
+	return manifest
 }
 
 // DeserializeUntypedDeployment deserializes an untyped deployment and produces a `deploy.Snapshot`
 // from it. DeserializeDeployment will return an error if the untyped deployment's version is
-// not within the range `DeploymentSchemaVersionCurrent` and `DeploymentSchemaVersionOldestSupported`.
-func DeserializeUntypedDeployment(deployment *apitype.UntypedDeployment) (*deploy.Snapshot, error) {
+// not within the range `DeploymentSchemaVersionCurrent` and `DeploymentSchemaVersionOldestSupported`. Any secret values
+// in the deployment are decrypted using the given decrypter.
+func DeserializeUntypedDeployment(deployment *apitype.UntypedDeployment,
+	dec config.Decrypter) (*deploy.Snapshot, error) {
+
+	v3deployment, err := UnmarshalUntypedDeployment(deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeserializeDeploymentV3(*v3deployment, dec)
+}
+
+// UnmarshalUntypedDeployment unmarshals an untyped deployment into a DeploymentV3, migrating it from an older schema
+// version if necessary. UnmarshalUntypedDeployment will return an error if the untyped deployment's version is not
+// within the range `DeploymentSchemaVersionCurrent` and `DeploymentSchemaVersionOldestSupported`.
+func UnmarshalUntypedDeployment(deployment *apitype.UntypedDeployment) (*apitype.DeploymentV3, error) {
 	contract.Require(deployment != nil, "deployment")
 	switch {
 	case deployment.Version > apitype.DeploymentSchemaVersionCurrent:
@@ -123,11 +154,11 @@ func DeserializeUntypedDeployment(deployment *apitype.UntypedDeployment) (*deplo
 		contract.Failf("unrecognized version: %d", deployment.Version)
 	}
 
-	return DeserializeDeploymentV3(v3deployment)
+	return &v3deployment, nil
 }
 
 // DeserializeDeploymentV3 deserializes a typed DeploymentV3 into a `deploy.Snapshot`.
-func DeserializeDeploymentV3(deployment apitype.DeploymentV3) (*deploy.Snapshot, error) {
+func DeserializeDeploymentV3(deployment apitype.DeploymentV3, dec config.Decrypter) (*deploy.Snapshot, error) {
 	// Unpack the versions.
 	manifest := deploy.Manifest{
 		Time:    deployment.Manifest.Time,
@@ -153,7 +184,7 @@ func DeserializeDeploymentV3(deployment apitype.DeploymentV3) (*deploy.Snapshot,
 	// For every serialized resource vertex, create a ResourceDeployment out of it.
 	var resources []*resource.State
 	for _, res := range deployment.Resources {
-		desres, err := DeserializeResource(res)
+		desres, err := DeserializeResource(res, dec)
 		if err != nil {
 			return nil, err
 		}
@@ -162,7 +193,7 @@ func DeserializeDeploymentV3(deployment apitype.DeploymentV3) (*deploy.Snapshot,
 
 	var ops []resource.Operation
 	for _, op := range deployment.PendingOperations {
-		desop, err := DeserializeOperation(op)
+		desop, err := DeserializeOperation(op, dec)
 		if err != nil {
 			return nil, err
 		}
@@ -173,18 +204,48 @@ func DeserializeDeploymentV3(deployment apitype.DeploymentV3) (*deploy.Snapshot,
 }
 
 // SerializeResource turns a resource into a structure suitable for serialization.
-func SerializeResource(res *resource.State) apitype.ResourceV3 {
+func SerializeResource(res *resource.State, enc config.Encrypter) (apitype.ResourceV3, error) {
 	contract.Assert(res != nil)
 	contract.Assertf(string(res.URN) != "", "Unexpected empty resource resource.URN")
 
 	// Serialize all input and output properties recursively, and add them if non-empty.
 	var inputs map[string]interface{}
 	if inp := res.Inputs; inp != nil {
-		inputs = SerializeProperties(inp)
+		sinp, err := SerializeProperties(inp, enc)
+		if err != nil {
+			return apitype.ResourceV3{}, err
+		}
+		inputs = sinp
 	}
 	var outputs map[string]interface{}
 	if outp := res.Outputs; outp != nil {
-		outputs = SerializeProperties(outp)
+		soutp, err := SerializeProperties(outp, enc)
+		if err != nil {
+			return apitype.ResourceV3{}, err
+		}
+		outputs = soutp
+	}
+
+	// Only record custom timeouts if any have been set.
+	var customTimeouts *apitype.CustomTimeoutsV1
+	if res.CustomTimeouts != (resource.CustomTimeouts{}) {
+		customTimeouts = &apitype.CustomTimeoutsV1{
+			Create: res.CustomTimeouts.Create,
+			Update: res.CustomTimeouts.Update,
+			Delete: res.CustomTimeouts.Delete,
+		}
+	}
+
+	// Likewise, only record a retry policy if one has been set.
+	var retryPolicy *apitype.RetryPolicyV1
+	if p := res.RetryPolicy; p != (resource.RetryPolicy{}) {
+		retryPolicy = &apitype.RetryPolicyV1{
+			MaxAttempts: p.MaxAttempts,
+			Delay:       p.Delay,
+			MaxDelay:    p.MaxDelay,
+			Backoff:     p.Backoff,
+			Jitter:      p.Jitter,
+		}
 	}
 
 	return apitype.ResourceV3{
@@ -203,34 +264,45 @@ func SerializeResource(res *resource.State) apitype.ResourceV3 {
 		Provider:             res.Provider,
 		PropertyDependencies: res.PropertyDependencies,
 		PendingReplacement:   res.PendingReplacement,
-	}
+		CustomTimeouts:       customTimeouts,
+		RetainOnDelete:       res.RetainOnDelete,
+		RetryPolicy:          retryPolicy,
+		ReplaceOnChanges:     res.ReplaceOnChanges,
+	}, nil
 }
 
-func SerializeOperation(op resource.Operation) apitype.OperationV2 {
-	res := SerializeResource(op.Resource)
+func SerializeOperation(op resource.Operation, enc config.Encrypter) (apitype.OperationV2, error) {
+	res, err := SerializeResource(op.Resource, enc)
+	if err != nil {
+		return apitype.OperationV2{}, err
+	}
 	return apitype.OperationV2{
 		Resource: res,
 		Type:     apitype.OperationType(op.Type),
-	}
+	}, nil
 }
 
 // SerializeProperties serializes a resource property bag so that it's suitable for serialization.
-func SerializeProperties(props resource.PropertyMap) map[string]interface{} {
+func SerializeProperties(props resource.PropertyMap, enc config.Encrypter) (map[string]interface{}, error) {
 	dst := make(map[string]interface{})
 	for _, k := range props.StableKeys() {
-		if v := SerializePropertyValue(props[k]); v != nil {
+		v, err := SerializePropertyValue(props[k], enc)
+		if err != nil {
+			return nil, err
+		} else if v != nil {
 			dst[string(k)] = v
 		}
 	}
-	return dst
+	return dst, nil
 }
 
-// SerializePropertyValue serializes a resource property value so that it's suitable for serialization.
-func SerializePropertyValue(prop resource.PropertyValue) interface{} {
+// SerializePropertyValue serializes a resource property value so that it's suitable for serialization. Secret values
+// are encrypted using the given encrypter.
+func SerializePropertyValue(prop resource.PropertyValue, enc config.Encrypter) (interface{}, error) {
 	// Skip nulls and "outputs"; the former needn't be serialized, and the latter happens if there is an output
 	// that hasn't materialized (either because we're serializing inputs or the provider didn't give us the value).
 	if prop.IsComputed() || !prop.HasValue() {
-		return nil
+		return nil, nil
 	}
 
 	// For arrays, make sure to recurse.
@@ -238,47 +310,91 @@ func SerializePropertyValue(prop resource.PropertyValue) interface{} {
 		srcarr := prop.ArrayValue()
 		dstarr := make([]interface{}, len(srcarr))
 		for i, elem := range prop.ArrayValue() {
-			dstarr[i] = SerializePropertyValue(elem)
+			selem, err := SerializePropertyValue(elem, enc)
+			if err != nil {
+				return nil, err
+			}
+			dstarr[i] = selem
 		}
-		return dstarr
+		return dstarr, nil
 	}
 
 	// Also for objects, recurse and use naked properties.
 	if prop.IsObject() {
-		return SerializeProperties(prop.ObjectValue())
+		return SerializeProperties(prop.ObjectValue(), enc)
 	}
 
 	// For assets, we need to serialize them a little carefully, so we can recover them afterwards.
 	if prop.IsAsset() {
-		return prop.AssetValue().Serialize()
+		return prop.AssetValue().Serialize(), nil
 	} else if prop.IsArchive() {
-		return prop.ArchiveValue().Serialize()
+		return prop.ArchiveValue().Serialize(), nil
+	}
+
+	// Secrets are serialized as JSON and then encrypted, so that their plaintext never appears in the checkpoint.
+	if prop.IsSecret() {
+		elem, err := SerializePropertyValue(prop.SecretValue().Element, enc)
+		if err != nil {
+			return nil, err
+		}
+		bytes, err := json.Marshal(elem)
+		if err != nil {
+			return nil, err
+		}
+		ciphertext, err := enc.EncryptValue(string(bytes))
+		if err != nil {
+			return nil, errors.Wrap(err, "encrypting secret value")
+		}
+		return map[string]interface{}{
+			resource.SigKey: resource.SecretSig,
+			"ciphertext":    ciphertext,
+		}, nil
 	}
 
 	// All others are returned as-is.
-	return prop.V
+	return prop.V, nil
 }
 
 // DeserializeResource turns a serialized resource back into its usual form.
-func DeserializeResource(res apitype.ResourceV3) (*resource.State, error) {
+func DeserializeResource(res apitype.ResourceV3, dec config.Decrypter) (*resource.State, error) {
 	// Deserialize the resource properties, if they exist.
-	inputs, err := DeserializeProperties(res.Inputs)
+	inputs, err := DeserializeProperties(res.Inputs, dec)
 	if err != nil {
 		return nil, err
 	}
-	outputs, err := DeserializeProperties(res.Outputs)
+	outputs, err := DeserializeProperties(res.Outputs, dec)
 	if err != nil {
 		return nil, err
 	}
 
+	var customTimeouts resource.CustomTimeouts
+	if res.CustomTimeouts != nil {
+		customTimeouts = resource.CustomTimeouts{
+			Create: res.CustomTimeouts.Create,
+			Update: res.CustomTimeouts.Update,
+			Delete: res.CustomTimeouts.Delete,
+		}
+	}
+	var retryPolicy resource.RetryPolicy
+	if p := res.RetryPolicy; p != nil {
+		retryPolicy = resource.RetryPolicy{
+			MaxAttempts: p.MaxAttempts,
+			Delay:       p.Delay,
+			MaxDelay:    p.MaxDelay,
+			Backoff:     p.Backoff,
+			Jitter:      p.Jitter,
+		}
+	}
+
 	return resource.NewState(
 		res.Type, res.URN, res.Custom, res.Delete, res.ID,
 		inputs, outputs, res.Parent, res.Protect, res.External, res.Dependencies, res.InitErrors, res.Provider,
-		res.PropertyDependencies, res.PendingReplacement), nil
+		res.PropertyDependencies, res.PendingReplacement, customTimeouts, res.RetainOnDelete, retryPolicy,
+		res.ReplaceOnChanges), nil
 }
 
-func DeserializeOperation(op apitype.OperationV2) (resource.Operation, error) {
-	res, err := DeserializeResource(op.Resource)
+func DeserializeOperation(op apitype.OperationV2, dec config.Decrypter) (resource.Operation, error) {
+	res, err := DeserializeResource(op.Resource, dec)
 	if err != nil {
 		return resource.Operation{}, err
 	}
@@ -286,10 +402,10 @@ func DeserializeOperation(op apitype.OperationV2) (resource.Operation, error) {
 }
 
 // DeserializeProperties deserializes an entire map of deploy properties into a resource property map.
-func DeserializeProperties(props map[string]interface{}) (resource.PropertyMap, error) {
+func DeserializeProperties(props map[string]interface{}, dec config.Decrypter) (resource.PropertyMap, error) {
 	result := make(resource.PropertyMap)
 	for k, prop := range props {
-		desprop, err := DeserializePropertyValue(prop)
+		desprop, err := DeserializePropertyValue(prop, dec)
 		if err != nil {
 			return nil, err
 		}
@@ -298,8 +414,9 @@ func DeserializeProperties(props map[string]interface{}) (resource.PropertyMap,
 	return result, nil
 }
 
-// DeserializePropertyValue deserializes a single deploy property into a resource property value.
-func DeserializePropertyValue(v interface{}) (resource.PropertyValue, error) {
+// DeserializePropertyValue deserializes a single deploy property into a resource property value. Secret values are
+// decrypted using the given decrypter.
+func DeserializePropertyValue(v interface{}, dec config.Decrypter) (resource.PropertyValue, error) {
 	if v != nil {
 		switch w := v.(type) {
 		case bool:
@@ -311,7 +428,7 @@ func DeserializePropertyValue(v interface{}) (resource.PropertyValue, error) {
 		case []interface{}:
 			var arr []resource.PropertyValue
 			for _, elem := range w {
-				ev, err := DeserializePropertyValue(elem)
+				ev, err := DeserializePropertyValue(elem, dec)
 				if err != nil {
 					return resource.PropertyValue{}, err
 				}
@@ -319,7 +436,7 @@ func DeserializePropertyValue(v interface{}) (resource.PropertyValue, error) {
 			}
 			return resource.NewArrayProperty(arr), nil
 		case map[string]interface{}:
-			obj, err := DeserializeProperties(w)
+			obj, err := DeserializeProperties(w, dec)
 			if err != nil {
 				return resource.PropertyValue{}, err
 			}
@@ -343,8 +460,23 @@ func DeserializePropertyValue(v interface{}) (resource.PropertyValue, error) {
 					contract.Assert(isarchive)
 					return resource.NewArchiveProperty(archive), nil
 				case resource.SecretSig:
-					return resource.PropertyValue{},
-						errors.New("this version of the Pulumi SDK does not support first-class secrets")
+					ciphertext, ok := objmap["ciphertext"].(string)
+					if !ok {
+						return resource.PropertyValue{}, errors.New("malformed secret value: missing ciphertext")
+					}
+					plaintext, err := dec.DecryptValue(ciphertext)
+					if err != nil {
+						return resource.PropertyValue{}, errors.Wrap(err, "decrypting secret value")
+					}
+					var elem interface{}
+					if err = json.Unmarshal([]byte(plaintext), &elem); err != nil {
+						return resource.PropertyValue{}, errors.Wrap(err, "malformed secret value")
+					}
+					ev, err := DeserializePropertyValue(elem, dec)
+					if err != nil {
+						return resource.PropertyValue{}, err
+					}
+					return resource.MakeSecret(ev), nil
 				default:
 					return resource.PropertyValue{}, errors.Errorf("unrecognized signature '%v' in property map", sig)
 				}
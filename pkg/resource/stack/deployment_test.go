@@ -15,12 +15,14 @@
 package stack
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/tokens"
 )
 
@@ -78,9 +80,14 @@ func TestDeploymentSerialization(t *testing.T) {
 		"",
 		nil,
 		false,
+		resource.CustomTimeouts{},
+		true,
+		resource.RetryPolicy{MaxAttempts: 3},
+		[]string{"in-map.a"},
 	)
 
-	dep := SerializeResource(res)
+	dep, err := SerializeResource(res, config.NewPanicCrypter())
+	assert.NoError(t, err)
 
 	// assert some things about the deployment record:
 	assert.NotNil(t, dep)
@@ -90,6 +97,9 @@ func TestDeploymentSerialization(t *testing.T) {
 	assert.Equal(t, 2, len(dep.Dependencies))
 	assert.Equal(t, resource.URN("foo:bar:baz"), dep.Dependencies[0])
 	assert.Equal(t, resource.URN("foo:bar:boo"), dep.Dependencies[1])
+	assert.True(t, dep.RetainOnDelete)
+	assert.Equal(t, &apitype.RetryPolicyV1{MaxAttempts: 3}, dep.RetryPolicy)
+	assert.Equal(t, []string{"in-map.a"}, dep.ReplaceOnChanges)
 
 	// assert some things about the inputs:
 	assert.NotNil(t, dep.Inputs)
@@ -154,7 +164,7 @@ func TestLoadTooNewDeployment(t *testing.T) {
 		Version: apitype.DeploymentSchemaVersionCurrent + 1,
 	}
 
-	deployment, err := DeserializeUntypedDeployment(untypedDeployment)
+	deployment, err := DeserializeUntypedDeployment(untypedDeployment, config.NewPanicCrypter())
 	assert.Nil(t, deployment)
 	assert.Error(t, err)
 	assert.Equal(t, ErrDeploymentSchemaVersionTooNew, err)
@@ -165,17 +175,40 @@ func TestLoadTooOldDeployment(t *testing.T) {
 		Version: DeploymentSchemaVersionOldestSupported - 1,
 	}
 
-	deployment, err := DeserializeUntypedDeployment(untypedDeployment)
+	deployment, err := DeserializeUntypedDeployment(untypedDeployment, config.NewPanicCrypter())
 	assert.Nil(t, deployment)
 	assert.Error(t, err)
 	assert.Equal(t, ErrDeploymentSchemaVersionTooOld, err)
 }
 
-func TestUnsupportedSecret(t *testing.T) {
+func TestSecretRoundTrip(t *testing.T) {
+	crypter := config.NewSymmetricCrypter(make([]byte, config.SymmetricCrypterKeyBytes))
+
+	secret := resource.MakeSecret(resource.NewObjectProperty(resource.NewPropertyMapFromMap(map[string]interface{}{
+		"password": "hunter2",
+		"ports":    []interface{}{80, 443},
+	})))
+	serialized, err := SerializePropertyValue(secret, crypter)
+	assert.NoError(t, err)
+
+	// The plaintext must not appear in the serialized value.
+	bytes, err := json.Marshal(serialized)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(bytes), "hunter2")
+
+	var raw interface{}
+	assert.NoError(t, json.Unmarshal(bytes, &raw))
+	deserialized, err := DeserializePropertyValue(raw, crypter)
+	assert.NoError(t, err)
+	assert.True(t, deserialized.IsSecret())
+	assert.True(t, secret.DeepEquals(deserialized))
+}
+
+func TestMalformedSecret(t *testing.T) {
 	rawProp := map[string]interface{}{
 		resource.SigKey: resource.SecretSig,
 	}
-	_, err := DeserializePropertyValue(rawProp)
+	_, err := DeserializePropertyValue(rawProp, config.NewPanicCrypter())
 	assert.Error(t, err)
 }
 
@@ -183,6 +216,6 @@ func TestUnknownSig(t *testing.T) {
 	rawProp := map[string]interface{}{
 		resource.SigKey: "foobar",
 	}
-	_, err := DeserializePropertyValue(rawProp)
+	_, err := DeserializePropertyValue(rawProp, config.NewPanicCrypter())
 	assert.Error(t, err)
 }
@@ -0,0 +1,111 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// JournalEntry is a single change to the list of resources in a snapshot.
+type JournalEntry struct {
+	Kind  apitype.JournalEntryKind // the kind of change.
+	Index int                      // the index in the list of resources at which the change is made.
+	State *resource.State          // the created or updated resource; nil for deletions.
+}
+
+// SerializeJournalRecord turns the given changes into a journal record that applies to the deployment whose manifest
+// has the given time. The record carries the manifest and pending operations of the given snapshot, which is the
+// result of applying the changes. Any secret values in the changed resources are encrypted using the given encrypter.
+func SerializeJournalRecord(base time.Time, snap *deploy.Snapshot, entries []JournalEntry,
+	enc config.Encrypter) (*apitype.JournalRecordV1, error) {
+
+	contract.Require(snap != nil, "snap")
+
+	record := &apitype.JournalRecordV1{
+		Base:     base,
+		Manifest: serializeManifest(snap.Manifest),
+	}
+	for _, entry := range entries {
+		sentry := apitype.JournalEntryV1{Kind: entry.Kind, Index: entry.Index}
+		if entry.Kind != apitype.JournalEntryDelete {
+			sres, err := SerializeResource(entry.State, enc)
+			if err != nil {
+				return nil, err
+			}
+			sentry.Resource = &sres
+		}
+		record.Entries = append(record.Entries, sentry)
+	}
+	for _, op := range snap.PendingOperations {
+		sop, err := SerializeOperation(op, enc)
+		if err != nil {
+			return nil, err
+		}
+		record.PendingOperations = append(record.PendingOperations, sop)
+	}
+	return record, nil
+}
+
+// ApplyJournal applies the given journal records, in order, to the given deployment. Records that do not apply to the
+// deployment -- for instance, those left behind when a checkpoint was rewritten but its journal could not be removed
+// -- are ignored.
+func ApplyJournal(deployment *apitype.DeploymentV3, records []apitype.JournalRecordV1) error {
+	contract.Require(deployment != nil, "deployment")
+
+	base := deployment.Manifest.Time
+	for i, record := range records {
+		if !record.Base.Equal(base) {
+			continue
+		}
+
+		for _, entry := range record.Entries {
+			resources := deployment.Resources
+			switch entry.Kind {
+			case apitype.JournalEntryCreate:
+				if entry.Index < 0 || entry.Index > len(resources) || entry.Resource == nil {
+					return errors.Errorf("journal record %d: invalid create at index %d", i, entry.Index)
+				}
+				resources = append(resources, apitype.ResourceV3{})
+				copy(resources[entry.Index+1:], resources[entry.Index:])
+				resources[entry.Index] = *entry.Resource
+			case apitype.JournalEntryUpdate:
+				if entry.Index < 0 || entry.Index >= len(resources) || entry.Resource == nil {
+					return errors.Errorf("journal record %d: invalid update at index %d", i, entry.Index)
+				}
+				resources[entry.Index] = *entry.Resource
+			case apitype.JournalEntryDelete:
+				if entry.Index < 0 || entry.Index >= len(resources) {
+					return errors.Errorf("journal record %d: invalid delete at index %d", i, entry.Index)
+				}
+				resources = append(resources[:entry.Index], resources[entry.Index+1:]...)
+			default:
+				return errors.Errorf("journal record %d: unknown entry kind '%s'", i, entry.Kind)
+			}
+			deployment.Resources = resources
+		}
+
+		deployment.Manifest = record.Manifest
+		deployment.PendingOperations = record.PendingOperations
+	}
+	return nil
+}
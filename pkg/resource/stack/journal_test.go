@@ -0,0 +1,96 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+func journalResource(urn string) *apitype.ResourceV3 {
+	return &apitype.ResourceV3{URN: resource.URN(urn), Type: "test"}
+}
+
+func TestApplyJournal(t *testing.T) {
+	base := time.Now()
+	deployment := &apitype.DeploymentV3{
+		Manifest: apitype.ManifestV1{Time: base},
+		Resources: []apitype.ResourceV3{
+			*journalResource("a"),
+			*journalResource("b"),
+		},
+	}
+
+	updated := journalResource("b")
+	updated.ID = "b-id"
+	pending := apitype.OperationV2{Resource: *journalResource("c"), Type: apitype.OperationTypeCreating}
+	records := []apitype.JournalRecordV1{
+		{
+			Base:     base,
+			Manifest: apitype.ManifestV1{Time: base.Add(time.Second)},
+			Entries: []apitype.JournalEntryV1{
+				{Kind: apitype.JournalEntryCreate, Index: 0, Resource: journalResource("c")},
+				{Kind: apitype.JournalEntryDelete, Index: 1},
+			},
+			PendingOperations: []apitype.OperationV2{pending},
+		},
+		{
+			// This record applies to some other checkpoint, and must be ignored.
+			Base:     base.Add(-time.Second),
+			Manifest: apitype.ManifestV1{Time: base.Add(-time.Second)},
+			Entries: []apitype.JournalEntryV1{
+				{Kind: apitype.JournalEntryDelete, Index: 0},
+			},
+		},
+		{
+			Base:     base,
+			Manifest: apitype.ManifestV1{Time: base.Add(2 * time.Second)},
+			Entries: []apitype.JournalEntryV1{
+				{Kind: apitype.JournalEntryUpdate, Index: 1, Resource: updated},
+				{Kind: apitype.JournalEntryCreate, Index: 2, Resource: journalResource("d")},
+			},
+		},
+	}
+
+	err := ApplyJournal(deployment, records)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, []apitype.ResourceV3{*journalResource("c"), *updated, *journalResource("d")}, deployment.Resources)
+	assert.Equal(t, base.Add(2*time.Second), deployment.Manifest.Time)
+	assert.Empty(t, deployment.PendingOperations)
+}
+
+func TestApplyJournalInvalidIndex(t *testing.T) {
+	base := time.Now()
+	for _, entry := range []apitype.JournalEntryV1{
+		{Kind: apitype.JournalEntryCreate, Index: 2, Resource: journalResource("b")},
+		{Kind: apitype.JournalEntryUpdate, Index: 1, Resource: journalResource("b")},
+		{Kind: apitype.JournalEntryDelete, Index: -1},
+		{Kind: "move", Index: 0},
+	} {
+		deployment := &apitype.DeploymentV3{
+			Manifest:  apitype.ManifestV1{Time: base},
+			Resources: []apitype.ResourceV3{*journalResource("a")},
+		}
+		err := ApplyJournal(deployment, []apitype.JournalRecordV1{{Base: base, Entries: []apitype.JournalEntryV1{entry}}})
+		assert.Error(t, err, "entry %v", entry)
+	}
+}
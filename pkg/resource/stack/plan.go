@@ -0,0 +1,167 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// UpdatePlanSchemaVersionCurrent is the current version of the update plan format.
+const UpdatePlanSchemaVersionCurrent = 1
+
+// SerializePlan serializes an update plan. Any secret values in the plan are encrypted using the given encrypter.
+// Unlike checkpoints, plans preserve unknown property values, which are serialized using a sentinel string.
+func SerializePlan(plan *deploy.UpdatePlan, enc config.Encrypter) (apitype.UpdatePlanV1, error) {
+	contract.Require(plan != nil, "plan")
+
+	resources := make(map[resource.URN]apitype.ResourcePlanV1)
+	for urn, rp := range plan.ResourcePlans {
+		ops := make([]string, len(rp.Ops))
+		for i, op := range rp.Ops {
+			ops[i] = string(op)
+		}
+
+		inputs, err := serializePlanProperties(rp.Inputs, enc)
+		if err != nil {
+			return apitype.UpdatePlanV1{}, errors.Wrapf(err, "serializing inputs for resource '%v'", urn)
+		}
+		outputs, err := serializePlanProperties(rp.Outputs, enc)
+		if err != nil {
+			return apitype.UpdatePlanV1{}, errors.Wrapf(err, "serializing outputs for resource '%v'", urn)
+		}
+
+		resources[urn] = apitype.ResourcePlanV1{
+			Ops:     ops,
+			Inputs:  inputs,
+			Outputs: outputs,
+		}
+	}
+
+	return apitype.UpdatePlanV1{
+		Version:   UpdatePlanSchemaVersionCurrent,
+		Resources: resources,
+	}, nil
+}
+
+// DeserializePlan deserializes an update plan. Any secret values in the plan are decrypted using the given decrypter.
+func DeserializePlan(plan apitype.UpdatePlanV1, dec config.Decrypter) (*deploy.UpdatePlan, error) {
+	if plan.Version != UpdatePlanSchemaVersionCurrent {
+		return nil, errors.Errorf("unsupported update plan version %d", plan.Version)
+	}
+
+	result := deploy.NewUpdatePlan()
+	for urn, rp := range plan.Resources {
+		ops := make([]deploy.StepOp, len(rp.Ops))
+		for i, op := range rp.Ops {
+			ops[i] = deploy.StepOp(op)
+		}
+
+		inputs, err := deserializePlanProperties(rp.Inputs, dec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "deserializing inputs for resource '%v'", urn)
+		}
+		outputs, err := deserializePlanProperties(rp.Outputs, dec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "deserializing outputs for resource '%v'", urn)
+		}
+
+		result.ResourcePlans[urn] = &deploy.ResourcePlan{
+			Ops:     ops,
+			Inputs:  inputs,
+			Outputs: outputs,
+		}
+	}
+	return result, nil
+}
+
+func serializePlanProperties(props resource.PropertyMap, enc config.Encrypter) (map[string]interface{}, error) {
+	if props == nil {
+		return nil, nil
+	}
+
+	dst := make(map[string]interface{})
+	for _, k := range props.StableKeys() {
+		v, err := serializePlanPropertyValue(props[k], enc)
+		if err != nil {
+			return nil, err
+		} else if v != nil {
+			dst[string(k)] = v
+		}
+	}
+	return dst, nil
+}
+
+func serializePlanPropertyValue(prop resource.PropertyValue, enc config.Encrypter) (interface{}, error) {
+	switch {
+	case prop.IsComputed() || prop.IsOutput():
+		return plugin.UnknownStringValue, nil
+	case prop.IsArray():
+		srcarr := prop.ArrayValue()
+		dstarr := make([]interface{}, len(srcarr))
+		for i, elem := range srcarr {
+			selem, err := serializePlanPropertyValue(elem, enc)
+			if err != nil {
+				return nil, err
+			}
+			dstarr[i] = selem
+		}
+		return dstarr, nil
+	case prop.IsObject():
+		return serializePlanProperties(prop.ObjectValue(), enc)
+	default:
+		return SerializePropertyValue(prop, enc)
+	}
+}
+
+func deserializePlanProperties(props map[string]interface{}, dec config.Decrypter) (resource.PropertyMap, error) {
+	if props == nil {
+		return nil, nil
+	}
+
+	result, err := DeserializeProperties(props, dec)
+	if err != nil {
+		return nil, err
+	}
+	return restorePlanUnknowns(resource.NewObjectProperty(result)).ObjectValue(), nil
+}
+
+// restorePlanUnknowns replaces each unknown sentinel string in the given value with an unknown value.
+func restorePlanUnknowns(v resource.PropertyValue) resource.PropertyValue {
+	switch {
+	case v.IsString() && v.StringValue() == plugin.UnknownStringValue:
+		return resource.MakeComputed(resource.NewStringProperty(""))
+	case v.IsArray():
+		arr := make([]resource.PropertyValue, len(v.ArrayValue()))
+		for i, elem := range v.ArrayValue() {
+			arr[i] = restorePlanUnknowns(elem)
+		}
+		return resource.NewArrayProperty(arr)
+	case v.IsObject():
+		obj := make(resource.PropertyMap)
+		for k, elem := range v.ObjectValue() {
+			obj[k] = restorePlanUnknowns(elem)
+		}
+		return resource.NewObjectProperty(obj)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+func TestPlanRoundTrip(t *testing.T) {
+	urn := resource.URN("urn:pulumi:stack::proj::pkgA:m:typA::resA")
+
+	plan := deploy.NewUpdatePlan()
+	plan.ResourcePlans[urn] = &deploy.ResourcePlan{
+		Ops: []deploy.StepOp{deploy.OpCreateReplacement, deploy.OpReplace, deploy.OpDeleteReplaced},
+		Inputs: resource.PropertyMap{
+			"known":   resource.NewStringProperty("foo"),
+			"unknown": resource.MakeComputed(resource.NewStringProperty("")),
+			"nested": resource.NewObjectProperty(resource.PropertyMap{
+				"arr": resource.NewArrayProperty([]resource.PropertyValue{
+					resource.NewNumberProperty(42),
+					resource.MakeComputed(resource.NewStringProperty("")),
+				}),
+			}),
+		},
+		Outputs: resource.PropertyMap{
+			"id": resource.NewStringProperty("bar"),
+		},
+	}
+
+	serialized, err := SerializePlan(plan, config.NewPanicCrypter())
+	assert.NoError(t, err)
+	assert.Equal(t, UpdatePlanSchemaVersionCurrent, serialized.Version)
+	assert.Equal(t, []string{"create-replacement", "replace", "delete-replaced"}, serialized.Resources[urn].Ops)
+
+	deserialized, err := DeserializePlan(serialized, config.NewPanicCrypter())
+	assert.NoError(t, err)
+	assert.Len(t, deserialized.ResourcePlans, 1)
+
+	rp := deserialized.ResourcePlans[urn]
+	assert.Equal(t, plan.ResourcePlans[urn].Ops, rp.Ops)
+	assert.True(t, rp.Inputs.DeepEquals(plan.ResourcePlans[urn].Inputs))
+	assert.True(t, rp.Outputs.DeepEquals(plan.ResourcePlans[urn].Outputs))
+	assert.True(t, rp.Inputs["unknown"].IsComputed())
+	assert.True(t, rp.Inputs["nested"].ObjectValue()["arr"].ArrayValue()[1].IsComputed())
+}
+
+func TestLoadUnsupportedPlan(t *testing.T) {
+	_, err := DeserializePlan(apitype.UpdatePlanV1{Version: UpdatePlanSchemaVersionCurrent + 1},
+		config.NewPanicCrypter())
+	assert.Error(t, err)
+}
@@ -0,0 +1,141 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// RenameDeployment rewrites the given deployment in place so that its resources belong to the stack with the given
+// name. Each URN in the deployment -- those of its resources and pending operations, and their parents, dependencies,
+// property dependencies and providers -- is rewritten to refer to the new stack. Resource properties are left as-is,
+// so secret values do not need to be decrypted.
+func RenameDeployment(deployment *apitype.DeploymentV3, newName tokens.QName) error {
+	contract.Require(deployment != nil, "deployment")
+	contract.Require(newName != "", "newName")
+
+	for i := range deployment.Resources {
+		if err := renameResource(&deployment.Resources[i], newName); err != nil {
+			return err
+		}
+	}
+	for i := range deployment.PendingOperations {
+		if err := renameResource(&deployment.PendingOperations[i].Resource, newName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyDeploymentIntegrity checks the structure of the given deployment's resources as Snapshot.VerifyIntegrity does:
+// that parents, dependencies and providers come before the resources that refer to them, and that there are no
+// duplicate URNs. Only the resources' URNs and references are checked, so secret values do not need to be decrypted.
+func VerifyDeploymentIntegrity(deployment *apitype.DeploymentV3) error {
+	contract.Require(deployment != nil, "deployment")
+
+	manifest := deploy.Manifest{
+		Time:    deployment.Manifest.Time,
+		Magic:   deployment.Manifest.Magic,
+		Version: deployment.Manifest.Version,
+	}
+	var resources []*resource.State
+	for _, res := range deployment.Resources {
+		resources = append(resources, &resource.State{
+			URN:          res.URN,
+			Type:         res.Type,
+			Custom:       res.Custom,
+			Delete:       res.Delete,
+			ID:           res.ID,
+			Parent:       res.Parent,
+			Dependencies: res.Dependencies,
+			Provider:     res.Provider,
+		})
+	}
+	return deploy.NewSnapshot(manifest, resources, nil).VerifyIntegrity()
+}
+
+// renameResource rewrites each URN in the given resource to refer to the stack with the given name.
+func renameResource(res *apitype.ResourceV3, newName tokens.QName) error {
+	var err error
+	if res.URN, err = renameURN(res.URN, newName); err != nil {
+		return err
+	}
+	if res.Parent, err = renameURN(res.Parent, newName); err != nil {
+		return err
+	}
+	if res.Dependencies, err = renameURNs(res.Dependencies, newName); err != nil {
+		return err
+	}
+	for k, deps := range res.PropertyDependencies {
+		if res.PropertyDependencies[k], err = renameURNs(deps, newName); err != nil {
+			return err
+		}
+	}
+	if res.Provider != "" {
+		ref, err := providers.ParseReference(res.Provider)
+		if err != nil {
+			return errors.Wrapf(err, "parsing provider reference of resource '%s'", res.URN)
+		}
+		urn, err := renameURN(ref.URN(), newName)
+		if err != nil {
+			return err
+		}
+		newRef, err := providers.NewReference(urn, ref.ID())
+		if err != nil {
+			return err
+		}
+		res.Provider = newRef.String()
+	}
+	return nil
+}
+
+// renameURNs rewrites each of the given URNs to refer to the stack with the given name.
+func renameURNs(urns []resource.URN, newName tokens.QName) ([]resource.URN, error) {
+	var result []resource.URN
+	for _, urn := range urns {
+		renamed, err := renameURN(urn, newName)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, renamed)
+	}
+	return result, nil
+}
+
+// renameURN rewrites the given URN to refer to the stack with the given name. An empty URN is returned as-is.
+func renameURN(urn resource.URN, newName tokens.QName) (resource.URN, error) {
+	if urn == "" {
+		return urn, nil
+	}
+
+	s := string(urn)
+	if !strings.HasPrefix(s, resource.URNPrefix) {
+		return "", errors.Errorf("invalid URN '%s'", urn)
+	}
+	delim := strings.Index(s[len(resource.URNPrefix):], resource.URNNameDelimiter)
+	if delim < 0 {
+		return "", errors.Errorf("invalid URN '%s'", urn)
+	}
+	return resource.URN(resource.URNPrefix + string(newName) + s[len(resource.URNPrefix)+delim:]), nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+)
+
+func TestRenameDeployment(t *testing.T) {
+	urn := func(stack, name string) resource.URN {
+		return resource.URN("urn:pulumi:" + stack + "::proj::pkg:mod:typ::" + name)
+	}
+	provider := func(stack string) string {
+		ref, err := providers.NewReference(
+			resource.URN("urn:pulumi:"+stack+"::proj::pulumi:providers:pkg::default"), "id")
+		assert.NoError(t, err)
+		return ref.String()
+	}
+
+	deployment := &apitype.DeploymentV3{
+		Resources: []apitype.ResourceV3{
+			{URN: urn("dev", "a")},
+			{
+				URN:                  urn("dev", "b"),
+				Parent:               urn("dev", "a"),
+				Dependencies:         []resource.URN{urn("dev", "a")},
+				PropertyDependencies: map[resource.PropertyKey][]resource.URN{"p": {urn("dev", "a")}},
+				Provider:             provider("dev"),
+			},
+		},
+		PendingOperations: []apitype.OperationV2{
+			{Resource: apitype.ResourceV3{URN: urn("dev", "c"), Parent: urn("dev", "a")}},
+		},
+	}
+	assert.NoError(t, RenameDeployment(deployment, "prod"))
+
+	assert.Equal(t, urn("prod", "a"), deployment.Resources[0].URN)
+	assert.Equal(t, resource.URN(""), deployment.Resources[0].Parent)
+	assert.Nil(t, deployment.Resources[0].Dependencies)
+	assert.Equal(t, "", deployment.Resources[0].Provider)
+
+	b := deployment.Resources[1]
+	assert.Equal(t, urn("prod", "b"), b.URN)
+	assert.Equal(t, urn("prod", "a"), b.Parent)
+	assert.Equal(t, []resource.URN{urn("prod", "a")}, b.Dependencies)
+	assert.Equal(t, map[resource.PropertyKey][]resource.URN{"p": {urn("prod", "a")}}, b.PropertyDependencies)
+	assert.Equal(t, provider("prod"), b.Provider)
+
+	assert.Equal(t, urn("prod", "c"), deployment.PendingOperations[0].Resource.URN)
+	assert.Equal(t, urn("prod", "a"), deployment.PendingOperations[0].Resource.Parent)
+
+	// Invalid URNs are reported rather than rewritten.
+	invalid := &apitype.DeploymentV3{Resources: []apitype.ResourceV3{{URN: "not-a-urn"}}}
+	assert.Error(t, RenameDeployment(invalid, "prod"))
+}
+
+func TestVerifyDeploymentIntegrity(t *testing.T) {
+	urn := func(name string) resource.URN {
+		return resource.URN("urn:pulumi:dev::proj::pkg:mod:typ::" + name)
+	}
+	manifest := deploy.Manifest{Version: "1.0.0"}
+	manifest.Magic = manifest.NewMagic()
+	deployment := func(resources ...apitype.ResourceV3) *apitype.DeploymentV3 {
+		return &apitype.DeploymentV3{
+			Manifest: apitype.ManifestV1{
+				Time:    manifest.Time,
+				Magic:   manifest.Magic,
+				Version: manifest.Version,
+			},
+			Resources: resources,
+		}
+	}
+
+	// Secret values are not decrypted, so a deployment whose secrets cannot be decrypted can still be checked.
+	secret := map[string]interface{}{
+		resource.SigKey: resource.SecretSig,
+		"ciphertext":    "not-decryptable",
+	}
+	withSecrets := deployment(
+		apitype.ResourceV3{URN: urn("a"), Type: "pkg:mod:typ", Outputs: map[string]interface{}{"password": secret}},
+		apitype.ResourceV3{
+			URN:          urn("b"),
+			Type:         "pkg:mod:typ",
+			Parent:       urn("a"),
+			Dependencies: []resource.URN{urn("a")},
+			Inputs:       map[string]interface{}{"password": secret},
+		},
+	)
+	assert.NoError(t, VerifyDeploymentIntegrity(withSecrets))
+	assert.NoError(t, RenameDeployment(withSecrets, "prod"))
+	assert.NoError(t, VerifyDeploymentIntegrity(withSecrets))
+
+	// Resources that refer to missing or later resources, and duplicate resources, are reported.
+	assert.Error(t, VerifyDeploymentIntegrity(deployment(apitype.ResourceV3{URN: urn("b"), Parent: urn("a")})))
+	assert.Error(t, VerifyDeploymentIntegrity(deployment(
+		apitype.ResourceV3{URN: urn("b"), Dependencies: []resource.URN{urn("a")}},
+		apitype.ResourceV3{URN: urn("a")},
+	)))
+	assert.Error(t, VerifyDeploymentIntegrity(deployment(
+		apitype.ResourceV3{URN: urn("a")},
+		apitype.ResourceV3{URN: urn("a")},
+	)))
+}
@@ -387,7 +387,7 @@ func GetLogs(
 
 	var states []*resource.State
 	for _, res := range stackInfo.Deployment.Resources {
-		state, err := stack.DeserializeResource(res)
+		state, err := stack.DeserializeResource(res, config.NewPanicCrypter())
 		if !assert.NoError(t, err) {
 			return nil
 		}
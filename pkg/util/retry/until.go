@@ -16,6 +16,7 @@ package retry
 
 import (
 	"context"
+	"math/rand"
 	"time"
 )
 
@@ -24,6 +25,7 @@ type Acceptor struct {
 	Delay    *time.Duration // an optional delay duration.
 	Backoff  *float64       // an optional backoff multiplier.
 	MaxDelay *time.Duration // an optional maximum delay duration.
+	Jitter   *float64       // an optional fraction by which each delay is randomly lengthened or shortened.
 }
 
 // Acceptance is meant to accept a condition.  It returns true when this condition has succeeded, and false otherwise
@@ -60,6 +62,10 @@ func Until(ctx context.Context, acceptor Acceptor) (bool, interface{}, error) {
 	} else {
 		maxDelay = *acceptor.MaxDelay
 	}
+	var jitter float64
+	if acceptor.Jitter != nil {
+		jitter = *acceptor.Jitter
+	}
 
 	// Loop until the condition is accepted or the context expires, whichever comes first.
 	try := 0
@@ -70,15 +76,21 @@ func Until(ctx context.Context, acceptor Acceptor) (bool, interface{}, error) {
 			delay = maxDelay
 		}
 
+		// Apply any jitter to this particular wait, leaving the backoff sequence itself unchanged.
+		wait := delay
+		if jitter != 0 {
+			wait = time.Duration(float64(delay) * (1 + jitter*(2*rand.Float64()-1)))
+		}
+
 		// Try the acceptance condition; if it returns true, or an error, we are done.
-		b, data, err := acceptor.Accept(try, delay)
+		b, data, err := acceptor.Accept(try, wait)
 		if b || err != nil {
 			return b, data, err
 		}
 
 		// Wait for delay or timeout.
 		select {
-		case <-time.After(delay):
+		case <-time.After(wait):
 			// Continue on.
 		case <-ctx.Done():
 			return false, nil, nil
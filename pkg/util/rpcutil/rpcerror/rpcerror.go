@@ -83,6 +83,24 @@ func (r *Error) Details() []interface{} {
 	return r.details
 }
 
+// Retryable returns true if the operation that produced this error may succeed if it is retried. An error is
+// retryable if the server attached an ErrorRetryable to it (see `WithRetryable`); otherwise, errors whose codes
+// indicate a transient condition (i.e. `Unavailable` and `ResourceExhausted`) are retryable.
+func (r *Error) Retryable() bool {
+	for _, details := range r.details {
+		if retryable, ok := details.(*pulumirpc.ErrorRetryable); ok {
+			return retryable.Retryable
+		}
+	}
+
+	switch r.code {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
 // ErrorCause represents a root cause of an error that ultimately caused
 // an RPC endpoint to issue an error. ErrorCauses are optionally attached
 // to Errors.
@@ -157,6 +175,19 @@ func WithDetails(err error, details ...proto.Message) error {
 	return status.Err()
 }
 
+// WithRetryable marks an error created by this package as retryable or not retryable, overriding the default that
+// is implied by the error's code. The mark is accessible by calling `Retryable` on `Error` instances created by
+// `FromError`.
+func WithRetryable(err error, retryable bool) error {
+	return WithDetails(err, &pulumirpc.ErrorRetryable{Retryable: retryable})
+}
+
+// IsRetryable returns true if the given error, or the error that caused it, is an `Error` that is retryable.
+func IsRetryable(err error) bool {
+	rpcError, ok := FromError(errors.Cause(err))
+	return ok && rpcError != nil && rpcError.Retryable()
+}
+
 // FromError "unwraps" an error created by functions in the `rpcerror` package and produces
 // an `Error` structure from them.
 //
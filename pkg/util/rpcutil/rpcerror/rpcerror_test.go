@@ -137,3 +137,19 @@ func TestErrorString(t *testing.T) {
 
 	assert.Equal(t, "thing failed 2", unwrapped.Error())
 }
+
+func TestRetryable(t *testing.T) {
+	// Errors with transient codes are retryable by default.
+	assert.True(t, IsRetryable(New(codes.Unavailable, "try again later")))
+	assert.True(t, IsRetryable(New(codes.ResourceExhausted, "slow down")))
+	assert.False(t, IsRetryable(New(codes.InvalidArgument, "bad input")))
+	assert.False(t, IsRetryable(errors.New("not an rpc error")))
+
+	// An explicit mark overrides the default in either direction.
+	assert.True(t, IsRetryable(WithRetryable(New(codes.Unknown, "throttled"), true)))
+	assert.False(t, IsRetryable(WithRetryable(New(codes.Unavailable, "gone for good"), false)))
+
+	// Errors that have been converted or wrapped are also inspected.
+	assert.True(t, Convert(New(codes.Unavailable, "try again later")).Retryable())
+	assert.True(t, IsRetryable(errors.Wrap(Convert(New(codes.Unavailable, "try again later")), "creating")))
+}
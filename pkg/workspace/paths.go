@@ -39,6 +39,10 @@ const (
 	GitDir = ".git"
 	// HistoryDir is the name of the directory that holds historical information for projects.
 	HistoryDir = "history"
+	// JournalDir is the name of the directory that holds the checkpoint journals of stacks that are being updated.
+	JournalDir = "journals"
+	// LockDir is the name of the directory that holds the locks of stacks that are being updated.
+	LockDir = "locks"
 	// PluginDir is the name of the directory containing plugins.
 	PluginDir = "plugins"
 	// StackDir is the name of the directory that holds stack information for projects.
@@ -52,6 +52,21 @@ type ProjectTemplateConfigValue struct {
 	Secret bool `json:"secret,omitempty" yaml:"secret,omitempty"`
 }
 
+// ProjectRetryPolicy configures how resource operations that fail with transient errors are retried. Each resource
+// may override any of these settings.
+type ProjectRetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts for each create, update, or delete operation.
+	MaxAttempts int `json:"maxAttempts,omitempty" yaml:"maxAttempts,omitempty"`
+	// Delay is an optional delay before the first retry, e.g. "1s".
+	Delay string `json:"delay,omitempty" yaml:"delay,omitempty"`
+	// MaxDelay is an optional maximum delay between retries, e.g. "30s".
+	MaxDelay string `json:"maxDelay,omitempty" yaml:"maxDelay,omitempty"`
+	// Backoff is an optional multiplier that is applied to the delay after each retry.
+	Backoff float64 `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+	// Jitter is an optional fraction, between 0 and 1, by which each delay is randomly varied.
+	Jitter float64 `json:"jitter,omitempty" yaml:"jitter,omitempty"`
+}
+
 // Project is a Pulumi project manifest.
 //
 // We explicitly add yaml tags (instead of using the default behavior from https://github.com/ghodss/yaml which works
@@ -76,7 +91,8 @@ type Project struct {
 	// License is the optional license governing this project's usage.
 	License *string `json:"license,omitempty" yaml:"license,omitempty"`
 
-	// Analyzers is an optional list of analyzers that are enabled for this project.
+	// Analyzers is an optional list of analyzers that are enabled for this project. Entries ending in `.yaml` or `.yml`
+	// are paths, relative to the project's root directory, of YAML rules files that are checked by a built-in analyzer.
 	Analyzers *Analyzers `json:"analyzers,omitempty" yaml:"analyzers,omitempty"`
 
 	// Config indicates where to store the Pulumi.<stack-name>.yaml files, combined with the folder Pulumi.yaml is in.
@@ -84,6 +100,13 @@ type Project struct {
 
 	// Template is an optional template manifest, if this project is a template.
 	Template *ProjectTemplate `json:"template,omitempty" yaml:"template,omitempty"`
+
+	// Retry is an optional policy for retrying resource operations that fail with transient errors.
+	Retry *ProjectRetryPolicy `json:"retry,omitempty" yaml:"retry,omitempty"`
+
+	// Parallel optionally limits, by package name, the number of operations that each provider may have in flight at
+	// once, e.g. `aws: 5`. The stack configuration value `<package>:parallel` overrides the limit for a package.
+	Parallel map[string]int `json:"parallel,omitempty" yaml:"parallel,omitempty"`
 }
 
 func (proj *Project) Validate() error {
@@ -93,6 +116,11 @@ func (proj *Project) Validate() error {
 	if proj.Runtime.Name() == "" {
 		return errors.New("project is missing a 'runtime' attribute")
 	}
+	for pkg, limit := range proj.Parallel {
+		if limit <= 0 {
+			return errors.Errorf("project's 'parallel' limit for '%s' must be positive", pkg)
+		}
+	}
 
 	return nil
 }
@@ -129,6 +157,9 @@ type ProjectStack struct {
 	EncryptionSalt string `json:"encryptionsalt,omitempty" yaml:"encryptionsalt,omitempty"`
 	// Config is an optional config bag.
 	Config config.Map `json:"config,omitempty" yaml:"config,omitempty"`
+	// PolicyPacks is an optional list of policy pack directories to run during previews and updates of this stack.
+	// Relative paths are relative to the project's root directory.
+	PolicyPacks []string `json:"policyPacks,omitempty" yaml:"policyPacks,omitempty"`
 }
 
 // Save writes a project definition to a file.
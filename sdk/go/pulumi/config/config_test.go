@@ -46,12 +46,14 @@ func TestConfig(t *testing.T) {
 	assert.Equal(t, 42, cfg.GetInt("intint"))
 	assert.Equal(t, 99.963, cfg.GetFloat64("fpfpfp"))
 	assert.Equal(t, "", cfg.Get("missing"))
+	assert.Equal(t, pulumi.NewSecret("a string value"), cfg.GetSecret("sss"))
 
 	// Test Require, which panics for missing entries.
 	assert.Equal(t, "a string value", cfg.Require("sss"))
 	assert.Equal(t, true, cfg.RequireBool("bbb"))
 	assert.Equal(t, 42, cfg.RequireInt("intint"))
 	assert.Equal(t, 99.963, cfg.RequireFloat64("fpfpfp"))
+	assert.Equal(t, pulumi.NewSecret("a string value"), cfg.RequireSecret("sss"))
 	func() {
 		defer func() {
 			if r := recover(); r == nil {
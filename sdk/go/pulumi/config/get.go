@@ -129,3 +129,9 @@ func GetUint64(ctx *pulumi.Context, key string) uint64 {
 	}
 	return 0
 }
+
+// GetSecret loads an optional configuration value by its key, wrapped as a secret so that it is protected when it is
+// passed as a resource input, or returns a secret "" if it doesn't exist.
+func GetSecret(ctx *pulumi.Context, key string) pulumi.Secret {
+	return pulumi.NewSecret(Get(ctx, key))
+}
@@ -107,3 +107,9 @@ func RequireUint64(ctx *pulumi.Context, key string) uint64 {
 	v := Require(ctx, key)
 	return cast.ToUint64(v)
 }
+
+// RequireSecret loads a configuration value by its key, wrapped as a secret so that it is protected when it is passed
+// as a resource input, or panics if it doesn't exist.
+func RequireSecret(ctx *pulumi.Context, key string) pulumi.Secret {
+	return pulumi.NewSecret(Require(ctx, key))
+}
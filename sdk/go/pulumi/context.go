@@ -293,6 +293,13 @@ func (ctx *Context) RegisterResource(
 			Provider:             inputs.provider,
 			PropertyDependencies: inputs.rpcPropertyDeps,
 			DeleteBeforeReplace:  inputs.deleteBeforeReplace,
+			Aliases:              inputs.aliases,
+			IgnoreChanges:        inputs.ignoreChanges,
+			CustomTimeouts:       inputs.customTimeouts,
+			ImportId:             inputs.importID,
+			RetainOnDelete:       inputs.retainOnDelete,
+			RetryPolicy:          inputs.retryPolicy,
+			ReplaceOnChanges:     inputs.replaceOnChanges,
 		})
 		if err != nil {
 			glog.V(9).Infof("RegisterResource(%s, %s): error: %v", t, name, err)
@@ -412,13 +419,20 @@ type resourceInputs struct {
 	rpcProps            *structpb.Struct
 	rpcPropertyDeps     map[string]*pulumirpc.RegisterResourceRequest_PropertyDependencies
 	deleteBeforeReplace bool
+	aliases             []string
+	ignoreChanges       []string
+	customTimeouts      *pulumirpc.RegisterResourceRequest_CustomTimeouts
+	importID            string
+	retainOnDelete      bool
+	retryPolicy         *pulumirpc.RegisterResourceRequest_RetryPolicy
+	replaceOnChanges    []string
 }
 
 // prepareResourceInputs prepares the inputs for a resource operation, shared between read and register.
 func (ctx *Context) prepareResourceInputs(props map[string]interface{}, opts ...ResourceOpt) (*resourceInputs, error) {
 	// Get the parent and dependency URNs from the options, in addition to the protection bit.  If there wasn't an
 	// explicit parent, and a root stack resource exists, we will automatically parent to that.
-	parent, optDeps, protect, provider, deleteBeforeReplace, err := ctx.getOpts(opts...)
+	parent, optDeps, protect, provider, deleteBeforeReplace, aliases, err := ctx.getOpts(opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "resolving options")
 	}
@@ -458,6 +472,44 @@ func (ctx *Context) prepareResourceInputs(props map[string]interface{}, opts ...
 	}
 	sort.Strings(deps)
 
+	var rpcAliases []string
+	for _, alias := range aliases {
+		rpcAliases = append(rpcAliases, string(alias))
+	}
+
+	// Collect the property paths whose changes should be ignored or should force a replacement and the first set of
+	// custom timeouts, import ID, and retry policy, if any. The resource is retained on deletion if any of the options
+	// asks for it to be.
+	var ignoreChanges, replaceOnChanges []string
+	var customTimeouts *pulumirpc.RegisterResourceRequest_CustomTimeouts
+	var importID ID
+	var retainOnDelete bool
+	var retryPolicy *pulumirpc.RegisterResourceRequest_RetryPolicy
+	for _, opt := range opts {
+		ignoreChanges = append(ignoreChanges, opt.IgnoreChanges...)
+		replaceOnChanges = append(replaceOnChanges, opt.ReplaceOnChanges...)
+		retainOnDelete = retainOnDelete || opt.RetainOnDelete
+		if importID == "" {
+			importID = opt.Import
+		}
+		if customTimeouts == nil && opt.CustomTimeouts != nil {
+			customTimeouts = &pulumirpc.RegisterResourceRequest_CustomTimeouts{
+				Create: opt.CustomTimeouts.Create,
+				Update: opt.CustomTimeouts.Update,
+				Delete: opt.CustomTimeouts.Delete,
+			}
+		}
+		if retryPolicy == nil && opt.RetryPolicy != nil {
+			retryPolicy = &pulumirpc.RegisterResourceRequest_RetryPolicy{
+				MaxAttempts: int32(opt.RetryPolicy.MaxAttempts),
+				Delay:       opt.RetryPolicy.Delay,
+				MaxDelay:    opt.RetryPolicy.MaxDelay,
+				Backoff:     opt.RetryPolicy.Backoff,
+				Jitter:      opt.RetryPolicy.Jitter,
+			}
+		}
+	}
+
 	return &resourceInputs{
 		parent:              string(parent),
 		deps:                deps,
@@ -466,6 +518,13 @@ func (ctx *Context) prepareResourceInputs(props map[string]interface{}, opts ...
 		rpcProps:            rpcProps,
 		rpcPropertyDeps:     rpcPropertyDeps,
 		deleteBeforeReplace: deleteBeforeReplace,
+		aliases:             rpcAliases,
+		ignoreChanges:       ignoreChanges,
+		customTimeouts:      customTimeouts,
+		importID:            string(importID),
+		retainOnDelete:      retainOnDelete,
+		retryPolicy:         retryPolicy,
+		replaceOnChanges:    replaceOnChanges,
 	}, nil
 }
 
@@ -476,13 +535,15 @@ type resourceOutput struct {
 }
 
 // getOpts returns a set of resource options from an array of them. This includes the parent URN, any dependency URNs,
-// a boolean indicating whether the resource is to be protected, and the URN and ID of the resource's provider, if any.
-func (ctx *Context) getOpts(opts ...ResourceOpt) (URN, []URN, bool, string, bool, error) {
+// a boolean indicating whether the resource is to be protected, the URN and ID of the resource's provider, if any, a
+// boolean indicating whether the resource is to be deleted before replacement, and any aliases for the resource.
+func (ctx *Context) getOpts(opts ...ResourceOpt) (URN, []URN, bool, string, bool, []URN, error) {
 	var parent Resource
 	var deps []Resource
 	var protect bool
 	var provider ProviderResource
 	var deleteBeforeReplace bool
+	var aliases []URN
 	for _, opt := range opts {
 		if parent == nil && opt.Parent != nil {
 			parent = opt.Parent
@@ -499,6 +560,7 @@ func (ctx *Context) getOpts(opts ...ResourceOpt) (URN, []URN, bool, string, bool
 		if !deleteBeforeReplace && opt.DeleteBeforeReplace {
 			deleteBeforeReplace = true
 		}
+		aliases = append(aliases, opt.Aliases...)
 	}
 
 	var parentURN URN
@@ -507,7 +569,7 @@ func (ctx *Context) getOpts(opts ...ResourceOpt) (URN, []URN, bool, string, bool
 	} else {
 		urn, err := parent.URN().Value()
 		if err != nil {
-			return "", nil, false, "", false, err
+			return "", nil, false, "", false, nil, err
 		}
 		parentURN = urn
 	}
@@ -518,7 +580,7 @@ func (ctx *Context) getOpts(opts ...ResourceOpt) (URN, []URN, bool, string, bool
 		for i, r := range deps {
 			urn, err := r.URN().Value()
 			if err != nil {
-				return "", nil, false, "", false, err
+				return "", nil, false, "", false, nil, err
 			}
 			depURNs[i] = urn
 		}
@@ -528,12 +590,12 @@ func (ctx *Context) getOpts(opts ...ResourceOpt) (URN, []URN, bool, string, bool
 	if provider != nil {
 		pr, err := ctx.resolveProviderReference(provider)
 		if err != nil {
-			return "", nil, false, "", false, err
+			return "", nil, false, "", false, nil, err
 		}
 		providerRef = pr
 	}
 
-	return parentURN, depURNs, protect, providerRef, false, nil
+	return parentURN, depURNs, protect, providerRef, deleteBeforeReplace, aliases, nil
 }
 
 func (ctx *Context) resolveProviderReference(provider ProviderResource) (string, error) {
@@ -619,6 +619,17 @@ func (out *URNOutput) Apply(applier func(URN) (interface{}, error)) *Output {
 	})
 }
 
+// Secret wraps an input property value that should be treated as a secret.  The engine encrypts secret values when it
+// writes them to the stack's checkpoint and masks them in the display; providers still receive the underlying value.
+type Secret struct {
+	Value interface{} // the underlying input value.
+}
+
+// NewSecret wraps the given input property value, which may itself be an output, as a secret.
+func NewSecret(v interface{}) Secret {
+	return Secret{Value: v}
+}
+
 // toString attempts to convert v to a string.
 func toString(v interface{}) string {
 	if s := cast.ToString(v); s != "" {
@@ -62,6 +62,47 @@ type ResourceOpt struct {
 	Provider ProviderResource
 	// DeleteBeforeReplace, when set to true, ensures that this resource is deleted prior to replacement.
 	DeleteBeforeReplace bool
+	// Aliases is an optional list of URNs by which this resource was previously known. If an existing resource is
+	// found under one of these URNs, it is treated as the same resource rather than being replaced.
+	Aliases []URN
+	// IgnoreChanges is an optional list of property paths whose changes should be ignored when deciding whether or
+	// not this resource needs to be updated, e.g. "tags.owner" or "ports[0]".
+	IgnoreChanges []string
+	// CustomTimeouts is an optional configuration block used to override the default timeouts for this resource's
+	// create, update, and delete operations.
+	CustomTimeouts *CustomTimeouts
+	// Import, when provided with a resource ID, indicates that this resource's provider should import its state from
+	// the cloud resource with the given ID. The inputs to the resource's constructor must align with the resource's
+	// current state. Once a resource has been imported, the import property must be removed from the resource's
+	// options.
+	Import ID
+	// RetainOnDelete, when set to true, ensures that this resource is removed from the stack, rather than deleted,
+	// when it is deleted or replaced. The underlying cloud resource is left untouched.
+	RetainOnDelete bool
+	// RetryPolicy is an optional configuration block used to override the project's policy for retrying this
+	// resource's create, update, and delete operations after transient failures.
+	RetryPolicy *RetryPolicy
+	// ReplaceOnChanges is an optional list of property paths whose changes should force this resource to be replaced
+	// rather than updated in place, even if its provider would update it in place, e.g. "engineVersion".
+	ReplaceOnChanges []string
+}
+
+// RetryPolicy overrides how a resource's operations are retried after transient failures. Delays are duration strings
+// such as "500ms" or "1m"; zero values leave the corresponding settings of the project's policy unchanged.
+type RetryPolicy struct {
+	MaxAttempts int     // the maximum number of attempts for each operation.
+	Delay       string  // the delay before the first retry.
+	MaxDelay    string  // the maximum delay between retries.
+	Backoff     float64 // the multiplier applied to the delay after each retry.
+	Jitter      float64 // the fraction by which each delay is randomly varied.
+}
+
+// CustomTimeouts overrides the default timeouts for a resource's operations. Each timeout is a duration string such as
+// "5m" or "1h30m"; an empty string means that the corresponding operation is not subject to a timeout.
+type CustomTimeouts struct {
+	Create string
+	Update string
+	Delete string
 }
 
 // InvokeOpt contains optional settings that control an invoke's behavior.
@@ -108,6 +108,13 @@ func marshalInput(v interface{}) (interface{}, []Resource, error) {
 			"path":                t.Path(),
 			"uri":                 t.URI(),
 		}, nil, nil
+	case Secret:
+		return marshalInputSecret(t)
+	case *Secret:
+		if t == nil {
+			return nil, nil, nil
+		}
+		return marshalInputSecret(*t)
 	case Output:
 		return marshalInputOutput(&t)
 	case *Output:
@@ -178,6 +185,19 @@ func marshalInput(v interface{}) (interface{}, []Resource, error) {
 	return nil, nil, errors.Errorf("unrecognized input property type: %v (%v)", v, reflect.TypeOf(v))
 }
 
+// marshalInputSecret marshals a secret's underlying value and wraps it in the secret signature so that the engine knows
+// to protect it.
+func marshalInputSecret(secret Secret) (interface{}, []Resource, error) {
+	e, d, err := marshalInput(secret.Value)
+	if err != nil {
+		return nil, nil, err
+	}
+	return map[string]interface{}{
+		rpcTokenSpecialSigKey: rpcTokenSpecialSecretSig,
+		"value":               e,
+	}, d, nil
+}
+
 func marshalInputOutput(out *Output) (interface{}, []Resource, error) {
 	// Await the value and return its raw value.
 	ov, known, err := out.Value()
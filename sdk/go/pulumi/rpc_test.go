@@ -19,6 +19,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/go/pulumi/asset"
 )
 
@@ -88,6 +90,43 @@ func TestMarshalRoundtrip(t *testing.T) {
 	}
 }
 
+// TestMarshalSecret ensures that secret inputs are marshaled in a form that the engine recognizes as secret, and that
+// the dependencies of secret outputs are preserved.
+func TestMarshalSecret(t *testing.T) {
+	out, resolve, _ := NewOutput(nil)
+	resolve("hunter2", true)
+	input := map[string]interface{}{
+		"password": NewSecret("hunter2"),
+		"token":    NewSecret(out),
+		"nested":   map[string]interface{}{"key": NewSecret([]interface{}{"a", "b"})},
+		"username": "admin",
+	}
+
+	m, _, _, err := marshalInputs(input)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	props, err := plugin.UnmarshalProperties(m, plugin.MarshalOptions{KeepSecrets: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, resource.MakeSecret(resource.NewStringProperty("hunter2")), props["password"])
+	assert.Equal(t, resource.MakeSecret(resource.NewStringProperty("hunter2")), props["token"])
+	assert.Equal(t, resource.MakeSecret(resource.NewArrayProperty([]resource.PropertyValue{
+		resource.NewStringProperty("a"),
+		resource.NewStringProperty("b"),
+	})), props["nested"].ObjectValue()["key"])
+	assert.Equal(t, resource.NewStringProperty("admin"), props["username"])
+
+	// Programs that read outputs back see only the underlying values.
+	res, err := unmarshalOutputs(m)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "hunter2", res["password"])
+		assert.Equal(t, "hunter2", res["token"])
+	}
+}
+
 func TestUnmarshalUnsupportedSecret(t *testing.T) {
 	m, _, err := marshalInput(map[string]interface{}{
 		rpcTokenSpecialSigKey: rpcTokenSpecialSecretSig,
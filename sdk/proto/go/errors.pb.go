@@ -30,7 +30,7 @@ func (m *ErrorCause) Reset()         { *m = ErrorCause{} }
 func (m *ErrorCause) String() string { return proto.CompactTextString(m) }
 func (*ErrorCause) ProtoMessage()    {}
 func (*ErrorCause) Descriptor() ([]byte, []int) {
-	return fileDescriptor_errors_f003e48c15598612, []int{0}
+	return fileDescriptor_errors_809794ec86201991, []int{0}
 }
 func (m *ErrorCause) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ErrorCause.Unmarshal(m, b)
@@ -64,19 +64,63 @@ func (m *ErrorCause) GetStackTrace() string {
 	return ""
 }
 
+// ErrorRetryable may be attached to an error returned by a resource provider to indicate whether or not the failed
+// operation may succeed if it is retried, e.g. because the failure was due to throttling. A provider must only mark an
+// error as retryable if the failed operation left the resource unchanged.
+type ErrorRetryable struct {
+	Retryable            bool     `protobuf:"varint,1,opt,name=retryable" json:"retryable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ErrorRetryable) Reset()         { *m = ErrorRetryable{} }
+func (m *ErrorRetryable) String() string { return proto.CompactTextString(m) }
+func (*ErrorRetryable) ProtoMessage()    {}
+func (*ErrorRetryable) Descriptor() ([]byte, []int) {
+	return fileDescriptor_errors_809794ec86201991, []int{1}
+}
+func (m *ErrorRetryable) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ErrorRetryable.Unmarshal(m, b)
+}
+func (m *ErrorRetryable) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ErrorRetryable.Marshal(b, m, deterministic)
+}
+func (dst *ErrorRetryable) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErrorRetryable.Merge(dst, src)
+}
+func (m *ErrorRetryable) XXX_Size() int {
+	return xxx_messageInfo_ErrorRetryable.Size(m)
+}
+func (m *ErrorRetryable) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErrorRetryable.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ErrorRetryable proto.InternalMessageInfo
+
+func (m *ErrorRetryable) GetRetryable() bool {
+	if m != nil {
+		return m.Retryable
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*ErrorCause)(nil), "pulumirpc.ErrorCause")
+	proto.RegisterType((*ErrorRetryable)(nil), "pulumirpc.ErrorRetryable")
 }
 
-func init() { proto.RegisterFile("errors.proto", fileDescriptor_errors_f003e48c15598612) }
+func init() { proto.RegisterFile("errors.proto", fileDescriptor_errors_809794ec86201991) }
 
-var fileDescriptor_errors_f003e48c15598612 = []byte{
-	// 106 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xe2, 0xe2, 0x49, 0x2d, 0x2a, 0xca,
+var fileDescriptor_errors_809794ec86201991 = []byte{
+	// 131 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x49, 0x2d, 0x2a, 0xca,
 	0x2f, 0x2a, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x2c, 0x28, 0xcd, 0x29, 0xcd, 0xcd,
 	0x2c, 0x2a, 0x48, 0x56, 0x72, 0xe3, 0xe2, 0x72, 0x05, 0x49, 0x39, 0x27, 0x96, 0x16, 0xa7, 0x0a,
 	0x49, 0x70, 0xb1, 0xe7, 0xa6, 0x16, 0x17, 0x27, 0xa6, 0xa7, 0x4a, 0x30, 0x2a, 0x30, 0x6a, 0x70,
 	0x06, 0xc1, 0xb8, 0x42, 0x72, 0x5c, 0x5c, 0xc5, 0x25, 0x89, 0xc9, 0xd9, 0x21, 0x45, 0x89, 0xc9,
-	0xa9, 0x12, 0x4c, 0x60, 0x49, 0x24, 0x91, 0x24, 0x36, 0xb0, 0xc9, 0xc6, 0x80, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0xbe, 0x60, 0x19, 0xf9, 0x69, 0x00, 0x00, 0x00,
+	0xa9, 0x12, 0x4c, 0x60, 0x49, 0x24, 0x11, 0x25, 0x3d, 0x2e, 0x3e, 0xb0, 0x39, 0x41, 0xa9, 0x25,
+	0x45, 0x95, 0x89, 0x49, 0x39, 0xa9, 0x42, 0x32, 0x5c, 0x9c, 0x45, 0x30, 0x0e, 0xd8, 0x34, 0x8e,
+	0x20, 0x84, 0x40, 0x12, 0x1b, 0xd8, 0x25, 0xc6, 0x80, 0x01, 0x00, 0x36, 0x59, 0x43, 0x7f, 0x99,
+	0x00, 0x00, 0x00,
 }
@@ -43,7 +43,7 @@ func (m *ReadResourceRequest) Reset()         { *m = ReadResourceRequest{} }
 func (m *ReadResourceRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadResourceRequest) ProtoMessage()    {}
 func (*ReadResourceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_03e51d5764cd9ae8, []int{0}
+	return fileDescriptor_resource_bf41533ce8ce3f17, []int{0}
 }
 func (m *ReadResourceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadResourceRequest.Unmarshal(m, b)
@@ -125,7 +125,7 @@ func (m *ReadResourceResponse) Reset()         { *m = ReadResourceResponse{} }
 func (m *ReadResourceResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadResourceResponse) ProtoMessage()    {}
 func (*ReadResourceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_03e51d5764cd9ae8, []int{1}
+	return fileDescriptor_resource_bf41533ce8ce3f17, []int{1}
 }
 func (m *ReadResourceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadResourceResponse.Unmarshal(m, b)
@@ -171,6 +171,13 @@ type RegisterResourceRequest struct {
 	Provider             string                                                   `protobuf:"bytes,8,opt,name=provider" json:"provider,omitempty"`
 	PropertyDependencies map[string]*RegisterResourceRequest_PropertyDependencies `protobuf:"bytes,9,rep,name=propertyDependencies" json:"propertyDependencies,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	DeleteBeforeReplace  bool                                                     `protobuf:"varint,10,opt,name=deleteBeforeReplace" json:"deleteBeforeReplace,omitempty"`
+	Aliases              []string                                                 `protobuf:"bytes,11,rep,name=aliases" json:"aliases,omitempty"`
+	IgnoreChanges        []string                                                 `protobuf:"bytes,12,rep,name=ignoreChanges" json:"ignoreChanges,omitempty"`
+	CustomTimeouts       *RegisterResourceRequest_CustomTimeouts                  `protobuf:"bytes,13,opt,name=customTimeouts" json:"customTimeouts,omitempty"`
+	ImportId             string                                                   `protobuf:"bytes,14,opt,name=importId" json:"importId,omitempty"`
+	RetainOnDelete       bool                                                     `protobuf:"varint,15,opt,name=retainOnDelete" json:"retainOnDelete,omitempty"`
+	RetryPolicy          *RegisterResourceRequest_RetryPolicy                     `protobuf:"bytes,16,opt,name=retryPolicy" json:"retryPolicy,omitempty"`
+	ReplaceOnChanges     []string                                                 `protobuf:"bytes,17,rep,name=replaceOnChanges" json:"replaceOnChanges,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                                                 `json:"-"`
 	XXX_unrecognized     []byte                                                   `json:"-"`
 	XXX_sizecache        int32                                                    `json:"-"`
@@ -180,7 +187,7 @@ func (m *RegisterResourceRequest) Reset()         { *m = RegisterResourceRequest
 func (m *RegisterResourceRequest) String() string { return proto.CompactTextString(m) }
 func (*RegisterResourceRequest) ProtoMessage()    {}
 func (*RegisterResourceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_03e51d5764cd9ae8, []int{2}
+	return fileDescriptor_resource_bf41533ce8ce3f17, []int{2}
 }
 func (m *RegisterResourceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceRequest.Unmarshal(m, b)
@@ -270,6 +277,55 @@ func (m *RegisterResourceRequest) GetDeleteBeforeReplace() bool {
 	return false
 }
 
+func (m *RegisterResourceRequest) GetAliases() []string {
+	if m != nil {
+		return m.Aliases
+	}
+	return nil
+}
+
+func (m *RegisterResourceRequest) GetIgnoreChanges() []string {
+	if m != nil {
+		return m.IgnoreChanges
+	}
+	return nil
+}
+
+func (m *RegisterResourceRequest) GetCustomTimeouts() *RegisterResourceRequest_CustomTimeouts {
+	if m != nil {
+		return m.CustomTimeouts
+	}
+	return nil
+}
+
+func (m *RegisterResourceRequest) GetImportId() string {
+	if m != nil {
+		return m.ImportId
+	}
+	return ""
+}
+
+func (m *RegisterResourceRequest) GetRetainOnDelete() bool {
+	if m != nil {
+		return m.RetainOnDelete
+	}
+	return false
+}
+
+func (m *RegisterResourceRequest) GetRetryPolicy() *RegisterResourceRequest_RetryPolicy {
+	if m != nil {
+		return m.RetryPolicy
+	}
+	return nil
+}
+
+func (m *RegisterResourceRequest) GetReplaceOnChanges() []string {
+	if m != nil {
+		return m.ReplaceOnChanges
+	}
+	return nil
+}
+
 // PropertyDependencies describes the resources that a particular property depends on.
 type RegisterResourceRequest_PropertyDependencies struct {
 	Urns                 []string `protobuf:"bytes,1,rep,name=urns" json:"urns,omitempty"`
@@ -286,7 +342,7 @@ func (m *RegisterResourceRequest_PropertyDependencies) String() string {
 }
 func (*RegisterResourceRequest_PropertyDependencies) ProtoMessage() {}
 func (*RegisterResourceRequest_PropertyDependencies) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_03e51d5764cd9ae8, []int{2, 0}
+	return fileDescriptor_resource_bf41533ce8ce3f17, []int{2, 0}
 }
 func (m *RegisterResourceRequest_PropertyDependencies) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceRequest_PropertyDependencies.Unmarshal(m, b)
@@ -313,6 +369,136 @@ func (m *RegisterResourceRequest_PropertyDependencies) GetUrns() []string {
 	return nil
 }
 
+// CustomTimeouts allows a user to be explicit about the timeout behavior of their resources.
+type RegisterResourceRequest_CustomTimeouts struct {
+	Create               string   `protobuf:"bytes,1,opt,name=create" json:"create,omitempty"`
+	Update               string   `protobuf:"bytes,2,opt,name=update" json:"update,omitempty"`
+	Delete               string   `protobuf:"bytes,3,opt,name=delete" json:"delete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterResourceRequest_CustomTimeouts) Reset() {
+	*m = RegisterResourceRequest_CustomTimeouts{}
+}
+func (m *RegisterResourceRequest_CustomTimeouts) String() string {
+	return proto.CompactTextString(m)
+}
+func (*RegisterResourceRequest_CustomTimeouts) ProtoMessage() {}
+func (*RegisterResourceRequest_CustomTimeouts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_resource_bf41533ce8ce3f17, []int{2, 1}
+}
+func (m *RegisterResourceRequest_CustomTimeouts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterResourceRequest_CustomTimeouts.Unmarshal(m, b)
+}
+func (m *RegisterResourceRequest_CustomTimeouts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterResourceRequest_CustomTimeouts.Marshal(b, m, deterministic)
+}
+func (dst *RegisterResourceRequest_CustomTimeouts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterResourceRequest_CustomTimeouts.Merge(dst, src)
+}
+func (m *RegisterResourceRequest_CustomTimeouts) XXX_Size() int {
+	return xxx_messageInfo_RegisterResourceRequest_CustomTimeouts.Size(m)
+}
+func (m *RegisterResourceRequest_CustomTimeouts) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterResourceRequest_CustomTimeouts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterResourceRequest_CustomTimeouts proto.InternalMessageInfo
+
+func (m *RegisterResourceRequest_CustomTimeouts) GetCreate() string {
+	if m != nil {
+		return m.Create
+	}
+	return ""
+}
+
+func (m *RegisterResourceRequest_CustomTimeouts) GetUpdate() string {
+	if m != nil {
+		return m.Update
+	}
+	return ""
+}
+
+func (m *RegisterResourceRequest_CustomTimeouts) GetDelete() string {
+	if m != nil {
+		return m.Delete
+	}
+	return ""
+}
+
+// RetryPolicy allows a user to override how the resource's operations are retried after transient failures.
+type RegisterResourceRequest_RetryPolicy struct {
+	MaxAttempts          int32    `protobuf:"varint,1,opt,name=maxAttempts" json:"maxAttempts,omitempty"`
+	Delay                string   `protobuf:"bytes,2,opt,name=delay" json:"delay,omitempty"`
+	MaxDelay             string   `protobuf:"bytes,3,opt,name=maxDelay" json:"maxDelay,omitempty"`
+	Backoff              float64  `protobuf:"fixed64,4,opt,name=backoff" json:"backoff,omitempty"`
+	Jitter               float64  `protobuf:"fixed64,5,opt,name=jitter" json:"jitter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterResourceRequest_RetryPolicy) Reset()         { *m = RegisterResourceRequest_RetryPolicy{} }
+func (m *RegisterResourceRequest_RetryPolicy) String() string { return proto.CompactTextString(m) }
+func (*RegisterResourceRequest_RetryPolicy) ProtoMessage()    {}
+func (*RegisterResourceRequest_RetryPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_resource_bf41533ce8ce3f17, []int{2, 2}
+}
+func (m *RegisterResourceRequest_RetryPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterResourceRequest_RetryPolicy.Unmarshal(m, b)
+}
+func (m *RegisterResourceRequest_RetryPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterResourceRequest_RetryPolicy.Marshal(b, m, deterministic)
+}
+func (dst *RegisterResourceRequest_RetryPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterResourceRequest_RetryPolicy.Merge(dst, src)
+}
+func (m *RegisterResourceRequest_RetryPolicy) XXX_Size() int {
+	return xxx_messageInfo_RegisterResourceRequest_RetryPolicy.Size(m)
+}
+func (m *RegisterResourceRequest_RetryPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterResourceRequest_RetryPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterResourceRequest_RetryPolicy proto.InternalMessageInfo
+
+func (m *RegisterResourceRequest_RetryPolicy) GetMaxAttempts() int32 {
+	if m != nil {
+		return m.MaxAttempts
+	}
+	return 0
+}
+
+func (m *RegisterResourceRequest_RetryPolicy) GetDelay() string {
+	if m != nil {
+		return m.Delay
+	}
+	return ""
+}
+
+func (m *RegisterResourceRequest_RetryPolicy) GetMaxDelay() string {
+	if m != nil {
+		return m.MaxDelay
+	}
+	return ""
+}
+
+func (m *RegisterResourceRequest_RetryPolicy) GetBackoff() float64 {
+	if m != nil {
+		return m.Backoff
+	}
+	return 0
+}
+
+func (m *RegisterResourceRequest_RetryPolicy) GetJitter() float64 {
+	if m != nil {
+		return m.Jitter
+	}
+	return 0
+}
+
 // RegisterResourceResponse is returned by the engine after a resource has finished being initialized.  It includes the
 // auto-assigned URN, the provider-assigned ID, and any other properties initialized by the engine.
 type RegisterResourceResponse struct {
@@ -330,7 +516,7 @@ func (m *RegisterResourceResponse) Reset()         { *m = RegisterResourceRespon
 func (m *RegisterResourceResponse) String() string { return proto.CompactTextString(m) }
 func (*RegisterResourceResponse) ProtoMessage()    {}
 func (*RegisterResourceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_03e51d5764cd9ae8, []int{3}
+	return fileDescriptor_resource_bf41533ce8ce3f17, []int{3}
 }
 func (m *RegisterResourceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceResponse.Unmarshal(m, b)
@@ -398,7 +584,7 @@ func (m *RegisterResourceOutputsRequest) Reset()         { *m = RegisterResource
 func (m *RegisterResourceOutputsRequest) String() string { return proto.CompactTextString(m) }
 func (*RegisterResourceOutputsRequest) ProtoMessage()    {}
 func (*RegisterResourceOutputsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_03e51d5764cd9ae8, []int{4}
+	return fileDescriptor_resource_bf41533ce8ce3f17, []int{4}
 }
 func (m *RegisterResourceOutputsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceOutputsRequest.Unmarshal(m, b)
@@ -438,6 +624,8 @@ func init() {
 	proto.RegisterType((*RegisterResourceRequest)(nil), "pulumirpc.RegisterResourceRequest")
 	proto.RegisterMapType((map[string]*RegisterResourceRequest_PropertyDependencies)(nil), "pulumirpc.RegisterResourceRequest.PropertyDependenciesEntry")
 	proto.RegisterType((*RegisterResourceRequest_PropertyDependencies)(nil), "pulumirpc.RegisterResourceRequest.PropertyDependencies")
+	proto.RegisterType((*RegisterResourceRequest_CustomTimeouts)(nil), "pulumirpc.RegisterResourceRequest.CustomTimeouts")
+	proto.RegisterType((*RegisterResourceRequest_RetryPolicy)(nil), "pulumirpc.RegisterResourceRequest.RetryPolicy")
 	proto.RegisterType((*RegisterResourceResponse)(nil), "pulumirpc.RegisterResourceResponse")
 	proto.RegisterType((*RegisterResourceOutputsRequest)(nil), "pulumirpc.RegisterResourceOutputsRequest")
 }
@@ -613,46 +801,61 @@ var _ResourceMonitor_serviceDesc = grpc.ServiceDesc{
 	Metadata: "resource.proto",
 }
 
-func init() { proto.RegisterFile("resource.proto", fileDescriptor_resource_03e51d5764cd9ae8) }
-
-var fileDescriptor_resource_03e51d5764cd9ae8 = []byte{
-	// 601 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x9c, 0x55, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xae, 0x9d, 0xd6, 0x49, 0xa6, 0x55, 0xa8, 0xb6, 0x51, 0xeb, 0x1a, 0x54, 0x2a, 0x73, 0x01,
-	0x0e, 0x0e, 0x84, 0x43, 0x11, 0x42, 0x42, 0x42, 0xf4, 0xc0, 0xa1, 0x02, 0xcc, 0x19, 0x24, 0xc7,
-	0x9e, 0x46, 0xa6, 0x8e, 0x77, 0x59, 0xaf, 0x23, 0xe5, 0xc6, 0x9b, 0xf0, 0x48, 0xbc, 0x01, 0x27,
-	0x1e, 0x84, 0x5d, 0xaf, 0x1d, 0xe2, 0xd8, 0x69, 0x2a, 0x4e, 0x99, 0xbf, 0xfd, 0x76, 0xe6, 0x9b,
-	0x6f, 0x1d, 0x18, 0x70, 0xcc, 0x68, 0xce, 0x43, 0xf4, 0x18, 0xa7, 0x82, 0x92, 0x3e, 0xcb, 0x93,
-	0x7c, 0x16, 0x73, 0x16, 0x3a, 0xf7, 0xa7, 0x94, 0x4e, 0x13, 0x1c, 0x15, 0x89, 0x49, 0x7e, 0x3d,
-	0xc2, 0x19, 0x13, 0x0b, 0x5d, 0xe7, 0x3c, 0x58, 0x4f, 0x66, 0x82, 0xe7, 0xa1, 0x28, 0xb3, 0x03,
-	0xf9, 0x33, 0x8f, 0x23, 0xe4, 0xda, 0x77, 0x7f, 0x1b, 0x70, 0xe4, 0x63, 0x10, 0xf9, 0xe5, 0x65,
-	0x3e, 0x7e, 0xcf, 0x31, 0x13, 0x64, 0x00, 0x66, 0x1c, 0xd9, 0xc6, 0xb9, 0xf1, 0xb8, 0xef, 0x4b,
-	0x8b, 0x10, 0xd8, 0x15, 0x0b, 0x86, 0xb6, 0x59, 0x44, 0x0a, 0x5b, 0xc5, 0xd2, 0x60, 0x86, 0x76,
-	0x47, 0xc7, 0x94, 0x4d, 0x8e, 0xc1, 0x62, 0x01, 0xc7, 0x54, 0xd8, 0xbb, 0x45, 0xb4, 0xf4, 0xc8,
-	0x05, 0x80, 0xbc, 0x90, 0x21, 0x17, 0x31, 0x66, 0xf6, 0x9e, 0xcc, 0xed, 0x8f, 0x4f, 0x3c, 0xdd,
-	0xaa, 0x57, 0xb5, 0xea, 0x7d, 0x2e, 0x5a, 0xf5, 0x57, 0x4a, 0x89, 0x0b, 0x07, 0x11, 0x32, 0x4c,
-	0x23, 0x4c, 0x43, 0x75, 0xd4, 0x3a, 0xef, 0x48, 0xd8, 0x5a, 0x8c, 0x38, 0xd0, 0xab, 0xc6, 0xb2,
-	0xbb, 0xc5, 0xb5, 0x4b, 0xdf, 0x0d, 0x60, 0x58, 0x9f, 0x2f, 0x63, 0x34, 0xcd, 0x90, 0x1c, 0x42,
-	0x27, 0xe7, 0x69, 0x39, 0xa1, 0x32, 0xd7, 0x5a, 0x34, 0xef, 0xdc, 0xa2, 0xfb, 0x6b, 0x17, 0x4e,
-	0x7c, 0x9c, 0xc6, 0x99, 0x40, 0xbe, 0xce, 0x63, 0xc5, 0x9b, 0xd1, 0xc2, 0x9b, 0xd9, 0xca, 0x5b,
-	0xa7, 0xc6, 0x9b, 0x8c, 0x87, 0x79, 0x26, 0xe8, 0xac, 0xe0, 0xb3, 0xe7, 0x97, 0x1e, 0x19, 0x81,
-	0x45, 0x27, 0xdf, 0x30, 0x14, 0xdb, 0xb8, 0x2c, 0xcb, 0x88, 0x0d, 0x5d, 0x95, 0x52, 0x27, 0xac,
-	0x02, 0xa9, 0x72, 0x1b, 0x0c, 0x77, 0xb7, 0x30, 0xdc, 0xab, 0x33, 0x4c, 0x18, 0x0c, 0x4b, 0x32,
-	0x16, 0xef, 0x56, 0x71, 0xfa, 0x12, 0x67, 0x7f, 0xfc, 0xda, 0x5b, 0xea, 0xd6, 0xdb, 0x40, 0x92,
-	0xf7, 0xb1, 0xe5, 0xf8, 0x65, 0x2a, 0xf8, 0xc2, 0x6f, 0x45, 0x26, 0xcf, 0xe0, 0x28, 0xc2, 0x04,
-	0x05, 0xbe, 0xc5, 0x6b, 0xca, 0x25, 0x0c, 0x4b, 0x82, 0x10, 0x6d, 0x28, 0xe6, 0x6a, 0x4b, 0x39,
-	0x4f, 0x61, 0xd8, 0x76, 0x89, 0x5a, 0x85, 0x5c, 0x7d, 0x26, 0xd7, 0xa3, 0x66, 0x2e, 0x6c, 0xe7,
-	0x87, 0x01, 0xa7, 0x1b, 0x3b, 0x52, 0xba, 0xb9, 0xc1, 0x45, 0xa5, 0x1b, 0x69, 0x92, 0x2b, 0xd8,
-	0x9b, 0x07, 0x49, 0x8e, 0xa5, 0x64, 0x2e, 0xfe, 0x73, 0x60, 0x5f, 0xa3, 0xbc, 0x32, 0x5f, 0x1a,
-	0xee, 0x4f, 0x03, 0xec, 0xe6, 0xd9, 0x8d, 0xca, 0xd5, 0x8f, 0xd5, 0x5c, 0x3e, 0xd6, 0x7f, 0xe2,
-	0xe8, 0xdc, 0x4d, 0x1c, 0x52, 0x65, 0x99, 0x08, 0x26, 0x09, 0x56, 0x2a, 0xd3, 0x9e, 0x12, 0x8d,
-	0xb6, 0xd4, 0x93, 0x55, 0x0c, 0x55, 0xae, 0x8b, 0x70, 0xb6, 0xde, 0xe0, 0x87, 0x5c, 0xb0, 0x5c,
-	0x64, 0x95, 0xf2, 0x9b, 0x6d, 0x3e, 0x87, 0x2e, 0xd5, 0x35, 0xdb, 0x5e, 0x57, 0x55, 0x37, 0xfe,
-	0x63, 0xc2, 0xbd, 0x0a, 0xff, 0x8a, 0xa6, 0xb1, 0xa0, 0x9c, 0xbc, 0x01, 0xeb, 0x7d, 0x3a, 0xa7,
-	0x37, 0xb2, 0xbd, 0x15, 0xaa, 0x75, 0xa8, 0xbc, 0xdc, 0x39, 0x6d, 0xc9, 0x68, 0xfa, 0xdc, 0x1d,
-	0xf2, 0x09, 0x0e, 0x56, 0x3f, 0x09, 0xe4, 0xac, 0xb6, 0xb1, 0xc6, 0xb7, 0xd0, 0x79, 0xb8, 0x31,
-	0xbf, 0x84, 0xfc, 0x02, 0x87, 0xeb, 0x74, 0x10, 0x77, 0xbb, 0x10, 0x9c, 0x47, 0xb7, 0xd6, 0x2c,
-	0xe1, 0xbf, 0x36, 0x3f, 0x30, 0x25, 0xdb, 0xe4, 0xc9, 0x2d, 0x08, 0xf5, 0x8d, 0x38, 0xc7, 0x0d,
-	0xba, 0x2f, 0xd5, 0xff, 0x86, 0xbb, 0x33, 0xb1, 0x8a, 0xc8, 0x8b, 0xbf, 0x01, 0x00, 0x00, 0xff,
-	0xff, 0x00, 0xdf, 0xec, 0x9d, 0x74, 0x06, 0x00, 0x00,
+func init() { proto.RegisterFile("resource.proto", fileDescriptor_resource_bf41533ce8ce3f17) }
+
+var fileDescriptor_resource_bf41533ce8ce3f17 = []byte{
+	// 833 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x4f, 0x6f, 0xdc, 0x44,
+	0x14, 0xaf, 0xbd, 0x8d, 0x93, 0x7d, 0x9b, 0x6e, 0xc3, 0x34, 0x4a, 0xa6, 0x06, 0x95, 0x95, 0x41,
+	0x28, 0xf4, 0xe0, 0xd0, 0x70, 0x28, 0x42, 0x48, 0x08, 0x9a, 0x1e, 0x7a, 0xa8, 0x12, 0x06, 0x0e,
+	0x70, 0x00, 0x69, 0xd6, 0x7e, 0x59, 0xdc, 0xd8, 0x9e, 0x61, 0x3c, 0x8e, 0xea, 0x1b, 0xdf, 0x81,
+	0x2b, 0x12, 0xdf, 0x8c, 0x13, 0x1f, 0x04, 0xcd, 0x8c, 0xbd, 0x5d, 0xef, 0x9f, 0x64, 0xd5, 0xdb,
+	0xfc, 0xde, 0x9b, 0xf7, 0xe6, 0xbd, 0xdf, 0xcc, 0xfb, 0xd9, 0x30, 0x56, 0x58, 0x89, 0x5a, 0x25,
+	0x18, 0x4b, 0x25, 0xb4, 0x20, 0x43, 0x59, 0xe7, 0x75, 0x91, 0x29, 0x99, 0x84, 0x1f, 0xce, 0x84,
+	0x98, 0xe5, 0x78, 0x6a, 0x1d, 0xd3, 0xfa, 0xea, 0x14, 0x0b, 0xa9, 0x1b, 0xb7, 0x2f, 0xfc, 0x68,
+	0xd9, 0x59, 0x69, 0x55, 0x27, 0xba, 0xf5, 0x8e, 0xa5, 0x12, 0x37, 0x59, 0x8a, 0xca, 0xe1, 0xe8,
+	0x5f, 0x0f, 0x1e, 0x31, 0xe4, 0x29, 0x6b, 0x0f, 0x63, 0xf8, 0x47, 0x8d, 0x95, 0x26, 0x63, 0xf0,
+	0xb3, 0x94, 0x7a, 0x13, 0xef, 0x64, 0xc8, 0xfc, 0x2c, 0x25, 0x04, 0xee, 0xeb, 0x46, 0x22, 0xf5,
+	0xad, 0xc5, 0xae, 0x8d, 0xad, 0xe4, 0x05, 0xd2, 0x81, 0xb3, 0x99, 0x35, 0x39, 0x82, 0x40, 0x72,
+	0x85, 0xa5, 0xa6, 0xf7, 0xad, 0xb5, 0x45, 0xe4, 0x39, 0x80, 0x54, 0x42, 0xa2, 0xd2, 0x19, 0x56,
+	0x74, 0x67, 0xe2, 0x9d, 0x8c, 0xce, 0x8e, 0x63, 0x57, 0x6a, 0xdc, 0x95, 0x1a, 0xff, 0x68, 0x4b,
+	0x65, 0x0b, 0x5b, 0x49, 0x04, 0xfb, 0x29, 0x4a, 0x2c, 0x53, 0x2c, 0x13, 0x13, 0x1a, 0x4c, 0x06,
+	0x27, 0x43, 0xd6, 0xb3, 0x91, 0x10, 0xf6, 0xba, 0xb6, 0xe8, 0xae, 0x3d, 0x76, 0x8e, 0x23, 0x0e,
+	0x87, 0xfd, 0xfe, 0x2a, 0x29, 0xca, 0x0a, 0xc9, 0x01, 0x0c, 0x6a, 0x55, 0xb6, 0x1d, 0x9a, 0xe5,
+	0x52, 0x89, 0xfe, 0xd6, 0x25, 0x46, 0x7f, 0x0f, 0xe1, 0x98, 0xe1, 0x2c, 0xab, 0x34, 0xaa, 0x65,
+	0x1e, 0x3b, 0xde, 0xbc, 0x35, 0xbc, 0xf9, 0x6b, 0x79, 0x1b, 0xf4, 0x78, 0x3b, 0x82, 0x20, 0xa9,
+	0x2b, 0x2d, 0x0a, 0xcb, 0xe7, 0x1e, 0x6b, 0x11, 0x39, 0x85, 0x40, 0x4c, 0xdf, 0x60, 0xa2, 0xef,
+	0xe2, 0xb2, 0xdd, 0x46, 0x28, 0xec, 0x1a, 0x97, 0x89, 0x08, 0x6c, 0xa6, 0x0e, 0xae, 0x30, 0xbc,
+	0x7b, 0x07, 0xc3, 0x7b, 0x7d, 0x86, 0x89, 0x84, 0xc3, 0x96, 0x8c, 0xe6, 0x7c, 0x31, 0xcf, 0x70,
+	0x32, 0x38, 0x19, 0x9d, 0x7d, 0x13, 0xcf, 0xdf, 0x6d, 0xbc, 0x81, 0xa4, 0xf8, 0x72, 0x4d, 0xf8,
+	0xcb, 0x52, 0xab, 0x86, 0xad, 0xcd, 0x4c, 0xbe, 0x80, 0x47, 0x29, 0xe6, 0xa8, 0xf1, 0x7b, 0xbc,
+	0x12, 0x0a, 0x19, 0xca, 0x9c, 0x27, 0x48, 0xc1, 0xf6, 0xb5, 0xce, 0x65, 0xba, 0xe7, 0x79, 0xc6,
+	0x2b, 0xac, 0xe8, 0xc8, 0xb6, 0xd7, 0x41, 0xf2, 0x29, 0x3c, 0xc8, 0x66, 0xa5, 0x50, 0xf8, 0xe2,
+	0x77, 0x5e, 0xce, 0xb0, 0xa2, 0xfb, 0xd6, 0xdf, 0x37, 0x92, 0x5f, 0x60, 0xec, 0x88, 0xff, 0x29,
+	0x2b, 0x50, 0xd4, 0xba, 0xa2, 0x0f, 0x2c, 0xed, 0xcf, 0xb6, 0xe8, 0xee, 0x45, 0x2f, 0x90, 0x2d,
+	0x25, 0x32, 0xd4, 0x66, 0x85, 0x14, 0x4a, 0xbf, 0x4a, 0xe9, 0xd8, 0x51, 0xdb, 0x61, 0xf2, 0x99,
+	0x51, 0x01, 0xcd, 0xb3, 0xf2, 0xa2, 0x3c, 0xb7, 0x5d, 0xd1, 0x87, 0xb6, 0xc7, 0x25, 0x2b, 0xb9,
+	0x84, 0x91, 0x42, 0xad, 0x9a, 0x4b, 0x91, 0x67, 0x49, 0x43, 0x0f, 0x6c, 0x6d, 0xf1, 0x16, 0xb5,
+	0xb1, 0x77, 0x51, 0x6c, 0x31, 0x05, 0x79, 0x0a, 0x07, 0xca, 0x71, 0x77, 0x51, 0x76, 0xcc, 0x7c,
+	0x60, 0x99, 0x59, 0xb1, 0x87, 0x4f, 0xe1, 0x70, 0xdd, 0x0d, 0x9a, 0x77, 0x5e, 0xab, 0xb2, 0xa2,
+	0x9e, 0x8d, 0xb3, 0xeb, 0xf0, 0x67, 0x18, 0xf7, 0xf9, 0xb0, 0x2f, 0x5c, 0x21, 0xd7, 0xdd, 0x8c,
+	0xb4, 0xc8, 0xd8, 0x6b, 0x99, 0x72, 0xdd, 0xcd, 0x49, 0x8b, 0x8c, 0xdd, 0xdd, 0x70, 0x37, 0x29,
+	0x0e, 0x85, 0x7f, 0x79, 0x30, 0x5a, 0x68, 0x87, 0x4c, 0x60, 0x54, 0xf0, 0xb7, 0xdf, 0x69, 0x6d,
+	0xc4, 0xb1, 0xb2, 0xc9, 0x77, 0xd8, 0xa2, 0x89, 0x1c, 0xc2, 0x4e, 0x8a, 0x39, 0x6f, 0xda, 0x03,
+	0x1c, 0x30, 0xf7, 0x51, 0xf0, 0xb7, 0xe7, 0xd6, 0xe1, 0x4e, 0x98, 0x63, 0xf3, 0x8c, 0xa6, 0x3c,
+	0xb9, 0x16, 0x57, 0x57, 0x76, 0x1c, 0x3d, 0xd6, 0x41, 0x53, 0xd5, 0x9b, 0x4c, 0x6b, 0x54, 0x76,
+	0x1e, 0x3d, 0xd6, 0xa2, 0xf0, 0x4f, 0x0f, 0x1e, 0x6f, 0x7c, 0xde, 0x46, 0x84, 0xae, 0xb1, 0xe9,
+	0x44, 0xe8, 0x1a, 0x1b, 0xf2, 0x1a, 0x76, 0x6e, 0x78, 0x5e, 0x63, 0xab, 0x3f, 0xcf, 0xdf, 0x73,
+	0x7a, 0x98, 0xcb, 0xf2, 0xb5, 0xff, 0x95, 0x17, 0xfd, 0xe3, 0x01, 0x5d, 0x8d, 0xdd, 0x28, 0x83,
+	0x4e, 0xf9, 0xfd, 0xb9, 0xf2, 0xbf, 0x53, 0x9a, 0xc1, 0x76, 0x4a, 0x73, 0x04, 0x41, 0xa5, 0xf9,
+	0x34, 0xc7, 0x4e, 0xb2, 0x1c, 0x32, 0xe4, 0xb9, 0x95, 0xd1, 0x7f, 0x3b, 0x83, 0x2d, 0x8c, 0x10,
+	0x9e, 0x2c, 0x17, 0x78, 0x51, 0x6b, 0x69, 0xa6, 0xa5, 0x95, 0xd1, 0xd5, 0x32, 0x9f, 0xc1, 0xae,
+	0x70, 0x7b, 0xee, 0x92, 0xea, 0x6e, 0xdf, 0xd9, 0x7f, 0x3e, 0x3c, 0xec, 0xf2, 0xbf, 0x16, 0x65,
+	0xa6, 0x85, 0x22, 0xdf, 0x42, 0xf0, 0xaa, 0xbc, 0x11, 0xd7, 0x48, 0xe8, 0x02, 0xd5, 0xce, 0xd4,
+	0x1e, 0x1e, 0x3e, 0x5e, 0xe3, 0x71, 0xf4, 0x45, 0xf7, 0xc8, 0x0f, 0xb0, 0xbf, 0xf8, 0x7d, 0x21,
+	0x4f, 0x7a, 0x37, 0xb6, 0xf2, 0x61, 0x0d, 0x3f, 0xde, 0xe8, 0x9f, 0xa7, 0xfc, 0x15, 0x0e, 0x96,
+	0xe9, 0x20, 0xd1, 0xdd, 0x0f, 0x21, 0xfc, 0xe4, 0xd6, 0x3d, 0xf3, 0xf4, 0xbf, 0xc1, 0xf1, 0x06,
+	0xb6, 0xc9, 0xe7, 0xb7, 0x64, 0xe8, 0xdf, 0x48, 0x78, 0xb4, 0x42, 0xf7, 0x4b, 0xf3, 0x13, 0x12,
+	0xdd, 0x9b, 0x06, 0xd6, 0xf2, 0xe5, 0xff, 0x03, 0x00, 0xc9, 0x9c, 0x32, 0x20, 0xc1, 0x08, 0x00,
+	0x00,
 }
@@ -19,7 +19,7 @@ DESCRIPTOR = _descriptor.FileDescriptor(
   package='pulumirpc',
   syntax='proto3',
   serialized_options=None,
-  serialized_pb=_b('\n\x0c\x65rrors.proto\x12\tpulumirpc\"1\n\nErrorCause\x12\x0f\n\x07message\x18\x01 \x01(\t\x12\x12\n\nstackTrace\x18\x02 \x01(\tb\x06proto3')
+  serialized_pb=_b('\n\x0c\x65rrors.proto\x12\tpulumirpc\"1\n\nErrorCause\x12\x0f\n\x07message\x18\x01 \x01(\t\x12\x12\n\nstackTrace\x18\x02 \x01(\t\"#\n\x0e\x45rrorRetryable\x12\x11\n\tretryable\x18\x01 \x01(\x08\x62\x06proto3')
 )
 
 
@@ -62,7 +62,39 @@ _ERRORCAUSE = _descriptor.Descriptor(
   serialized_end=76,
 )
 
+
+_ERRORRETRYABLE = _descriptor.Descriptor(
+  name='ErrorRetryable',
+  full_name='pulumirpc.ErrorRetryable',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='retryable', full_name='pulumirpc.ErrorRetryable.retryable', index=0,
+      number=1, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=78,
+  serialized_end=113,
+)
+
 DESCRIPTOR.message_types_by_name['ErrorCause'] = _ERRORCAUSE
+DESCRIPTOR.message_types_by_name['ErrorRetryable'] = _ERRORRETRYABLE
 _sym_db.RegisterFileDescriptor(DESCRIPTOR)
 
 ErrorCause = _reflection.GeneratedProtocolMessageType('ErrorCause', (_message.Message,), dict(
@@ -72,5 +104,12 @@ ErrorCause = _reflection.GeneratedProtocolMessageType('ErrorCause', (_message.Me
   ))
 _sym_db.RegisterMessage(ErrorCause)
 
+ErrorRetryable = _reflection.GeneratedProtocolMessageType('ErrorRetryable', (_message.Message,), dict(
+  DESCRIPTOR = _ERRORRETRYABLE,
+  __module__ = 'errors_pb2'
+  # @@protoc_insertion_point(class_scope:pulumirpc.ErrorRetryable)
+  ))
+_sym_db.RegisterMessage(ErrorRetryable)
+
 
 # @@protoc_insertion_point(module_scope)